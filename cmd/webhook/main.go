@@ -27,6 +27,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/gcp/profiler"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/logging"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/metrics"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/ready"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/webhook"
 
@@ -52,13 +53,24 @@ func main() {
 
 	var enablePprof bool
 	var pprofPort int
+	var prometheusScrapeEndpoint string
+	var validationModeFlag map[string]string
 
 	profiler.AddFlag(flag.CommandLine)
 	flag.CommandLine.AddGoFlagSet(goflag.CommandLine)
 	flag.BoolVar(&enablePprof, "enable-pprof", false, "Enable the pprof server.")
 	flag.IntVar(&pprofPort, "pprof-port", 6060, "The port that the pprof server binds to if enabled.")
+	flag.StringVar(&prometheusScrapeEndpoint, "prometheus-scrape-endpoint", ":8888", "configure the Prometheus scrape endpoint; :8888 as default")
+	flag.StringToStringVar(&validationModeFlag, "validation-modes", nil, "the modes of the validating webhooks, e.g. 'immutable-fields=warn,unknown-fields=audit'; "+
+		"each of the 'immutable-fields', 'unknown-fields', 'iam-validation', 'resource-validation' and 'resource-policy-validation' webhooks can be in 'enforce', 'warn' or 'audit' mode; "+
+		"webhooks are in 'enforce' mode by default")
 	flag.Parse()
 
+	validationModes, err := webhook.ParseValidationModes(validationModeFlag)
+	if err != nil {
+		log.Fatalf("error parsing --validation-modes: %v", err)
+	}
+
 	// this enables packages using the kubernetes controller-runtime logging package to log
 	logging.SetupLogger()
 
@@ -76,6 +88,14 @@ func main() {
 		logging.Fatal(err, "error starting Cloud Profiler agent")
 	}
 
+	// Register the webhook OpenCensus views and the Prometheus exporter
+	if err := metrics.RegisterWebhookOpenCensusViews(); err != nil {
+		logging.Fatal(err, "error registering webhook OpenCensus views.")
+	}
+	if err := metrics.RegisterPrometheusExporter(prometheusScrapeEndpoint); err != nil {
+		logging.Fatal(err, "error registering the Prometheus exporter.")
+	}
+
 	// Get a config to talk to the apiserver
 	cfg, err := config.GetConfig()
 	if err != nil {
@@ -129,7 +149,7 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	if err := webhook.RegisterCommonWebhooks(mgr, nocacheClient); err != nil {
+	if err := webhook.RegisterCommonWebhooks(mgr, nocacheClient, validationModes); err != nil {
 		log.Fatalf("error adding the validating webhooks: %v", err)
 	}
 
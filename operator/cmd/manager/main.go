@@ -20,11 +20,13 @@ import (
 	"net/http"
 	_ "net/http/pprof" // Needed to allow pprof server to accept requests
 	"os"
+	"path/filepath"
 
 	corev1v1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/controllers/configconnector"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/controllers/configconnectorcontext"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/logging"
+	cnrmmanifest "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/manifest"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/kccmanager/nocache"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/gcp/profiler"
 
@@ -54,12 +56,18 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var repoPath string
+	var remoteRepoURL string
+	var remoteRepoDigest string
+	var remoteRepoCacheDir string
 	var enablePprof bool
 	var pprofPort int
 
 	flag.CommandLine.AddGoFlagSet(goflag.CommandLine)
 	profiler.AddFlag(flag.CommandLine)
 	flag.StringVar(&repoPath, "local-repo", "./channels", "location of local repository to use")
+	flag.StringVar(&remoteRepoURL, "remote-repo-url", "", "URL of a gzipped tarball of the repository to use instead of the local repository.")
+	flag.StringVar(&remoteRepoDigest, "remote-repo-digest", "", "Expected digest of the remote repository tarball, in the form 'sha256:<hex>'. Required if --remote-repo-url is set.")
+	flag.StringVar(&remoteRepoCacheDir, "remote-repo-cache-dir", filepath.Join(os.TempDir(), "cnrm-operator-repo"), "Directory where the remote repository is cached.")
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
@@ -100,12 +108,24 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = configconnector.Add(mgr, repoPath); err != nil {
+	var repo cnrmmanifest.Repository
+	if remoteRepoURL != "" {
+		setupLog.Info("using remote repository", "url", remoteRepoURL, "digest", remoteRepoDigest)
+		repo, err = cnrmmanifest.NewRemoteRepository(remoteRepoURL, remoteRepoDigest, remoteRepoCacheDir, nil)
+		if err != nil {
+			setupLog.Error(err, "unable to create the remote repository")
+			os.Exit(1)
+		}
+	} else {
+		repo = cnrmmanifest.NewLocalRepository(repoPath)
+	}
+
+	if err = configconnector.Add(mgr, repo); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ConfigConnector")
 		os.Exit(1)
 	}
 
-	if err = configconnectorcontext.Add(mgr, repoPath); err != nil {
+	if err = configconnectorcontext.Add(mgr, repo); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ConfigConnectorContext")
 		os.Exit(1)
 	}
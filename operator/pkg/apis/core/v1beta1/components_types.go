@@ -0,0 +1,132 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ConfigConnectorComponents configures the Config Connector system components deployed by the ConfigConnector object.
+type ConfigConnectorComponents struct {
+	// Configures the controller manager. This is only applied when running in cluster mode;
+	// in namespaced mode, use `components.controllerManager` in the ConfigConnectorContext object of each namespace.
+	ControllerManager *ControllerManagerComponentSpec `json:"controllerManager,omitempty"`
+
+	// Configures the webhook manager.
+	Webhook *WebhookComponentSpec `json:"webhook,omitempty"`
+
+	// Configures the resource stats recorder.
+	Recorder *ScalableComponentSpec `json:"recorder,omitempty"`
+
+	// Configures the deletion defender.
+	DeletionDefender *ComponentSpec `json:"deletionDefender,omitempty"`
+}
+
+// ConfigConnectorContextComponents configures the per-namespace components deployed by the ConfigConnectorContext object.
+type ConfigConnectorContextComponents struct {
+	// Configures the controller manager of the associated namespace.
+	ControllerManager *ControllerManagerComponentSpec `json:"controllerManager,omitempty"`
+}
+
+// ComponentSpec configures the scheduling and the compute resources of a Config Connector component.
+type ComponentSpec struct {
+	// Compute resources of the component's main container. Requests and limits that are set here
+	// replace the corresponding defaults; the defaults for unset resources are kept.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Node selector of the component's pods. This replaces the default node selector, if any.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the component's pods. This replaces the default tolerations, if any.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// ScalableComponentSpec configures a Config Connector component that can run more than one replica.
+type ScalableComponentSpec struct {
+	ComponentSpec `json:",inline"`
+
+	// Number of replicas of the component. For the webhook manager, which is autoscaled,
+	// this is the minimum number of replicas of its HorizontalPodAutoscaler.
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// WebhookComponentSpec configures the webhook manager.
+type WebhookComponentSpec struct {
+	ScalableComponentSpec `json:",inline"`
+
+	// The modes of the validating webhooks. Webhooks default to `enforce` mode.
+	ValidationModes *WebhookValidationModes `json:"validationModes,omitempty"`
+}
+
+// WebhookValidationModes configures what the validating webhooks do with the requests that they deny.
+// In `enforce` mode, the requests are denied. In `warn` mode, the requests are allowed and the reason they
+// would have been denied is returned as an admission warning. In `audit` mode, the requests are allowed and
+// the reason they would have been denied is only logged. Requests allowed in `warn` or `audit` mode are
+// counted in the `webhook_bypassed_denials_total` metric of the webhook manager.
+type WebhookValidationModes struct {
+	// The mode of the webhook rejecting updates to immutable fields.
+	// +kubebuilder:validation:Enum=enforce;warn;audit
+	ImmutableFields string `json:"immutableFields,omitempty"`
+
+	// The mode of the webhook rejecting unknown fields.
+	// +kubebuilder:validation:Enum=enforce;warn;audit
+	UnknownFields string `json:"unknownFields,omitempty"`
+
+	// The mode of the webhook validating IAM resources.
+	// +kubebuilder:validation:Enum=enforce;warn;audit
+	IAMValidation string `json:"iamValidation,omitempty"`
+
+	// The mode of the webhook validating the configuration of resources whose behavior can be overridden,
+	// e.g. with annotations.
+	// +kubebuilder:validation:Enum=enforce;warn;audit
+	ResourceValidation string `json:"resourceValidation,omitempty"`
+
+	// The mode of the webhook validating resources against ResourcePolicy objects.
+	// +kubebuilder:validation:Enum=enforce;warn;audit
+	ResourcePolicyValidation string `json:"resourcePolicyValidation,omitempty"`
+}
+
+// ControllerManagerComponentSpec configures the controller manager. The controller manager
+// always runs a single replica per scope, so the number of replicas cannot be configured.
+type ControllerManagerComponentSpec struct {
+	ComponentSpec `json:",inline"`
+
+	// Additional flags of the manager container, keyed by flag name, e.g. `--resource-name-label: "true"`.
+	// Only the following flags are allowed: `--resource-name-label`, `--billing-project`,
+	// `--user-project-override`, `--enable-pprof` and `--pprof-port`.
+	Flags map[string]string `json:"flags,omitempty"`
+}
+
+// ComponentStatus reports the readiness of a Config Connector component.
+type ComponentStatus struct {
+	// The name of the component, e.g. `cnrm-webhook-manager`.
+	Name string `json:"name"`
+
+	// The namespace watched by the component. Only set for the controller manager of a namespace in namespaced mode.
+	ScopedNamespace string `json:"scopedNamespace,omitempty"`
+
+	// The version of Config Connector that the component belongs to.
+	Version string `json:"version,omitempty"`
+
+	// Whether all the desired replicas of the component are ready.
+	Ready bool `json:"ready"`
+
+	// The desired number of replicas of the component.
+	Replicas int32 `json:"replicas"`
+
+	// The number of ready replicas of the component.
+	ReadyReplicas int32 `json:"readyReplicas"`
+}
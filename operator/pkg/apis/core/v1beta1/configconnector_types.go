@@ -16,6 +16,7 @@ package v1beta1
 
 import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	addonv1alpha1 "sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/addon/pkg/apis/v1alpha1"
 )
@@ -40,11 +41,77 @@ type ConfigConnectorSpec struct {
 	// When in namespaced mode, you must create a ConfigConnectorContext object per namespace that you want to enable Config Connector in, and each must set `googleServiceAccount` to specify the Google Service Account to be used to authenticate with Google Cloud APIs for the namespace.
 	//+kubebuilder:validation:Enum=cluster;namespaced
 	Mode string `json:"mode,omitempty"`
+
+	// Configures the resources, scheduling and flags of the Config Connector system components.
+	Components *ConfigConnectorComponents `json:"components,omitempty"`
+
+	// The version of Config Connector to install, e.g. '1.98.0'. It must be one of the versions bundled with the operator.
+	// If unset, the latest version of the stable channel is installed.
+	//+kubebuilder:validation:Pattern=`^[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?$`
+	Version string `json:"version,omitempty"`
+
+	// Rolls out another version of the controller manager to the namespaces whose ConfigConnectorContext objects
+	// match a label selector, before rolling it out to the rest of the namespaces. Only used in namespaced mode.
+	Canary *CanarySpec `json:"canary,omitempty"`
+
+	// Replaces the registry and repository path of the images of all the Config Connector components, including
+	// the per-namespace components in namespaced mode. The image names and their tags or digests are kept, e.g.
+	// 'registry.example.com/cnrm' turns 'gcr.io/gke-release/cnrm/webhook:1a2b3c' into 'registry.example.com/cnrm/webhook:1a2b3c'.
+	// The images must be mirrored to the registry beforehand.
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+
+	// The secrets used to pull the images of all the Config Connector components, including the per-namespace
+	// components in namespaced mode. The secrets must exist in the 'cnrm-system' namespace.
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+}
+
+// CanarySpec selects the namespaces that run a canary version of the controller manager.
+type CanarySpec struct {
+	// The version of the controller manager to roll out to the selected namespaces. It must be one of the versions
+	// bundled with the operator and have the same major version as the version installed for the rest of the cluster.
+	//+kubebuilder:validation:Pattern=`^[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?$`
+	Version string `json:"version"`
+
+	// Selects the ConfigConnectorContext objects, by label, of the namespaces that run the canary version.
+	Selector metav1.LabelSelector `json:"selector"`
 }
 
 // ConfigConnectorStatus defines the observed state of ConfigConnector
 type ConfigConnectorStatus struct {
 	addonv1alpha1.CommonStatus `json:",inline"`
+
+	// The version of Config Connector deployed by the most recent successful reconcile.
+	Version string `json:"version,omitempty"`
+
+	// The time of the most recent successful reconcile.
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// The readiness of the Config Connector system components, including the controller manager of each namespace
+	// in namespaced mode, as observed at the most recent successful reconcile.
+	Components []ComponentStatus `json:"components,omitempty"`
+
+	// The namespaces whose controller manager is not ready, in namespaced mode.
+	NotReadyNamespaces []string `json:"notReadyNamespaces,omitempty"`
+
+	// The progress of the migration steps executed by the operator to upgrade Config Connector
+	// across versions that declare migrations, e.g. major versions.
+	Migration *MigrationStatus `json:"migration,omitempty"`
+}
+
+// MigrationStatus records the migration steps executed to upgrade Config Connector from one version to another.
+type MigrationStatus struct {
+	// The version of Config Connector that was installed before the upgrade.
+	FromVersion string `json:"fromVersion"`
+
+	// The version of Config Connector that is being rolled out.
+	ToVersion string `json:"toVersion"`
+
+	// The names of the migration steps that have been executed successfully, in order of execution.
+	CompletedSteps []string `json:"completedSteps,omitempty"`
+
+	// Whether all the migration steps have been executed. The manifests of the new version are only
+	// rolled out once all the migration steps have been executed.
+	Completed bool `json:"completed,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -53,6 +120,7 @@ type ConfigConnectorStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Healthy",type=string,JSONPath=".status.healthy", description="When 'true' the most recent reconcile of the ConfigConnector object succeeded"
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=".status.version", description="The version of Config Connector deployed by the most recent successful reconcile"
 
 // ConfigConnector is the Schema for the configconnectors API
 type ConfigConnector struct {
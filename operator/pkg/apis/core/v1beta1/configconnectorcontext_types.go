@@ -41,17 +41,36 @@ type ConfigConnectorContextSpec struct {
 	// Specifies the project to use for preconditions, quota and billing.
 	// Should only be used when requestProjectPolicy is set to BILLING_PROJECT.
 	BillingProject string `json:"billingProject,omitempty"`
+
+	// Configures the resources, scheduling and flags of the per-namespace components.
+	Components *ConfigConnectorContextComponents `json:"components,omitempty"`
 }
 
 // ConfigConnectorContextStatus defines the observed state of ConfigConnectorContext
 type ConfigConnectorContextStatus struct {
 	addonv1alpha1.CommonStatus `json:",inline"`
+
+	// The version of the controller manager deployed by the most recent successful reconcile.
+	Version string `json:"version,omitempty"`
+
+	// The time of the most recent successful reconcile.
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// The readiness of the controller manager of the associated namespace, as observed at the most recent
+	// successful reconcile.
+	ControllerManager *ComponentStatus `json:"controllerManager,omitempty"`
+
+	// The latest available observations of the ConfigConnectorContext object's state.
+	// The `IdentityVerified` condition reports whether the Google Service Account exists
+	// and whether it has a Workload Identity binding for the namespace's Kubernetes Service Account.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Healthy",type=string,JSONPath=".status.healthy", description="When 'true' the most recent reconcile of the ConfigConnectorContext object succeeded"
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=".status.version", description="The version of the controller manager deployed by the most recent successful reconcile"
 
 // ConfigConnectorContext is the Schema for the ConfigConnectorContexts API
 type ConfigConnectorContext struct {
@@ -6,15 +6,82 @@
 package v1beta1
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanarySpec.
+func (in *CanarySpec) DeepCopy() *CanarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSpec.
+func (in *ComponentSpec) DeepCopy() *ComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigConnector) DeepCopyInto(out *ConfigConnector) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -36,12 +103,47 @@ func (in *ConfigConnector) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigConnectorComponents) DeepCopyInto(out *ConfigConnectorComponents) {
+	*out = *in
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(ControllerManagerComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Recorder != nil {
+		in, out := &in.Recorder, &out.Recorder
+		*out = new(ScalableComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionDefender != nil {
+		in, out := &in.DeletionDefender, &out.DeletionDefender
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigConnectorComponents.
+func (in *ConfigConnectorComponents) DeepCopy() *ConfigConnectorComponents {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigConnectorComponents)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigConnectorContext) DeepCopyInto(out *ConfigConnectorContext) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -63,6 +165,26 @@ func (in *ConfigConnectorContext) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigConnectorContextComponents) DeepCopyInto(out *ConfigConnectorContextComponents) {
+	*out = *in
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(ControllerManagerComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigConnectorContextComponents.
+func (in *ConfigConnectorContextComponents) DeepCopy() *ConfigConnectorContextComponents {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigConnectorContextComponents)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigConnectorContextList) DeepCopyInto(out *ConfigConnectorContextList) {
 	*out = *in
@@ -98,6 +220,11 @@ func (in *ConfigConnectorContextList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigConnectorContextSpec) DeepCopyInto(out *ConfigConnectorContextSpec) {
 	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = new(ConfigConnectorContextComponents)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigConnectorContextSpec.
@@ -114,6 +241,22 @@ func (in *ConfigConnectorContextSpec) DeepCopy() *ConfigConnectorContextSpec {
 func (in *ConfigConnectorContextStatus) DeepCopyInto(out *ConfigConnectorContextStatus) {
 	*out = *in
 	in.CommonStatus.DeepCopyInto(&out.CommonStatus)
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(ComponentStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigConnectorContextStatus.
@@ -162,6 +305,21 @@ func (in *ConfigConnectorList) DeepCopyObject() runtime.Object {
 func (in *ConfigConnectorSpec) DeepCopyInto(out *ConfigConnectorSpec) {
 	*out = *in
 	out.CommonSpec = in.CommonSpec
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = new(ConfigConnectorComponents)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanarySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigConnectorSpec.
@@ -178,6 +336,25 @@ func (in *ConfigConnectorSpec) DeepCopy() *ConfigConnectorSpec {
 func (in *ConfigConnectorStatus) DeepCopyInto(out *ConfigConnectorStatus) {
 	*out = *in
 	in.CommonStatus.DeepCopyInto(&out.CommonStatus)
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotReadyNamespaces != nil {
+		in, out := &in.NotReadyNamespaces, &out.NotReadyNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(MigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigConnectorStatus.
@@ -189,3 +366,103 @@ func (in *ConfigConnectorStatus) DeepCopy() *ConfigConnectorStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerManagerComponentSpec) DeepCopyInto(out *ControllerManagerComponentSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerManagerComponentSpec.
+func (in *ControllerManagerComponentSpec) DeepCopy() *ControllerManagerComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerManagerComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationStatus) DeepCopyInto(out *MigrationStatus) {
+	*out = *in
+	if in.CompletedSteps != nil {
+		in, out := &in.CompletedSteps, &out.CompletedSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationStatus.
+func (in *MigrationStatus) DeepCopy() *MigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalableComponentSpec) DeepCopyInto(out *ScalableComponentSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalableComponentSpec.
+func (in *ScalableComponentSpec) DeepCopy() *ScalableComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalableComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookComponentSpec) DeepCopyInto(out *WebhookComponentSpec) {
+	*out = *in
+	in.ScalableComponentSpec.DeepCopyInto(&out.ScalableComponentSpec)
+	if in.ValidationModes != nil {
+		in, out := &in.ValidationModes, &out.ValidationModes
+		*out = new(WebhookValidationModes)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookComponentSpec.
+func (in *WebhookComponentSpec) DeepCopy() *WebhookComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookValidationModes) DeepCopyInto(out *WebhookValidationModes) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookValidationModes.
+func (in *WebhookValidationModes) DeepCopy() *WebhookValidationModes {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookValidationModes)
+	in.DeepCopyInto(out)
+	return out
+}
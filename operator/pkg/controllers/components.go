@@ -0,0 +1,300 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative/pkg/manifest"
+)
+
+var containersPath = []string{"spec", "template", "spec", "containers"} // Path to container configurations in a StatefulSet or Deployment
+
+// GetComponentName returns the Config Connector component that the given object belongs to, if any.
+func GetComponentName(obj *manifest.Object) string {
+	return obj.UnstructuredObject().GetLabels()[k8s.KCCSystemComponentLabel]
+}
+
+// IsComponentWorkload returns true if the given object is the StatefulSet or Deployment of the given component.
+func IsComponentWorkload(obj *manifest.Object, kind, component string) bool {
+	return obj.Kind == kind && GetComponentName(obj) == component
+}
+
+// IsWebhookHorizontalPodAutoscaler returns true if the given object autoscales the webhook manager Deployment.
+func IsWebhookHorizontalPodAutoscaler(obj *manifest.Object) bool {
+	if obj.Kind != "HorizontalPodAutoscaler" {
+		return false
+	}
+	target, _, _ := unstructured.NestedString(obj.UnstructuredObject().Object, "spec", "scaleTargetRef", "name")
+	return target == k8s.KCCWebhookComponent
+}
+
+// ApplyComponentSpec applies the compute resources, node selector and tolerations in the given spec to
+// the given workload. The compute resources are applied to the container with the given name.
+func ApplyComponentSpec(obj *manifest.Object, containerName string, spec *corev1beta1.ComponentSpec) (*manifest.Object, error) {
+	u := obj.UnstructuredObject().DeepCopy()
+	if spec.Resources != nil {
+		if err := setContainerResources(u, containerName, spec); err != nil {
+			return nil, fmt.Errorf("error setting resources of container %v in %v %v: %w", containerName, u.GetKind(), u.GetName(), err)
+		}
+	}
+	if spec.NodeSelector != nil {
+		if err := unstructured.SetNestedStringMap(u.Object, spec.NodeSelector, "spec", "template", "spec", "nodeSelector"); err != nil {
+			return nil, fmt.Errorf("error setting nodeSelector in %v %v: %w", u.GetKind(), u.GetName(), err)
+		}
+	}
+	if spec.Tolerations != nil {
+		tolerations := make([]interface{}, 0, len(spec.Tolerations))
+		for i := range spec.Tolerations {
+			t, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec.Tolerations[i])
+			if err != nil {
+				return nil, fmt.Errorf("error converting toleration %v: %w", spec.Tolerations[i], err)
+			}
+			tolerations = append(tolerations, t)
+		}
+		if err := unstructured.SetNestedSlice(u.Object, tolerations, "spec", "template", "spec", "tolerations"); err != nil {
+			return nil, fmt.Errorf("error setting tolerations in %v %v: %w", u.GetKind(), u.GetName(), err)
+		}
+	}
+	return manifest.NewObject(u)
+}
+
+func setContainerResources(u *unstructured.Unstructured, containerName string, spec *corev1beta1.ComponentSpec) error {
+	containers, found, err := unstructured.NestedSlice(u.Object, containersPath...)
+	if err != nil || !found {
+		return fmt.Errorf("couldn't resolve containers: %w", err)
+	}
+	container, index, err := findContainer(containers, containerName)
+	if err != nil {
+		return err
+	}
+	for field, values := range map[string]map[string]string{
+		"requests": quantitiesToStrings(spec.Resources.Requests),
+		"limits":   quantitiesToStrings(spec.Resources.Limits),
+	} {
+		if len(values) == 0 {
+			continue
+		}
+		existing, _, err := unstructured.NestedStringMap(container, "resources", field)
+		if err != nil {
+			return fmt.Errorf("couldn't resolve resource %v: %w", field, err)
+		}
+		if existing == nil {
+			existing = make(map[string]string)
+		}
+		for name, value := range values {
+			existing[name] = value
+		}
+		if err := unstructured.SetNestedStringMap(container, existing, "resources", field); err != nil {
+			return fmt.Errorf("error setting resource %v: %w", field, err)
+		}
+	}
+	containers[index] = container
+	return unstructured.SetNestedSlice(u.Object, containers, containersPath...)
+}
+
+func quantitiesToStrings(list map[corev1.ResourceName]resource.Quantity) map[string]string {
+	res := make(map[string]string, len(list))
+	for name, q := range list {
+		res[string(name)] = q.String()
+	}
+	return res
+}
+
+// SetReplicas sets the number of replicas of the given workload.
+func SetReplicas(obj *manifest.Object, replicas int32) (*manifest.Object, error) {
+	u := obj.UnstructuredObject().DeepCopy()
+	if err := unstructured.SetNestedField(u.Object, int64(replicas), "spec", "replicas"); err != nil {
+		return nil, fmt.Errorf("error setting replicas in %v %v: %w", u.GetKind(), u.GetName(), err)
+	}
+	return manifest.NewObject(u)
+}
+
+// SetMinReplicas sets the minimum number of replicas of the given HorizontalPodAutoscaler, raising its
+// maximum number of replicas if needed.
+func SetMinReplicas(obj *manifest.Object, replicas int32) (*manifest.Object, error) {
+	u := obj.UnstructuredObject().DeepCopy()
+	if err := unstructured.SetNestedField(u.Object, int64(replicas), "spec", "minReplicas"); err != nil {
+		return nil, fmt.Errorf("error setting minReplicas in %v %v: %w", u.GetKind(), u.GetName(), err)
+	}
+	maxReplicas, found, err := unstructured.NestedInt64(u.Object, "spec", "maxReplicas")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't resolve maxReplicas in %v %v: %w", u.GetKind(), u.GetName(), err)
+	}
+	if found && maxReplicas < int64(replicas) {
+		if err := unstructured.SetNestedField(u.Object, int64(replicas), "spec", "maxReplicas"); err != nil {
+			return nil, fmt.Errorf("error setting maxReplicas in %v %v: %w", u.GetKind(), u.GetName(), err)
+		}
+	}
+	return manifest.NewObject(u)
+}
+
+// ApplyControllerManagerComponentSpec applies the given spec to the given controller manager StatefulSet.
+func ApplyControllerManagerComponentSpec(obj *manifest.Object, spec *corev1beta1.ControllerManagerComponentSpec) (*manifest.Object, error) {
+	processed, err := ApplyComponentSpec(obj, k8s.CNRMManagerContainerName, &spec.ComponentSpec)
+	if err != nil {
+		return nil, err
+	}
+	return ApplyManagerFlags(processed, spec.Flags)
+}
+
+// ApplyWebhookComponentSpec applies the given spec to the given webhook manager Deployment. The number of
+// replicas is not applied as it is managed by the HorizontalPodAutoscaler of the webhook manager.
+func ApplyWebhookComponentSpec(obj *manifest.Object, spec *corev1beta1.WebhookComponentSpec) (*manifest.Object, error) {
+	processed, err := ApplyComponentSpec(obj, k8s.CNRMWebhookContainerName, &spec.ComponentSpec)
+	if err != nil || spec.ValidationModes == nil {
+		return processed, err
+	}
+	modes := webhookValidationModesFlagValue(spec.ValidationModes)
+	if modes == "" {
+		return processed, nil
+	}
+	u := processed.UnstructuredObject().DeepCopy()
+	if err := SetFlagForContainer(u, k8s.CNRMWebhookContainerName, k8s.WebhookValidationModesFlag, modes); err != nil {
+		return nil, fmt.Errorf("error setting %v in Deployment %v: %w", k8s.WebhookValidationModesFlag, u.GetName(), err)
+	}
+	return manifest.NewObject(u)
+}
+
+// webhookValidationModesFlagValue returns the value of the validation modes flag of the webhook manager
+// for the given modes, e.g. 'immutable-fields=warn,unknown-fields=audit'.
+func webhookValidationModesFlagValue(modes *corev1beta1.WebhookValidationModes) string {
+	values := make([]string, 0)
+	for _, m := range []struct {
+		webhook string
+		mode    string
+	}{
+		{"iam-validation", modes.IAMValidation},
+		{"immutable-fields", modes.ImmutableFields},
+		{"resource-policy-validation", modes.ResourcePolicyValidation},
+		{"resource-validation", modes.ResourceValidation},
+		{"unknown-fields", modes.UnknownFields},
+	} {
+		if m.mode != "" {
+			values = append(values, m.webhook+"="+m.mode)
+		}
+	}
+	return strings.Join(values, ",")
+}
+
+// ValidateManagerFlags returns an error if any of the given flags cannot be set on the manager container.
+func ValidateManagerFlags(flags map[string]string) error {
+	for flag := range flags {
+		if !k8s.AllowedManagerFlags[flag] {
+			return fmt.Errorf("flag %v is not allowed for the controller manager; allowed flags are %v", flag, allowedManagerFlagNames())
+		}
+	}
+	return nil
+}
+
+func allowedManagerFlagNames() []string {
+	names := make([]string, 0, len(k8s.AllowedManagerFlags))
+	for flag := range k8s.AllowedManagerFlags {
+		names = append(names, flag)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyManagerFlags sets the given flags on the manager container of the given controller manager StatefulSet.
+func ApplyManagerFlags(obj *manifest.Object, flags map[string]string) (*manifest.Object, error) {
+	if err := ValidateManagerFlags(flags); err != nil {
+		return nil, err
+	}
+	u := obj.UnstructuredObject().DeepCopy()
+	names := make([]string, 0, len(flags))
+	for flag := range flags {
+		names = append(names, flag)
+	}
+	sort.Strings(names)
+	for _, flag := range names {
+		if err := SetFlagForManagerContainer(u, flag, flags[flag]); err != nil {
+			return nil, fmt.Errorf("error setting %v in StatefulSet %v: %w", flag, u.GetName(), err)
+		}
+	}
+	return manifest.NewObject(u)
+}
+
+func findContainer(containers []interface{}, containerName string) (container map[string]interface{}, index int, err error) {
+	for i, c := range containers {
+		containerAsMap, ok := c.(map[string]interface{})
+		if !ok {
+			return nil, 0, fmt.Errorf("couldn't convert container configuration %v to a map", c)
+		}
+		name, found, err := unstructured.NestedString(containerAsMap, "name")
+		if err != nil || !found {
+			return nil, 0, fmt.Errorf("couldn't resolve name of container configuration %v: %v", c, err)
+		}
+		if name == containerName {
+			return containerAsMap, i, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("no %v container found", containerName)
+}
+
+// SetFlagForManagerContainer is a helper method to add optional flags for manager container.
+func SetFlagForManagerContainer(u *unstructured.Unstructured, flag string, flagValue string) error {
+	return SetFlagForContainer(u, k8s.CNRMManagerContainerName, flag, flagValue)
+}
+
+// SetFlagForContainer sets the given flag on the container with the given name, replacing its existing value, if any.
+func SetFlagForContainer(u *unstructured.Unstructured, containerName string, flag string, flagValue string) error {
+	containers, found, err := unstructured.NestedSlice(u.Object, containersPath...)
+	if err != nil || !found {
+		return fmt.Errorf("couldn't resolve containers: %w", err)
+	}
+
+	container, index, err := findContainer(containers, containerName)
+	if err != nil {
+		return fmt.Errorf("error finding %v container: %v", containerName, err)
+	}
+	args, found, err := unstructured.NestedStringSlice(container, "args")
+	if err != nil {
+		return fmt.Errorf("couldn't resolve args of %v container %v: %w", containerName, container, err)
+	}
+	if !found {
+		args = make([]string, 0)
+	}
+	newArgs := removeFlagFromArgs(args, flag)
+	newArgs = append(newArgs, flag+"="+flagValue)
+	if err := unstructured.SetNestedStringSlice(container, newArgs, "args"); err != nil {
+		return fmt.Errorf("error setting args in %v container: %v", containerName, err)
+	}
+
+	containers[index] = container
+	if err := unstructured.SetNestedSlice(u.Object, containers, containersPath...); err != nil {
+		return fmt.Errorf("error setting containers: %v", err)
+	}
+	return nil
+}
+
+func removeFlagFromArgs(args []string, flag string) []string {
+	newArgs := make([]string, 0)
+	for _, a := range args {
+		if !strings.HasPrefix(a, flag) {
+			newArgs = append(newArgs, a)
+		}
+	}
+	return newArgs
+}
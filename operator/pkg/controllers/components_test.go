@@ -0,0 +1,277 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	testcontroller "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/test/controller"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative/pkg/manifest"
+)
+
+var controllerManagerStatefulSet = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  labels:
+    cnrm.cloud.google.com/component: cnrm-controller-manager
+    cnrm.cloud.google.com/system: "true"
+  name: cnrm-controller-manager
+  namespace: cnrm-system
+spec:
+  serviceName: cnrm-manager
+  template:
+    spec:
+      containers:
+      - args:
+        - --prometheus-scrape-endpoint=:8888
+        name: manager
+        resources:
+          limits:
+            memory: 512Mi
+          requests:
+            cpu: 100m
+            memory: 512Mi
+      - name: prom-to-sd
+`
+
+var webhookHorizontalPodAutoscaler = `
+apiVersion: autoscaling/v1
+kind: HorizontalPodAutoscaler
+metadata:
+  name: cnrm-webhook
+  namespace: cnrm-system
+spec:
+  maxReplicas: 20
+  minReplicas: 2
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: cnrm-webhook-manager
+`
+
+var controllerManagerStatefulSetWithComponentSpec = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  labels:
+    cnrm.cloud.google.com/component: cnrm-controller-manager
+    cnrm.cloud.google.com/system: "true"
+  name: cnrm-controller-manager
+  namespace: cnrm-system
+spec:
+  serviceName: cnrm-manager
+  template:
+    spec:
+      containers:
+      - args:
+        - --prometheus-scrape-endpoint=:8888
+        - --billing-project=foo-project
+        - --resource-name-label=true
+        name: manager
+        resources:
+          limits:
+            memory: 1Gi
+          requests:
+            cpu: 500m
+            memory: 512Mi
+      - name: prom-to-sd
+      nodeSelector:
+        pool: system
+      tolerations:
+      - effect: NoSchedule
+        key: dedicated
+        operator: Equal
+        value: cnrm
+`
+
+var webhookDeploymentWithValidationModes = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    cnrm.cloud.google.com/component: cnrm-webhook-manager
+    cnrm.cloud.google.com/system: "true"
+  name: cnrm-webhook-manager
+  namespace: cnrm-system
+spec:
+  template:
+    spec:
+      containers:
+      - args:
+        - --prometheus-scrape-endpoint=:8888
+        - --validation-modes=unknown-fields=warn
+        name: webhook
+        resources:
+          limits:
+            memory: 128Mi
+          requests:
+            cpu: 250m
+            memory: 128Mi
+`
+
+var webhookDeploymentWithComponentSpec = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    cnrm.cloud.google.com/component: cnrm-webhook-manager
+    cnrm.cloud.google.com/system: "true"
+  name: cnrm-webhook-manager
+  namespace: cnrm-system
+spec:
+  template:
+    spec:
+      containers:
+      - args:
+        - --prometheus-scrape-endpoint=:8888
+        - --validation-modes=iam-validation=enforce,immutable-fields=warn,resource-policy-validation=warn,resource-validation=audit
+        name: webhook
+        resources:
+          limits:
+            memory: 256Mi
+          requests:
+            cpu: 250m
+            memory: 128Mi
+`
+
+func parseObject(t *testing.T, obj string) *manifest.Object {
+	m := testcontroller.ParseObjects(t, context.TODO(), []string{obj})
+	if len(m.Items) != 1 {
+		t.Fatalf("expected 1 object, got %v", len(m.Items))
+	}
+	return m.Items[0]
+}
+
+func TestApplyControllerManagerComponentSpec(t *testing.T) {
+	spec := &corev1beta1.ControllerManagerComponentSpec{
+		ComponentSpec: corev1beta1.ComponentSpec{
+			Resources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+			},
+			NodeSelector: map[string]string{"pool": "system"},
+			Tolerations: []corev1.Toleration{
+				{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "cnrm",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+		},
+		Flags: map[string]string{
+			"--resource-name-label": "true",
+			"--billing-project":     "foo-project",
+		},
+	}
+	processed, err := ApplyControllerManagerComponentSpec(parseObject(t, controllerManagerStatefulSet), spec)
+	if err != nil {
+		t.Fatalf("error applying component spec: %v", err)
+	}
+	expected := testcontroller.ToUnstructured(t, controllerManagerStatefulSetWithComponentSpec)
+	if diff := cmp.Diff(expected.Object, processed.UnstructuredObject().Object); diff != "" {
+		t.Fatalf("unexpected diff: %v", diff)
+	}
+}
+
+func TestApplyWebhookComponentSpec(t *testing.T) {
+	replicas := int32(3)
+	spec := &corev1beta1.WebhookComponentSpec{
+		ScalableComponentSpec: corev1beta1.ScalableComponentSpec{
+			ComponentSpec: corev1beta1.ComponentSpec{
+				Resources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+			},
+			Replicas: &replicas,
+		},
+		ValidationModes: &corev1beta1.WebhookValidationModes{
+			ImmutableFields:          "warn",
+			IAMValidation:            "enforce",
+			ResourceValidation:       "audit",
+			ResourcePolicyValidation: "warn",
+		},
+	}
+	processed, err := ApplyWebhookComponentSpec(parseObject(t, webhookDeploymentWithValidationModes), spec)
+	if err != nil {
+		t.Fatalf("error applying component spec: %v", err)
+	}
+	expected := testcontroller.ToUnstructured(t, webhookDeploymentWithComponentSpec)
+	if diff := cmp.Diff(expected.Object, processed.UnstructuredObject().Object); diff != "" {
+		t.Fatalf("unexpected diff: %v", diff)
+	}
+}
+
+func TestApplyManagerFlagsRejectsFlagsNotAllowed(t *testing.T) {
+	flags := map[string]string{"--scoped-namespace": "foo"}
+	if _, err := ApplyManagerFlags(parseObject(t, controllerManagerStatefulSet), flags); err == nil {
+		t.Fatalf("expected an error, got nil")
+	}
+}
+
+func TestSetMinReplicas(t *testing.T) {
+	tests := []struct {
+		name        string
+		replicas    int32
+		minReplicas int64
+		maxReplicas int64
+	}{
+		{
+			name:        "below max replicas",
+			replicas:    3,
+			minReplicas: 3,
+			maxReplicas: 20,
+		},
+		{
+			name:        "above max replicas",
+			replicas:    25,
+			minReplicas: 25,
+			maxReplicas: 25,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			obj := parseObject(t, webhookHorizontalPodAutoscaler)
+			if !IsWebhookHorizontalPodAutoscaler(obj) {
+				t.Fatalf("expected the object to be the webhook HorizontalPodAutoscaler")
+			}
+			processed, err := SetMinReplicas(obj, tc.replicas)
+			if err != nil {
+				t.Fatalf("error setting min replicas: %v", err)
+			}
+			spec := processed.UnstructuredObject().Object["spec"].(map[string]interface{})
+			if got := spec["minReplicas"]; got != tc.minReplicas {
+				t.Errorf("got minReplicas %v, want %v", got, tc.minReplicas)
+			}
+			if got := spec["maxReplicas"]; got != tc.maxReplicas {
+				t.Errorf("got maxReplicas %v, want %v", got, tc.maxReplicas)
+			}
+		})
+	}
+}
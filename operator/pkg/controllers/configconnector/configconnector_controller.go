@@ -23,6 +23,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/controllers"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
 	cnrmmanifest "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/manifest"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/migration"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/preflight"
 	corekcck8s "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
 
@@ -43,6 +44,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/addon/pkg/apis/v1alpha1"
 	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative"
@@ -61,11 +63,12 @@ type ConfigConnectorReconciler struct {
 	client     client.Client
 	recorder   record.EventRecorder
 	labelMaker declarative.LabelMaker
+	migrator   *migration.Migrator
 	log        logr.Logger
 }
 
-func Add(mgr ctrl.Manager, repoPath string) error {
-	r, err := newReconciler(mgr, repoPath)
+func Add(mgr ctrl.Manager, repo cnrmmanifest.Repository) error {
+	r, err := newReconciler(mgr, repo)
 	if err != nil {
 		return err
 	}
@@ -76,7 +79,8 @@ func Add(mgr ctrl.Manager, repoPath string) error {
 		ControllerManagedBy(mgr).
 		Named(controllerName).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 1}).
-		For(obj, builder.OnlyMetadata).
+		// Only changes to the spec trigger a reconciliation, as the status is updated on every reconciliation.
+		For(obj, builder.OnlyMetadata, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Build(r)
 	if err != nil {
 		return err
@@ -85,8 +89,7 @@ func Add(mgr ctrl.Manager, repoPath string) error {
 	return nil
 }
 
-func newReconciler(mgr ctrl.Manager, repoPath string) (*ConfigConnectorReconciler, error) {
-	repo := cnrmmanifest.NewLocalRepository(repoPath)
+func newReconciler(mgr ctrl.Manager, repo cnrmmanifest.Repository) (*ConfigConnectorReconciler, error) {
 	manifestLoader := cnrmmanifest.NewManifestLoader(repo)
 	preflight := preflight.NewCompositePreflight([]declarative.Preflight{
 		preflight.NewNameChecker(mgr.GetClient(), k8s.ConfigConnectorAllowedName),
@@ -98,6 +101,7 @@ func newReconciler(mgr ctrl.Manager, repoPath string) (*ConfigConnectorReconcile
 		client:     mgr.GetClient(),
 		recorder:   mgr.GetEventRecorderFor(controllerName),
 		labelMaker: declarative.SourceLabel(mgr.GetScheme()),
+		migrator:   migration.NewMigrator(mgr.GetClient(), repo),
 		log:        ctrl.Log.WithName(controllerName),
 	}
 
@@ -106,7 +110,10 @@ func newReconciler(mgr ctrl.Manager, repoPath string) (*ConfigConnectorReconcile
 		declarative.WithPreserveNamespace(),
 		declarative.WithManifestController(manifestLoader),
 		declarative.WithOwner(declarative.SourceAsOwner),
+		declarative.WithObjectTransform(r.handleMigrations()),
 		declarative.WithObjectTransform(r.transformForClusterMode()),
+		declarative.WithObjectTransform(r.transformComponents()),
+		declarative.WithObjectTransform(r.transformImages()),
 		declarative.WithObjectTransform(r.handleConfigConnectorLifecycle()),
 		declarative.WithStatus(&declarative.StatusBuilder{
 			PreflightImpl: preflight,
@@ -132,7 +139,15 @@ func (r *ConfigConnectorReconciler) Reconcile(ctx context.Context, req reconcile
 		return reconcile.Result{}, reconciliationErr
 	}
 	r.log.Info("successfully finished reconcile", "ConfigConnector", req.Name)
-	return reconcile.Result{RequeueAfter: corekcck8s.MeanReconcileReenqueuePeriod}, r.handleReconcileSucceeded(ctx, req.NamespacedName)
+	ready, err := r.handleReconcileSucceeded(ctx, req.NamespacedName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !ready {
+		r.log.Info("Config Connector components are not ready yet; polling their rollout status", "ConfigConnector", req.Name)
+		return reconcile.Result{RequeueAfter: controllers.RolloutPollPeriod}, nil
+	}
+	return reconcile.Result{RequeueAfter: corekcck8s.MeanReconcileReenqueuePeriod}, nil
 }
 
 func (r *ConfigConnectorReconciler) handleReconcileFailed(ctx context.Context, nn types.NamespacedName, reconcileErr error) error {
@@ -151,24 +166,56 @@ func (r *ConfigConnectorReconciler) handleReconcileFailed(ctx context.Context, n
 		Healthy: false,
 		Errors:  []string{msg},
 	})
+	// The components deployed by previous reconciliations are still running, so their status is
+	// surfaced along with the error.
+	if err := r.setRolloutStatus(ctx, cc); err != nil {
+		r.log.Info("error getting the rollout status of the ConfigConnector object", "name", nn.Name, "error", err)
+	}
 	return r.updateConfigConnectorStatus(ctx, cc)
 }
 
-func (r *ConfigConnectorReconciler) handleReconcileSucceeded(ctx context.Context, nn types.NamespacedName) error {
+// handleReconcileSucceeded updates the status of the ConfigConnector object after a successful
+// reconciliation, and returns whether all the Config Connector components are ready.
+func (r *ConfigConnectorReconciler) handleReconcileSucceeded(ctx context.Context, nn types.NamespacedName) (bool, error) {
 	cc, err := controllers.GetConfigConnector(ctx, r.client, nn)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			r.log.Info("ConfigConnector not found in API server; skipping the handling of successful reconciliation", "name", nn.Name)
-			return nil
+			return true, nil
 		}
-		return fmt.Errorf("error getting ConfigConnector object %v: %v", nn.Name, err)
+		return false, fmt.Errorf("error getting ConfigConnector object %v: %v", nn.Name, err)
 	}
 	r.recordEvent(cc, corev1.EventTypeNormal, k8s.UpToDate, k8s.UpToDateMessage)
 	cc.SetCommonStatus(v1alpha1.CommonStatus{
 		Healthy: true,
 		Errors:  []string{},
 	})
-	return r.updateConfigConnectorStatus(ctx, cc)
+	if err := r.setRolloutStatus(ctx, cc); err != nil {
+		return false, err
+	}
+	now := metav1.Now()
+	cc.Status.LastReconcileTime = &now
+	if err := r.updateConfigConnectorStatus(ctx, cc); err != nil {
+		return false, err
+	}
+	return controllers.AllComponentsReady(cc.Status.Components), nil
+}
+
+// setRolloutStatus records the deployed version and the readiness of the Config Connector system components
+// in the status of the given ConfigConnector object.
+func (r *ConfigConnectorReconciler) setRolloutStatus(ctx context.Context, cc *corev1beta1.ConfigConnector) error {
+	version, err := controllers.GetDeployedVersion(ctx, r.client)
+	if err != nil {
+		return err
+	}
+	components, err := controllers.GetComponentStatuses(ctx, r.client, client.MatchingLabels{k8s.KCCSystemLabelSelectorRaw: "true"})
+	if err != nil {
+		return fmt.Errorf("error getting the status of Config Connector components: %w", err)
+	}
+	cc.Status.Version = version
+	cc.Status.Components = components
+	cc.Status.NotReadyNamespaces = controllers.GetNotReadyNamespaces(components)
+	return nil
 }
 
 // Handle the lifecycle of the given components under different conditions:
@@ -232,6 +279,24 @@ func (r *ConfigConnectorReconciler) handleConfigConnectorLifecycle() declarative
 	}
 }
 
+// handleMigrations executes the migration steps declared by the version to deploy, if any, before
+// its manifests are rolled out.
+func (r *ConfigConnectorReconciler) handleMigrations() declarative.ObjectTransform {
+	return func(ctx context.Context, o declarative.DeclarativeObject, m *manifest.Objects) error {
+		cc, ok := o.(*corev1beta1.ConfigConnector)
+		if !ok {
+			return fmt.Errorf("expected the resource to be a ConfigConnector, but it was not. Object: %v", o)
+		}
+		if !cc.GetDeletionTimestamp().IsZero() {
+			return nil
+		}
+		if err := r.migrator.Migrate(ctx, cc); err != nil {
+			return errors.Wrap(err, "error migrating Config Connector to the version to deploy")
+		}
+		return nil
+	}
+}
+
 func (r *ConfigConnectorReconciler) transformForClusterMode() declarative.ObjectTransform {
 	return func(ctx context.Context, o declarative.DeclarativeObject, m *manifest.Objects) error {
 		cc, ok := o.(*corev1beta1.ConfigConnector)
@@ -290,6 +355,71 @@ func (r *ConfigConnectorReconciler) objectTransformForGCPIdentity(cc *corev1beta
 	return nil
 }
 
+// transformComponents applies the settings in spec.components to the Config Connector system components.
+func (r *ConfigConnectorReconciler) transformComponents() declarative.ObjectTransform {
+	return func(ctx context.Context, o declarative.DeclarativeObject, m *manifest.Objects) error {
+		cc, ok := o.(*corev1beta1.ConfigConnector)
+		if !ok {
+			return fmt.Errorf("expected the resource to be a ConfigConnector, but it was not. Object: %v", o)
+		}
+		if cc.Spec.Components == nil {
+			return nil
+		}
+		if err := r.objectTransformForComponents(cc.Spec.Components, m); err != nil {
+			return errors.Wrap(err, "error transforming loadedManifest for spec.components")
+		}
+		return nil
+	}
+}
+
+// transformImages applies spec.imageRegistry and spec.imagePullSecrets to the Config Connector system components.
+func (r *ConfigConnectorReconciler) transformImages() declarative.ObjectTransform {
+	return func(ctx context.Context, o declarative.DeclarativeObject, m *manifest.Objects) error {
+		cc, ok := o.(*corev1beta1.ConfigConnector)
+		if !ok {
+			return fmt.Errorf("expected the resource to be a ConfigConnector, but it was not. Object: %v", o)
+		}
+		if err := controllers.TransformImages(cc, m); err != nil {
+			return errors.Wrap(err, "error transforming loadedManifest for spec.imageRegistry and spec.imagePullSecrets")
+		}
+		return nil
+	}
+}
+
+func (r *ConfigConnectorReconciler) objectTransformForComponents(components *corev1beta1.ConfigConnectorComponents, m *manifest.Objects) error {
+	transformed := make([]*manifest.Object, 0, len(m.Items))
+	for _, obj := range m.Items {
+		processed, err := transformComponent(components, obj)
+		if err != nil {
+			return err
+		}
+		transformed = append(transformed, processed)
+	}
+	m.Items = transformed
+	return nil
+}
+
+func transformComponent(components *corev1beta1.ConfigConnectorComponents, obj *manifest.Object) (*manifest.Object, error) {
+	switch {
+	case controllers.IsControllerManagerStatefulSet(obj) && components.ControllerManager != nil:
+		return controllers.ApplyControllerManagerComponentSpec(obj, components.ControllerManager)
+	case controllers.IsComponentWorkload(obj, "Deployment", k8s.KCCWebhookComponent) && components.Webhook != nil:
+		return controllers.ApplyWebhookComponentSpec(obj, components.Webhook)
+	case controllers.IsWebhookHorizontalPodAutoscaler(obj) && components.Webhook != nil && components.Webhook.Replicas != nil:
+		return controllers.SetMinReplicas(obj, *components.Webhook.Replicas)
+	case controllers.IsComponentWorkload(obj, "Deployment", k8s.KCCRecorderComponent) && components.Recorder != nil:
+		processed, err := controllers.ApplyComponentSpec(obj, k8s.CNRMRecorderContainerName, &components.Recorder.ComponentSpec)
+		if err != nil || components.Recorder.Replicas == nil {
+			return processed, err
+		}
+		return controllers.SetReplicas(processed, *components.Recorder.Replicas)
+	case controllers.IsComponentWorkload(obj, "StatefulSet", k8s.KCCDeletionDefenderComponent) && components.DeletionDefender != nil:
+		return controllers.ApplyComponentSpec(obj, k8s.CNRMDeletionDefenderContainerName, components.DeletionDefender)
+	default:
+		return obj, nil
+	}
+}
+
 func createCNRMSystemNamespace(ctx context.Context, c client.Client, m *manifest.Objects) error {
 	for _, obj := range m.Items {
 		if obj.Kind == "Namespace" && obj.GetName() == k8s.CNRMSystemNamespace {
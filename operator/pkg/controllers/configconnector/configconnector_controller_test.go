@@ -128,9 +128,14 @@ func TestHandleReconcileSucceeded(t *testing.T) {
 	if err := c.Create(ctx, tc.cc); err != nil {
 		t.Fatalf("failed to create ConfigConnector: %v", err)
 	}
-	if err := r.handleReconcileSucceeded(ctx, nn); err != nil {
+	ready, err := r.handleReconcileSucceeded(ctx, nn)
+	if err != nil {
 		t.Errorf("error handling successful reconciliation: %v", err)
 	}
+	// No component is deployed in the test environment, so none can be not ready.
+	if !ready {
+		t.Errorf("unexpected readiness: got 'false', want 'true'")
+	}
 	mockEventRecorder.AssertEventRecorded(kind, nn, v1.EventTypeNormal, k8s.UpToDate, k8s.UpToDateMessage)
 
 	newCC := &corev1beta1.ConfigConnector{}
@@ -144,6 +149,9 @@ func TestHandleReconcileSucceeded(t *testing.T) {
 	if len(status.Errors) != 0 {
 		t.Errorf("unexpected number of errors in status.errors: got %v errors, want 0 errors. Got the errors: %v", len(status.Errors), status.Errors)
 	}
+	if newCC.Status.LastReconcileTime == nil {
+		t.Errorf("unexpected value for status.lastReconcileTime: got nil, want the time of the reconciliation")
+	}
 }
 
 func TestHandleConfigConnectorCreate(t *testing.T) {
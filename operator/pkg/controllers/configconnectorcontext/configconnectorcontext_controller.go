@@ -41,7 +41,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/addon/pkg/apis/v1alpha1"
 	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative"
 	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative/pkg/manifest"
@@ -62,8 +65,8 @@ type ConfigConnectorContextReconciler struct {
 	log        logr.Logger
 }
 
-func Add(mgr ctrl.Manager, repoPath string) error {
-	r, err := newReconciler(mgr, repoPath)
+func Add(mgr ctrl.Manager, repo cnrmmanifest.Repository) error {
+	r, err := newReconciler(mgr, repo)
 	if err != nil {
 		return err
 	}
@@ -74,7 +77,12 @@ func Add(mgr ctrl.Manager, repoPath string) error {
 		ControllerManagedBy(mgr).
 		Named(controllerName).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 20}).
-		For(obj, builder.OnlyMetadata).
+		// Only changes to the spec trigger a reconciliation, as the status is updated on every reconciliation.
+		For(obj, builder.OnlyMetadata, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Re-reconcile all the ConfigConnectorContext objects when the ConfigConnector object changes,
+		// as the version to install for a namespace depends on its version and canary settings.
+		Watches(&source.Kind{Type: &corev1beta1.ConfigConnector{}}, handler.EnqueueRequestsFromMapFunc(r.mapConfigConnectorToContexts),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Build(r)
 	if err != nil {
 		return err
@@ -83,13 +91,28 @@ func Add(mgr ctrl.Manager, repoPath string) error {
 	return nil
 }
 
-func newReconciler(mgr ctrl.Manager, repoPath string) (*ConfigConnectorContextReconciler, error) {
-	repo := cnrmmanifest.NewLocalRepository(repoPath)
-	manifestLoader := cnrmmanifest.NewPerNamespaceManifestLoader(repo)
+func (r *ConfigConnectorContextReconciler) mapConfigConnectorToContexts(o client.Object) []reconcile.Request {
+	cccList := &corev1beta1.ConfigConnectorContextList{}
+	if err := r.client.List(context.Background(), cccList); err != nil {
+		r.log.Error(err, "error listing ConfigConnectorContext objects")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(cccList.Items))
+	for _, ccc := range cccList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: ccc.Namespace, Name: ccc.Name},
+		})
+	}
+	return requests
+}
+
+func newReconciler(mgr ctrl.Manager, repo cnrmmanifest.Repository) (*ConfigConnectorContextReconciler, error) {
+	manifestLoader := cnrmmanifest.NewPerNamespaceManifestLoader(mgr.GetClient(), repo)
 	preflight := preflight.NewCompositePreflight([]declarative.Preflight{
 		preflight.NewNameChecker(mgr.GetClient(), k8s.ConfigConnectorContextAllowedName),
 		preflight.NewUpgradeChecker(mgr.GetClient(), repo),
 		preflight.NewConfigConnectorContextChecker(),
+		preflight.NewIdentityChecker(mgr.GetClient(), preflight.NewIAMServiceAccountClient()),
 	})
 
 	r := &ConfigConnectorContextReconciler{
@@ -104,6 +127,8 @@ func newReconciler(mgr ctrl.Manager, repoPath string) (*ConfigConnectorContextRe
 		declarative.WithPreserveNamespace(),
 		declarative.WithManifestController(manifestLoader),
 		declarative.WithObjectTransform(r.transformNamespacedComponents()),
+		declarative.WithObjectTransform(r.transformComponents()),
+		declarative.WithObjectTransform(r.transformImages()),
 		declarative.WithObjectTransform(r.addLabels()),
 		declarative.WithObjectTransform(r.handleCCContextLifecycle()),
 		declarative.WithStatus(&declarative.StatusBuilder{
@@ -129,9 +154,17 @@ func (r *ConfigConnectorContextReconciler) Reconcile(ctx context.Context, req re
 		}
 		return reconcile.Result{}, reconciliationErr
 	}
+	ready, err := r.handleReconcileSucceeded(ctx, req.NamespacedName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !ready {
+		r.log.Info("controller manager is not ready yet; polling its rollout status", "ConfigConnectorContext", req.NamespacedName)
+		return reconcile.Result{RequeueAfter: controllers.RolloutPollPeriod}, nil
+	}
 	jitteredPeriod := jitter.GenerateJitteredReenqueuePeriod()
 	r.log.Info("successfully finished reconcile", "ConfigConnectorContext", req.NamespacedName, "time to next reconciliation", jitteredPeriod)
-	return reconcile.Result{RequeueAfter: jitteredPeriod}, r.handleReconcileSucceeded(ctx, req.NamespacedName)
+	return reconcile.Result{RequeueAfter: jitteredPeriod}, nil
 }
 
 func (r *ConfigConnectorContextReconciler) getConfigConnectorContext(ctx context.Context, nn types.NamespacedName) (*corev1beta1.ConfigConnectorContext, error) {
@@ -160,17 +193,24 @@ func (r *ConfigConnectorContextReconciler) handleReconcileFailed(ctx context.Con
 		Healthy: false,
 		Errors:  []string{msg},
 	})
+	// The controller manager deployed by previous reconciliations is still running, so its status
+	// is surfaced along with the error.
+	if err := r.setRolloutStatus(ctx, ccc); err != nil {
+		r.log.Info("error getting the rollout status of the ConfigConnectorContext object", "namespace", nn.Namespace, "name", nn.Name, "error", err)
+	}
 	return r.updateConfigConnectorContextStatus(ctx, ccc)
 }
 
-func (r *ConfigConnectorContextReconciler) handleReconcileSucceeded(ctx context.Context, nn types.NamespacedName) error {
+// handleReconcileSucceeded updates the status of the ConfigConnectorContext object after a
+// successful reconciliation, and returns whether the controller manager of the namespace is ready.
+func (r *ConfigConnectorContextReconciler) handleReconcileSucceeded(ctx context.Context, nn types.NamespacedName) (bool, error) {
 	ccc, err := r.getConfigConnectorContext(ctx, nn)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			r.log.Info("ConfigConnectorContext not found in API server; skipping the handling of successful reconciliation", "namespace", nn.Namespace, "name", nn.Name)
-			return nil
+			return true, nil
 		}
-		return fmt.Errorf("error getting ConfigConnectorContext object %v/%v: %w", nn.Namespace, nn.Name, err)
+		return false, fmt.Errorf("error getting ConfigConnectorContext object %v/%v: %w", nn.Namespace, nn.Name, err)
 	}
 
 	r.recorder.Event(ccc, corev1.EventTypeNormal, k8s.UpToDate, k8s.UpToDateMessage)
@@ -178,7 +218,34 @@ func (r *ConfigConnectorContextReconciler) handleReconcileSucceeded(ctx context.
 		Healthy: true,
 		Errors:  []string{},
 	})
-	return r.updateConfigConnectorContextStatus(ctx, ccc)
+	if err := r.setRolloutStatus(ctx, ccc); err != nil {
+		return false, err
+	}
+	now := metav1.Now()
+	ccc.Status.LastReconcileTime = &now
+	if err := r.updateConfigConnectorContextStatus(ctx, ccc); err != nil {
+		return false, err
+	}
+	return ccc.Status.ControllerManager == nil || ccc.Status.ControllerManager.Ready, nil
+}
+
+// setRolloutStatus records the deployed version and the readiness of the controller manager of the
+// associated namespace in the status of the given ConfigConnectorContext object.
+func (r *ConfigConnectorContextReconciler) setRolloutStatus(ctx context.Context, ccc *corev1beta1.ConfigConnectorContext) error {
+	components, err := controllers.GetComponentStatuses(ctx, r.client, client.MatchingLabels{
+		k8s.KCCSystemComponentLabel:  k8s.KCCControllerManagerComponent,
+		k8s.NamespacedComponentLabel: ccc.Namespace,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting the status of the controller manager for namespace %v: %w", ccc.Namespace, err)
+	}
+	ccc.Status.ControllerManager = nil
+	ccc.Status.Version = ""
+	if len(components) > 0 {
+		ccc.Status.ControllerManager = &components[0]
+		ccc.Status.Version = components[0].Version
+	}
+	return nil
 }
 
 func (r *ConfigConnectorContextReconciler) updateConfigConnectorContextStatus(ctx context.Context, ccc *corev1beta1.ConfigConnectorContext) error {
@@ -203,6 +270,43 @@ func (r *ConfigConnectorContextReconciler) transformNamespacedComponents() decla
 	}
 }
 
+// transformComponents applies the settings in spec.components to the per-namespace components.
+func (r *ConfigConnectorContextReconciler) transformComponents() declarative.ObjectTransform {
+	return func(ctx context.Context, o declarative.DeclarativeObject, m *manifest.Objects) error {
+		ccc, ok := o.(*corev1beta1.ConfigConnectorContext)
+		if !ok {
+			return fmt.Errorf("expected the resource to be a ConfigConnectorContext, but it was not. Object: %v", o)
+		}
+		if ccc.Spec.Components == nil || ccc.Spec.Components.ControllerManager == nil {
+			return nil
+		}
+		transformedObjects, err := transformComponentTemplates(ccc, m.Items)
+		if err != nil {
+			return fmt.Errorf("error transforming namespaced components for spec.components: %w", err)
+		}
+		m.Items = transformedObjects
+		return nil
+	}
+}
+
+// transformImages applies spec.imageRegistry and spec.imagePullSecrets of the ConfigConnector object
+// to the per-namespace components.
+func (r *ConfigConnectorContextReconciler) transformImages() declarative.ObjectTransform {
+	return func(ctx context.Context, o declarative.DeclarativeObject, m *manifest.Objects) error {
+		cc, err := controllers.GetConfigConnector(ctx, r.client, types.NamespacedName{Name: k8s.ConfigConnectorAllowedName})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("error getting the ConfigConnector object: %w", err)
+		}
+		if err := controllers.TransformImages(cc, m); err != nil {
+			return fmt.Errorf("error transforming namespaced components for the image settings of the ConfigConnector object: %w", err)
+		}
+		return nil
+	}
+}
+
 // Add labels that will be used for the controller to dynamically watch on deployed KCC components.
 func (r *ConfigConnectorContextReconciler) addLabels() declarative.ObjectTransform {
 	return func(ctx context.Context, o declarative.DeclarativeObject, manifest *manifest.Objects) error {
@@ -77,6 +77,40 @@ func transformNamespacedComponentTemplates(ctx context.Context, c client.Client,
 	return transformedObjs, nil
 }
 
+func transformComponentTemplates(ccc *corev1beta1.ConfigConnectorContext, namespacedTemplates []*manifest.Object) ([]*manifest.Object, error) {
+	spec := ccc.Spec.Components.ControllerManager
+	if err := validateFlagsAgainstRequestProjectPolicy(ccc, spec.Flags); err != nil {
+		return nil, err
+	}
+	transformedObjs := make([]*manifest.Object, 0, len(namespacedTemplates))
+	for _, obj := range namespacedTemplates {
+		processed := obj
+		if controllers.IsControllerManagerStatefulSet(processed) {
+			var err error
+			processed, err = controllers.ApplyControllerManagerComponentSpec(processed, spec)
+			if err != nil {
+				return nil, err
+			}
+		}
+		transformedObjs = append(transformedObjs, processed)
+	}
+	return transformedObjs, nil
+}
+
+// The flags for the request project policy are set by the operator according to spec.requestProjectPolicy,
+// and so they cannot be overridden unless the default policy is used.
+func validateFlagsAgainstRequestProjectPolicy(ccc *corev1beta1.ConfigConnectorContext, flags map[string]string) error {
+	if ccc.GetRequestProjectPolicy() == k8s.ServiceAccountProjectPolicy {
+		return nil
+	}
+	for _, flag := range []string{k8s.UserProjectOverrideFlag, k8s.BillingProjectFlag} {
+		if _, ok := flags[flag]; ok {
+			return fmt.Errorf("flag %v cannot be set in spec.components.controllerManager.flags when spec.requestProjectPolicy is %v", flag, ccc.GetRequestProjectPolicy())
+		}
+	}
+	return nil
+}
+
 func handleControllerManagerService(ctx context.Context, c client.Client, ccc *corev1beta1.ConfigConnectorContext, obj *manifest.Object) (*manifest.Object, error) {
 	u := obj.UnstructuredObject().DeepCopy()
 	nsId, err := cluster.GetNamespaceID(k8s.OperatorNamespaceIDConfigMapNN, c, ctx, ccc.Namespace)
@@ -149,70 +183,11 @@ func handleControllerManagerStatefulSet(ctx context.Context, c client.Client, cc
 }
 
 func enableUserProjectOverride(u *unstructured.Unstructured) error {
-	return setFlagForManagerContainer(u, k8s.UserProjectOverrideFlag, "true")
+	return controllers.SetFlagForManagerContainer(u, k8s.UserProjectOverrideFlag, "true")
 }
 
 func enableBillingProject(u *unstructured.Unstructured, flagValue string) error {
-	return setFlagForManagerContainer(u, k8s.BillingProjectFlag, flagValue)
-}
-
-func findManagerContainer(containers []interface{}) (managerContainer map[string]interface{}, index int, err error) {
-	for i, container := range containers {
-		containerAsMap, ok := container.(map[string]interface{})
-		if !ok {
-			return nil, 0, fmt.Errorf("couldn't convert container configuration %v to a map", container)
-		}
-		name, found, err := unstructured.NestedString(containerAsMap, "name")
-		if err != nil || !found {
-			return nil, 0, fmt.Errorf("couldn't resolve name of container configuration %v: %v", container, err)
-		}
-		if name == k8s.CNRMManagerContainerName {
-			return containerAsMap, i, nil
-		}
-	}
-	return nil, 0, fmt.Errorf("no manager container found")
-}
-
-// A helper method to add optional flags for manager container.
-func setFlagForManagerContainer(u *unstructured.Unstructured, flag string, flagValue string) error {
-	containersPath := []string{"spec", "template", "spec", "containers"} // Path to container configurations in a StatefulSet
-	containers, found, err := unstructured.NestedSlice(u.Object, containersPath...)
-	if err != nil || !found {
-		return fmt.Errorf("couldn't resolve containers: %w", err)
-	}
-
-	managerContainer, index, err := findManagerContainer(containers)
-	if err != nil {
-		return fmt.Errorf("error finding manager container: %v", err)
-	}
-	args, found, err := unstructured.NestedStringSlice(managerContainer, "args")
-	if err != nil {
-		return fmt.Errorf("couldn't resolve args of manager container %v: %w", managerContainer, err)
-	}
-	if !found {
-		args = make([]string, 0)
-	}
-	newArgs := removeFlagFromArgs(args, flag)
-	newArgs = append(newArgs, flag+"="+flagValue)
-	if err := unstructured.SetNestedStringSlice(managerContainer, newArgs, "args"); err != nil {
-		return fmt.Errorf("error setting args in manager container: %v", err)
-	}
-
-	containers[index] = managerContainer
-	if err := unstructured.SetNestedSlice(u.Object, containers, containersPath...); err != nil {
-		return fmt.Errorf("error setting containers: %v", err)
-	}
-	return nil
-}
-
-func removeFlagFromArgs(args []string, flag string) []string {
-	newArgs := make([]string, 0)
-	for _, a := range args {
-		if !strings.HasPrefix(a, flag) {
-			newArgs = append(newArgs, a)
-		}
-	}
-	return newArgs
+	return controllers.SetFlagForManagerContainer(u, k8s.BillingProjectFlag, flagValue)
 }
 
 func removeStaleControllerManagerStatefulSet(ctx context.Context, c client.Client, ns string, validSts string) error {
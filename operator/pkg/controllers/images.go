@@ -0,0 +1,130 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative/pkg/manifest"
+)
+
+var initContainersPath = []string{"spec", "template", "spec", "initContainers"} // Path to init container configurations in a StatefulSet or Deployment
+
+// TransformImages applies the image registry override and the image pull secrets of the given
+// ConfigConnector object to every workload in the given manifest.
+func TransformImages(cc *corev1beta1.ConfigConnector, m *manifest.Objects) error {
+	if cc.Spec.ImageRegistry == "" && len(cc.Spec.ImagePullSecrets) == 0 {
+		return nil
+	}
+	transformed := make([]*manifest.Object, 0, len(m.Items))
+	for _, obj := range m.Items {
+		if obj.Kind != "Deployment" && obj.Kind != "StatefulSet" {
+			transformed = append(transformed, obj)
+			continue
+		}
+		processed, err := ApplyImageSettings(obj, cc.Spec.ImageRegistry, cc.Spec.ImagePullSecrets)
+		if err != nil {
+			return err
+		}
+		transformed = append(transformed, processed)
+	}
+	m.Items = transformed
+	return nil
+}
+
+// ApplyImageSettings rewrites the images of all the containers and init containers of the given workload
+// to be pulled from the given registry, if set, and adds the given image pull secrets to its pod template.
+func ApplyImageSettings(obj *manifest.Object, registry string, pullSecrets []corev1.LocalObjectReference) (*manifest.Object, error) {
+	u := obj.UnstructuredObject().DeepCopy()
+	if registry != "" {
+		for _, path := range [][]string{containersPath, initContainersPath} {
+			if err := rewriteContainerImages(u, path, registry); err != nil {
+				return nil, fmt.Errorf("error rewriting images in %v %v: %w", u.GetKind(), u.GetName(), err)
+			}
+		}
+	}
+	if len(pullSecrets) > 0 {
+		if err := addImagePullSecrets(u, pullSecrets); err != nil {
+			return nil, fmt.Errorf("error adding imagePullSecrets to %v %v: %w", u.GetKind(), u.GetName(), err)
+		}
+	}
+	return manifest.NewObject(u)
+}
+
+func rewriteContainerImages(u *unstructured.Unstructured, path []string, registry string) error {
+	containers, found, err := unstructured.NestedSlice(u.Object, path...)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+	for i, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected container %v", c)
+		}
+		image, _, err := unstructured.NestedString(container, "image")
+		if err != nil {
+			return err
+		}
+		if image == "" {
+			continue
+		}
+		container["image"] = RewriteImageRegistry(image, registry)
+		containers[i] = container
+	}
+	return unstructured.SetNestedSlice(u.Object, containers, path...)
+}
+
+// RewriteImageRegistry replaces the registry and repository path of the given image with the given registry,
+// keeping the image name and its tag or digest, e.g. 'gcr.io/gke-release/cnrm/webhook:1a2b3c' is rewritten to
+// 'registry.example.com/cnrm/webhook:1a2b3c' for the registry 'registry.example.com/cnrm'.
+func RewriteImageRegistry(image, registry string) string {
+	name := image
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		name = image[i+1:]
+	}
+	return strings.TrimSuffix(registry, "/") + "/" + name
+}
+
+func addImagePullSecrets(u *unstructured.Unstructured, pullSecrets []corev1.LocalObjectReference) error {
+	path := []string{"spec", "template", "spec", "imagePullSecrets"}
+	existing, _, err := unstructured.NestedSlice(u.Object, path...)
+	if err != nil {
+		return err
+	}
+	names := make(map[string]bool)
+	for _, s := range existing {
+		if m, ok := s.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok {
+				names[name] = true
+			}
+		}
+	}
+	for _, s := range pullSecrets {
+		if names[s.Name] {
+			continue
+		}
+		names[s.Name] = true
+		existing = append(existing, map[string]interface{}{"name": s.Name})
+	}
+	return unstructured.SetNestedSlice(u.Object, existing, path...)
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	testcontroller "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/test/controller"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var webhookDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    cnrm.cloud.google.com/component: cnrm-webhook-manager
+    cnrm.cloud.google.com/system: "true"
+  name: cnrm-webhook-manager
+  namespace: cnrm-system
+spec:
+  template:
+    spec:
+      containers:
+      - image: gcr.io/gke-release/cnrm/webhook:7f098b4
+        name: webhook
+      imagePullSecrets:
+      - name: existing-secret
+      initContainers:
+      - image: k8s.gcr.io/prometheus-to-sd@sha256:0123456789abcdef
+        name: init
+`
+
+var webhookDeploymentWithImageSettings = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    cnrm.cloud.google.com/component: cnrm-webhook-manager
+    cnrm.cloud.google.com/system: "true"
+  name: cnrm-webhook-manager
+  namespace: cnrm-system
+spec:
+  template:
+    spec:
+      containers:
+      - image: registry.example.com/mirror/webhook:7f098b4
+        name: webhook
+      imagePullSecrets:
+      - name: existing-secret
+      - name: registry-credentials
+      initContainers:
+      - image: registry.example.com/mirror/prometheus-to-sd@sha256:0123456789abcdef
+        name: init
+`
+
+var cnrmSystemNamespace = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: cnrm-system
+`
+
+func TestTransformImages(t *testing.T) {
+	cc := &corev1beta1.ConfigConnector{
+		Spec: corev1beta1.ConfigConnectorSpec{
+			ImageRegistry: "registry.example.com/mirror/",
+			ImagePullSecrets: []corev1.LocalObjectReference{
+				{Name: "existing-secret"},
+				{Name: "registry-credentials"},
+			},
+		},
+	}
+	m := testcontroller.ParseObjects(t, context.TODO(), []string{webhookDeployment, cnrmSystemNamespace})
+	if err := TransformImages(cc, m); err != nil {
+		t.Fatalf("error transforming images: %v", err)
+	}
+	expected := testcontroller.ParseObjects(t, context.TODO(), []string{webhookDeploymentWithImageSettings, cnrmSystemNamespace})
+	if len(m.Items) != len(expected.Items) {
+		t.Fatalf("got %v objects, want %v", len(m.Items), len(expected.Items))
+	}
+	for i := range expected.Items {
+		if diff := cmp.Diff(expected.Items[i].UnstructuredObject().Object, m.Items[i].UnstructuredObject().Object); diff != "" {
+			t.Fatalf("unexpected diff: %v", diff)
+		}
+	}
+}
+
+func TestRewriteImageRegistry(t *testing.T) {
+	tests := []struct {
+		image    string
+		registry string
+		expected string
+	}{
+		{
+			image:    "gcr.io/gke-release/cnrm/recorder:7f098b4",
+			registry: "registry.example.com",
+			expected: "registry.example.com/recorder:7f098b4",
+		},
+		{
+			image:    "localhost:5000/recorder:7f098b4",
+			registry: "registry.example.com:443/cnrm",
+			expected: "registry.example.com:443/cnrm/recorder:7f098b4",
+		},
+		{
+			image:    "recorder",
+			registry: "registry.example.com/cnrm",
+			expected: "registry.example.com/cnrm/recorder",
+		},
+	}
+	for _, tc := range tests {
+		if got := RewriteImageRegistry(tc.image, tc.registry); got != tc.expected {
+			t.Errorf("RewriteImageRegistry(%v, %v) = %v, want %v", tc.image, tc.registry, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RolloutPollPeriod is the period at which the ConfigConnector and ConfigConnectorContext objects are reconciled
+// while the components they deploy are not ready, so that their rollout status is kept up to date.
+const RolloutPollPeriod = 10 * time.Second
+
+// GetComponentStatuses returns the readiness of the StatefulSets and Deployments in the cnrm-system namespace
+// that match the given labels, sorted by component name and scoped namespace.
+func GetComponentStatuses(ctx context.Context, c client.Client, matchingLabels client.MatchingLabels) ([]corev1beta1.ComponentStatus, error) {
+	statuses := make([]corev1beta1.ComponentStatus, 0)
+	stsList := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, stsList, client.InNamespace(k8s.CNRMSystemNamespace), matchingLabels); err != nil {
+		return nil, fmt.Errorf("error listing StatefulSets in %v namespace: %w", k8s.CNRMSystemNamespace, err)
+	}
+	for i := range stsList.Items {
+		sts := &stsList.Items[i]
+		statuses = append(statuses, newComponentStatus(&sts.ObjectMeta, sts.Spec.Replicas, sts.Status.ObservedGeneration, sts.Status.ReadyReplicas, sts.Status.UpdatedReplicas))
+	}
+	deploymentList := &appsv1.DeploymentList{}
+	if err := c.List(ctx, deploymentList, client.InNamespace(k8s.CNRMSystemNamespace), matchingLabels); err != nil {
+		return nil, fmt.Errorf("error listing Deployments in %v namespace: %w", k8s.CNRMSystemNamespace, err)
+	}
+	for i := range deploymentList.Items {
+		d := &deploymentList.Items[i]
+		statuses = append(statuses, newComponentStatus(&d.ObjectMeta, d.Spec.Replicas, d.Status.ObservedGeneration, d.Status.ReadyReplicas, d.Status.UpdatedReplicas))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Name != statuses[j].Name {
+			return statuses[i].Name < statuses[j].Name
+		}
+		return statuses[i].ScopedNamespace < statuses[j].ScopedNamespace
+	})
+	return statuses, nil
+}
+
+func newComponentStatus(meta *metav1.ObjectMeta, replicas *int32, observedGeneration int64, readyReplicas, updatedReplicas int32) corev1beta1.ComponentStatus {
+	name := meta.Labels[k8s.KCCSystemComponentLabel]
+	if name == "" {
+		name = meta.Name
+	}
+	// The number of replicas defaults to 1 when unset.
+	desired := int32(1)
+	if replicas != nil {
+		desired = *replicas
+	}
+	// A component is only ready once its latest spec has been observed and all its replicas run the
+	// updated template, so that the replicas of a previous version are not reported as ready.
+	ready := observedGeneration >= meta.Generation && updatedReplicas == desired && readyReplicas >= desired
+	return corev1beta1.ComponentStatus{
+		Name:            name,
+		ScopedNamespace: meta.Labels[k8s.NamespacedComponentLabel],
+		Version:         meta.Annotations[k8s.VersionAnnotation],
+		Ready:           ready,
+		Replicas:        desired,
+		ReadyReplicas:   readyReplicas,
+	}
+}
+
+// AllComponentsReady returns true if all the given components are ready.
+func AllComponentsReady(statuses []corev1beta1.ComponentStatus) bool {
+	for _, s := range statuses {
+		if !s.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// GetNotReadyNamespaces returns the scoped namespaces of the given controller manager statuses that are not ready.
+func GetNotReadyNamespaces(statuses []corev1beta1.ComponentStatus) []string {
+	var namespaces []string
+	for _, s := range statuses {
+		if s.Name == k8s.KCCControllerManagerComponent && s.ScopedNamespace != "" && !s.Ready {
+			namespaces = append(namespaces, s.ScopedNamespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// GetDeployedVersion returns the version of Config Connector annotated on the cnrm-system namespace, if any.
+func GetDeployedVersion(ctx context.Context, c client.Client) (string, error) {
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: k8s.CNRMSystemNamespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("error getting %v namespace: %w", k8s.CNRMSystemNamespace, err)
+	}
+	return ns.GetAnnotations()[k8s.VersionAnnotation], nil
+}
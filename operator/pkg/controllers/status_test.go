@@ -0,0 +1,156 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewComponentStatus(t *testing.T) {
+	two := int32(2)
+	tests := []struct {
+		name               string
+		meta               metav1.ObjectMeta
+		replicas           *int32
+		observedGeneration int64
+		readyReplicas      int32
+		updatedReplicas    int32
+		expected           corev1beta1.ComponentStatus
+	}{
+		{
+			name: "per-namespace controller manager, ready",
+			meta: metav1.ObjectMeta{
+				Name:       "cnrm-controller-manager-abc123",
+				Generation: 2,
+				Labels: map[string]string{
+					k8s.KCCSystemComponentLabel:  k8s.KCCControllerManagerComponent,
+					k8s.NamespacedComponentLabel: "foo-ns",
+				},
+				Annotations: map[string]string{
+					k8s.VersionAnnotation: "1.98.0",
+				},
+			},
+			observedGeneration: 2,
+			readyReplicas:      1,
+			updatedReplicas:    1,
+			expected: corev1beta1.ComponentStatus{
+				Name:            k8s.KCCControllerManagerComponent,
+				ScopedNamespace: "foo-ns",
+				Version:         "1.98.0",
+				Ready:           true,
+				Replicas:        1,
+				ReadyReplicas:   1,
+			},
+		},
+		{
+			name: "webhook, partially ready",
+			meta: metav1.ObjectMeta{
+				Name: "cnrm-webhook-manager",
+				Labels: map[string]string{
+					k8s.KCCSystemComponentLabel: k8s.KCCWebhookComponent,
+				},
+			},
+			replicas:        &two,
+			readyReplicas:   1,
+			updatedReplicas: 2,
+			expected: corev1beta1.ComponentStatus{
+				Name:          k8s.KCCWebhookComponent,
+				Ready:         false,
+				Replicas:      2,
+				ReadyReplicas: 1,
+			},
+		},
+		{
+			name: "webhook, rolling out",
+			meta: metav1.ObjectMeta{
+				Name: "cnrm-webhook-manager",
+				Labels: map[string]string{
+					k8s.KCCSystemComponentLabel: k8s.KCCWebhookComponent,
+				},
+			},
+			replicas:        &two,
+			readyReplicas:   2,
+			updatedReplicas: 1,
+			expected: corev1beta1.ComponentStatus{
+				Name:          k8s.KCCWebhookComponent,
+				Ready:         false,
+				Replicas:      2,
+				ReadyReplicas: 2,
+			},
+		},
+		{
+			name: "webhook, new spec not observed yet",
+			meta: metav1.ObjectMeta{
+				Name:       "cnrm-webhook-manager",
+				Generation: 3,
+				Labels: map[string]string{
+					k8s.KCCSystemComponentLabel: k8s.KCCWebhookComponent,
+				},
+			},
+			observedGeneration: 2,
+			readyReplicas:      1,
+			updatedReplicas:    1,
+			expected: corev1beta1.ComponentStatus{
+				Name:          k8s.KCCWebhookComponent,
+				Ready:         false,
+				Replicas:      1,
+				ReadyReplicas: 1,
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			status := newComponentStatus(&tc.meta, tc.replicas, tc.observedGeneration, tc.readyReplicas, tc.updatedReplicas)
+			if !reflect.DeepEqual(status, tc.expected) {
+				t.Fatalf("unexpected diff: %v", cmp.Diff(tc.expected, status))
+			}
+		})
+	}
+}
+
+func TestGetNotReadyNamespaces(t *testing.T) {
+	statuses := []corev1beta1.ComponentStatus{
+		{Name: k8s.KCCControllerManagerComponent, ScopedNamespace: "foo-ns", Ready: false},
+		{Name: k8s.KCCControllerManagerComponent, ScopedNamespace: "bar-ns", Ready: true},
+		{Name: k8s.KCCControllerManagerComponent, ScopedNamespace: "baz-ns", Ready: false},
+		{Name: k8s.KCCWebhookComponent, Ready: false},
+	}
+	expected := []string{"baz-ns", "foo-ns"}
+	if got := GetNotReadyNamespaces(statuses); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("got %v, want %v", got, expected)
+	}
+}
+
+func TestAllComponentsReady(t *testing.T) {
+	ready := corev1beta1.ComponentStatus{Name: k8s.KCCWebhookComponent, Ready: true}
+	notReady := corev1beta1.ComponentStatus{Name: k8s.KCCControllerManagerComponent, ScopedNamespace: "foo-ns", Ready: false}
+	if !AllComponentsReady(nil) {
+		t.Errorf("expected an empty list of components to be ready")
+	}
+	if !AllComponentsReady([]corev1beta1.ComponentStatus{ready}) {
+		t.Errorf("expected ready components to be ready")
+	}
+	if AllComponentsReady([]corev1beta1.ComponentStatus{ready, notReady}) {
+		t.Errorf("expected components to be not ready when one of them is not ready")
+	}
+}
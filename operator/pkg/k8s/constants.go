@@ -32,6 +32,9 @@ const (
 	KCCSystemComponentLabel              = "cnrm.cloud.google.com/component"
 	KCCControllerManagerComponent        = "cnrm-controller-manager"
 	KCCUnmanagedDetectorComponent        = "cnrm-unmanaged-detector"
+	KCCWebhookComponent                  = "cnrm-webhook-manager"
+	KCCRecorderComponent                 = "cnrm-resource-stats-recorder"
+	KCCDeletionDefenderComponent         = "cnrm-deletiondefender"
 	CNRMDomain                           = "cnrm.cloud.google.com"
 	CNRMSystemNamespace                  = "cnrm-system"
 	NamespacedComponentLabel             = "cnrm.cloud.google.com/scoped-namespace"
@@ -56,7 +59,25 @@ const (
 	BillingProjectPolicy                 = "BILLING_PROJECT"
 	UserProjectOverrideFlag              = "--user-project-override"
 	BillingProjectFlag                   = "--billing-project"
+	ResourceNameLabelFlag                = "--resource-name-label"
+	EnablePprofFlag                      = "--enable-pprof"
+	PprofPortFlag                        = "--pprof-port"
+	WebhookValidationModesFlag           = "--validation-modes"
 	CNRMManagerContainerName             = "manager"
+	CNRMWebhookContainerName             = "webhook"
+	CNRMRecorderContainerName            = "recorder"
+	CNRMDeletionDefenderContainerName    = "deletiondefender"
+)
+
+// The IAM role that binds a Kubernetes Service Account to a Google Service Account, and the condition (with its
+// reasons) set on ConfigConnectorContext objects once the identity has been verified.
+const (
+	WorkloadIdentityUserRole              = "roles/iam.workloadIdentityUser"
+	IdentityVerifiedCondition             = "IdentityVerified"
+	IdentityVerifiedReason                = "Verified"
+	ServiceAccountNotFoundReason          = "ServiceAccountNotFound"
+	WorkloadIdentityBindingNotFoundReason = "WorkloadIdentityBindingNotFound"
+	IdentityVerificationFailedReason      = "VerificationFailed"
 )
 
 var (
@@ -71,6 +92,16 @@ var (
 		"servicemappings.core.cnrm.cloud.google.com": true,
 	}
 
+	// AllowedManagerFlags contains the manager container flags that can be set through
+	// `components.controllerManager.flags` in ConfigConnector and ConfigConnectorContext objects.
+	AllowedManagerFlags = map[string]bool{
+		ResourceNameLabelFlag:   true,
+		BillingProjectFlag:      true,
+		UserProjectOverrideFlag: true,
+		EnablePprofFlag:         true,
+		PprofPortFlag:           true,
+	}
+
 	OperatorNamespaceIDConfigMapNN = types.NamespacedName{
 		Namespace: OperatorSystemNamespace,
 		Name:      "namespace-id",
@@ -19,7 +19,6 @@ import (
 	"fmt"
 
 	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
-	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -35,6 +34,7 @@ const (
 	crdFileName                    = "crds.yaml"
 	cnrmSystemFileName             = "0-cnrm-system.yaml"
 	perNamespaceComponentsFileName = "per-namespace-components.yaml"
+	migrationsFileName             = "migrations.yaml"
 )
 
 type ManifestLoader struct {
@@ -58,11 +58,10 @@ func (c *ManifestLoader) ResolveManifest(ctx context.Context, o runtime.Object)
 	mlog.Info("resolving manifest", "name", cc.Name)
 
 	componentName := cc.ComponentName()
-	channelName := k8s.StableChannel
-	version, err := ResolveVersion(ctx, c.repo, componentName, channelName)
+	version, err := ResolveConfigConnectorVersion(ctx, c.repo, cc)
 	if err != nil {
-		return nil, fmt.Errorf("error resolving the version for %v in %v channel: %v", componentName, channelName, err)
+		return nil, err
 	}
-	mlog.Info("resolved version from channel", "channel", channelName, "version", version)
+	mlog.Info("resolved version", "version", version, "pinned", cc.Spec.Version != "")
 	return c.repo.LoadManifest(ctx, componentName, version, cc)
 }
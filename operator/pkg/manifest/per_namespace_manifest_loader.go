@@ -21,35 +21,46 @@ import (
 	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative"
 )
 
 type PerNamespaceManifestLoader struct {
-	repo Repository
+	client client.Client
+	repo   Repository
 }
 
 // Ensure that PerNamespaceManifestLoader implements declarative.ManifestController.
 var _ declarative.ManifestController = &PerNamespaceManifestLoader{}
 
-func NewPerNamespaceManifestLoader(repo Repository) *PerNamespaceManifestLoader {
+func NewPerNamespaceManifestLoader(client client.Client, repo Repository) *PerNamespaceManifestLoader {
 	return &PerNamespaceManifestLoader{
-		repo: repo,
+		client: client,
+		repo:   repo,
 	}
 }
 
 func (p *PerNamespaceManifestLoader) ResolveManifest(ctx context.Context, o runtime.Object) (map[string]string, error) {
-	_, ok := o.(*corev1beta1.ConfigConnectorContext)
+	ccc, ok := o.(*corev1beta1.ConfigConnectorContext)
 	if !ok {
 		return nil, fmt.Errorf("expected the resource to be a ConfigConnectorContext, but it was not. Object: %v", o)
 	}
 
+	cc := &corev1beta1.ConfigConnector{}
+	if err := p.client.Get(ctx, types.NamespacedName{Name: k8s.ConfigConnectorAllowedName}, cc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("error getting ConfigConnector %v: %v", k8s.ConfigConnectorAllowedName, err)
+		}
+		cc = nil
+	}
 	componentName := k8s.ConfigConnectorComponentName
-	channelName := k8s.StableChannel
-	v, err := ResolveVersion(ctx, p.repo, componentName, channelName)
+	v, err := ResolveNamespacedVersion(ctx, p.repo, cc, ccc)
 	if err != nil {
-		return nil, fmt.Errorf("error resolving the version for %v in %v channel: %v", componentName, channelName, err)
+		return nil, err
 	}
-
+	mlog.Info("resolved version for namespaced components", "namespace", ccc.Namespace, "version", v)
 	return p.repo.LoadNamespacedComponents(ctx, componentName, v)
 }
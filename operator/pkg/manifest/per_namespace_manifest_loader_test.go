@@ -16,6 +16,7 @@ package manifest_test
 
 import (
 	"context"
+	"log"
 	"path"
 	"reflect"
 	"testing"
@@ -26,13 +27,21 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/test/mocks"
 )
 
+func init() {
+	if err := corev1beta1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+		log.Fatalf("error registering core kcc operator scheme: %v", err)
+	}
+}
+
 func TestManifestLoader_ResolveNamespacedComponents(t *testing.T) {
 	t.Parallel()
 	baseDir, repo := newTestNewLocalRepository(t)
 	manifestPath := path.Join(baseDir, "packages/configconnector/0.0.0-test/namespaced/per-namespace-components.yaml")
-	ml := manifest.NewPerNamespaceManifestLoader(repo)
+	ml := manifest.NewPerNamespaceManifestLoader(mocks.Manager{}.GetClient(), repo)
 	tests := []struct {
 		name   string
 		ccc    *corev1beta1.ConfigConnectorContext
@@ -0,0 +1,243 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/addon/pkg/loaders"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative"
+)
+
+const (
+	sha256DigestPrefix = "sha256:"
+	// maxArchiveSize is the maximum size of a downloaded repository archive.
+	maxArchiveSize = 256 << 20
+)
+
+// RemoteRepository is a Repository backed by a gzipped tarball served over HTTP(S). The tarball has
+// the same layout as the local repository, i.e. the channel files at the root and the manifests under
+// the "packages" directory. The tarball is verified against the expected sha256 digest, then extracted
+// into a cache directory keyed by the digest, so it is only downloaded once per digest.
+type RemoteRepository struct {
+	url      string
+	digest   string
+	cacheDir string
+	client   *http.Client
+
+	mu    sync.Mutex
+	local *LocalRepository
+}
+
+// Ensure that RemoteRepository implements Repository.
+var _ Repository = &RemoteRepository{}
+
+// NewRemoteRepository returns a Repository that loads the manifests from the tarball at the given URL.
+// digest is the expected digest of the tarball, in the form "sha256:<hex>".
+func NewRemoteRepository(url, digest, cacheDir string, client *http.Client) (*RemoteRepository, error) {
+	if url == "" {
+		return nil, fmt.Errorf("the URL of the remote repository must be set")
+	}
+	if _, err := parseSHA256Digest(digest); err != nil {
+		return nil, err
+	}
+	if cacheDir == "" {
+		return nil, fmt.Errorf("the cache directory of the remote repository must be set")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &RemoteRepository{
+		url:      url,
+		digest:   digest,
+		cacheDir: cacheDir,
+		client:   client,
+	}, nil
+}
+
+func (r *RemoteRepository) LoadChannel(ctx context.Context, name string) (*loaders.Channel, error) {
+	local, err := r.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return local.LoadChannel(ctx, name)
+}
+
+func (r *RemoteRepository) LoadManifest(ctx context.Context, componentName string, version string, o declarative.DeclarativeObject) (map[string]string, error) {
+	local, err := r.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return local.LoadManifest(ctx, componentName, version, o)
+}
+
+func (r *RemoteRepository) LoadNamespacedComponents(ctx context.Context, componentName string, version string) (map[string]string, error) {
+	local, err := r.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return local.LoadNamespacedComponents(ctx, componentName, version)
+}
+
+func (r *RemoteRepository) LoadMigrations(ctx context.Context, componentName string, version string) (string, error) {
+	local, err := r.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	return local.LoadMigrations(ctx, componentName, version)
+}
+
+// fetch returns a LocalRepository reading the extracted tarball, downloading and extracting it first
+// if it isn't in the cache directory yet.
+func (r *RemoteRepository) fetch(ctx context.Context) (*LocalRepository, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.local != nil {
+		return r.local, nil
+	}
+	hexDigest, err := parseSHA256Digest(r.digest)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(r.cacheDir, hexDigest)
+	if _, err := os.Stat(dir); err == nil {
+		rlog.Info("using cached remote repository", "url", r.url, "digest", r.digest, "path", dir)
+		r.local = NewLocalRepository(dir)
+		return r.local, nil
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error checking the cache directory %v: %v", dir, err)
+	}
+
+	rlog.Info("downloading remote repository", "url", r.url, "digest", r.digest)
+	archive, err := r.download(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(archive)
+	if actual := hex.EncodeToString(sum[:]); actual != hexDigest {
+		return nil, fmt.Errorf("digest mismatch for the remote repository %v: expected %v, got %v%v", r.url, r.digest, sha256DigestPrefix, actual)
+	}
+
+	if err := os.MkdirAll(r.cacheDir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating the cache directory %v: %v", r.cacheDir, err)
+	}
+	// Extract into a temporary directory first so that a partially extracted tarball is never used.
+	tmpDir, err := ioutil.TempDir(r.cacheDir, hexDigest+".tmp-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating a temporary directory in %v: %v", r.cacheDir, err)
+	}
+	defer os.RemoveAll(tmpDir)
+	if err := extractTarGz(archive, tmpDir); err != nil {
+		return nil, fmt.Errorf("error extracting the remote repository %v: %v", r.url, err)
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return nil, fmt.Errorf("error moving the extracted remote repository to %v: %v", dir, err)
+	}
+	r.local = NewLocalRepository(dir)
+	return r.local, nil
+}
+
+func (r *RemoteRepository) download(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error building the request for %v: %v", r.url, err)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading %v: %v", r.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error downloading %v: unexpected status %v", r.url, resp.Status)
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxArchiveSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %v: %v", r.url, err)
+	}
+	if len(b) > maxArchiveSize {
+		return nil, fmt.Errorf("the remote repository %v exceeds the maximum size of %v bytes", r.url, maxArchiveSize)
+	}
+	return b, nil
+}
+
+func parseSHA256Digest(digest string) (string, error) {
+	if !strings.HasPrefix(digest, sha256DigestPrefix) {
+		return "", fmt.Errorf("invalid digest %q: expected the form %v<hex>", digest, sha256DigestPrefix)
+	}
+	h := strings.TrimPrefix(digest, sha256DigestPrefix)
+	if b, err := hex.DecodeString(h); err != nil || len(b) != sha256.Size {
+		return "", fmt.Errorf("invalid digest %q: expected %v hex-encoded bytes", digest, sha256.Size)
+	}
+	return strings.ToLower(h), nil
+}
+
+func extractTarGz(archive []byte, dest string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		p := filepath.Join(dest, filepath.Clean("/"+hdr.Name))
+		if p != dest && !strings.HasPrefix(p, dest+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path %v in archive", hdr.Name)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(p, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+		default:
+			// Links and special files are not needed by the manifests and are skipped.
+			rlog.Info("skipping unsupported entry in archive", "name", hdr.Name, "type", hdr.Typeflag)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/manifest"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/test/util/paths"
+)
+
+func TestRemoteRepository_LoadFromServer(t *testing.T) {
+	t.Parallel()
+	archive := tarGzDir(t, filepath.Join(paths.GetOperatorSrcRootOrLogFatal(), "pkg", "manifest", "testchannel"))
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write(archive)
+	}))
+	defer server.Close()
+	cacheDir := t.TempDir()
+	ctx := context.TODO()
+
+	repo, err := manifest.NewRemoteRepository(server.URL, sha256Digest(archive), cacheDir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating the remote repository: %v", err)
+	}
+	channel, err := repo.LoadChannel(ctx, k8s.StableChannel)
+	if err != nil {
+		t.Fatalf("unexpected error loading the channel: %v", err)
+	}
+	if len(channel.Manifests) != 1 || channel.Manifests[0].Version != "0.0.0-test" {
+		t.Fatalf("unexpected channel: %v", channel)
+	}
+	m, err := repo.LoadNamespacedComponents(ctx, k8s.ConfigConnectorComponentName, "0.0.0-test")
+	if err != nil {
+		t.Fatalf("unexpected error loading the namespaced components: %v", err)
+	}
+	for _, v := range m {
+		if v != namespacedComponentsOnly {
+			t.Fatalf("unexpected namespaced components: %v", v)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Fatalf("got %v requests, want 1", n)
+	}
+
+	// A new repository with the same digest uses the cache instead of the server.
+	server.Close()
+	repo, err = manifest.NewRemoteRepository(server.URL, sha256Digest(archive), cacheDir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating the remote repository: %v", err)
+	}
+	if _, err := repo.LoadChannel(ctx, k8s.StableChannel); err != nil {
+		t.Fatalf("unexpected error loading the channel from the cache: %v", err)
+	}
+}
+
+func TestRemoteRepository_DigestMismatch(t *testing.T) {
+	t.Parallel()
+	archive := tarGzDir(t, filepath.Join(paths.GetOperatorSrcRootOrLogFatal(), "pkg", "manifest", "testchannel"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	}))
+	defer server.Close()
+	cacheDir := t.TempDir()
+
+	repo, err := manifest.NewRemoteRepository(server.URL, sha256Digest([]byte("something else")), cacheDir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating the remote repository: %v", err)
+	}
+	_, err = repo.LoadChannel(context.TODO(), k8s.StableChannel)
+	if err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Fatalf("expected a digest mismatch error, got %v", err)
+	}
+	entries, err := ioutil.ReadDir(cacheDir)
+	if err != nil {
+		t.Fatalf("error reading the cache directory: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected the cache directory to be empty, got %v entries", len(entries))
+	}
+}
+
+func TestNewRemoteRepository_InvalidDigest(t *testing.T) {
+	t.Parallel()
+	for _, digest := range []string{"", "abc", "sha256:abc", "md5:" + strings.Repeat("0", 64)} {
+		if _, err := manifest.NewRemoteRepository("http://example.com/repo.tar.gz", digest, t.TempDir(), nil); err == nil {
+			t.Fatalf("expected an error for digest %q, got nil", digest)
+		}
+	}
+}
+
+func tarGzDir(t *testing.T, dir string) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(b)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("error archiving %v: %v", dir, err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("error closing the tar writer: %v", err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatalf("error closing the gzip writer: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func sha256Digest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -33,6 +34,9 @@ type Repository interface {
 	LoadChannel(ctx context.Context, name string) (*loaders.Channel, error)
 	LoadManifest(ctx context.Context, component string, version string, o declarative.DeclarativeObject) (map[string]string, error)
 	LoadNamespacedComponents(ctx context.Context, componentName string, version string) (map[string]string, error)
+	// LoadMigrations returns the migrations declared by the given version of the component,
+	// or an empty string if the version doesn't declare any.
+	LoadMigrations(ctx context.Context, componentName string, version string) (string, error)
 }
 
 type LocalRepository struct {
@@ -67,6 +71,9 @@ func (r *LocalRepository) LoadChannel(ctx context.Context, name string) (*loader
 }
 
 func (r *LocalRepository) LoadManifest(ctx context.Context, componentName string, version string, o declarative.DeclarativeObject) (map[string]string, error) {
+	if err := ValidateVersion(version); err != nil {
+		return nil, err
+	}
 	cc, ok := o.(*corev1beta1.ConfigConnector)
 	if !ok {
 		return nil, fmt.Errorf("expected the resource to be a ConfigConnector, but it was not. Object: %v", o)
@@ -111,6 +118,9 @@ func (r *LocalRepository) LoadManifest(ctx context.Context, componentName string
 }
 
 func (r *LocalRepository) LoadNamespacedComponents(ctx context.Context, componentName string, version string) (map[string]string, error) {
+	if err := ValidateVersion(version); err != nil {
+		return nil, err
+	}
 	p := filepath.Join(r.basedir, "packages", componentName, version, "namespaced", perNamespaceComponentsFileName)
 	b, err := ioutil.ReadFile(p)
 	if err != nil {
@@ -118,3 +128,18 @@ func (r *LocalRepository) LoadNamespacedComponents(ctx context.Context, componen
 	}
 	return map[string]string{p: string(b)}, nil
 }
+
+func (r *LocalRepository) LoadMigrations(ctx context.Context, componentName string, version string) (string, error) {
+	if err := ValidateVersion(version); err != nil {
+		return "", err
+	}
+	p := filepath.Join(r.basedir, "packages", componentName, version, migrationsFileName)
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("error reading file %s: %v", p, err)
+	}
+	return string(b), nil
+}
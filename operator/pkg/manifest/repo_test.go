@@ -16,6 +16,7 @@ package manifest_test
 
 import (
 	"context"
+	"os"
 	"path"
 	"reflect"
 	"strings"
@@ -516,6 +517,48 @@ func TestNewLocalRepository_LoadNamespacedComponents(t *testing.T) {
 	}
 }
 
+func TestNewLocalRepository_LoadMigrations(t *testing.T) {
+	t.Parallel()
+	baseDir, repo := newTestNewLocalRepository(t)
+	tests := []struct {
+		name    string
+		version string
+		result  string
+	}{
+		{
+			name:    "version with migrations",
+			version: "0.0.0-test",
+			result:  readFile(t, path.Join(baseDir, "packages/configconnector/0.0.0-test/migrations.yaml")),
+		},
+		{
+			name:    "version without migrations",
+			version: "0.0.0-unknown",
+			result:  "",
+		},
+	}
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			migrations, err := repo.LoadMigrations(context.TODO(), "configconnector", tc.version)
+			if err != nil {
+				t.Fatalf("unexpected error while loading the migrations: %v", err)
+			}
+			if migrations != tc.result {
+				t.Fatalf("unexpected diff: %v", cmp.Diff(migrations, tc.result))
+			}
+		})
+	}
+}
+
+func readFile(t *testing.T, p string) string {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatalf("error reading file %v: %v", p, err)
+	}
+	return string(b)
+}
+
 func TestNewLocalRepository_LoadChannel(t *testing.T) {
 	t.Parallel()
 	_, repo := newTestNewLocalRepository(t)
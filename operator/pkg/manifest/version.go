@@ -17,8 +17,46 @@ package manifest
 import (
 	"context"
 	"fmt"
+	"regexp"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+
+	"github.com/blang/semver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
+// versionRegex matches the versions of the packages in a repository, e.g. '1.98.0'. It must be kept in sync with
+// the validation pattern of the version fields of the ConfigConnector CRD.
+var versionRegex = regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?$`)
+
+// ValidateVersion returns an error if the given version is not a semantic version. Versions are part of the paths of
+// the packages in a repository, so they must be validated before being used.
+func ValidateVersion(version string) error {
+	if !versionRegex.MatchString(version) {
+		return fmt.Errorf("invalid version '%v': must be a semantic version, e.g. '1.98.0'", version)
+	}
+	return nil
+}
+
+// validateVersionInChannel returns an error if the given version is not a valid version listed in the given channel.
+func validateVersionInChannel(ctx context.Context, repo Repository, componentName string, channelName string, version string) error {
+	if err := ValidateVersion(version); err != nil {
+		return err
+	}
+	channel, err := repo.LoadChannel(ctx, channelName)
+	if err != nil {
+		return err
+	}
+	for _, v := range channel.Manifests {
+		if (v.Package == "" || v.Package == componentName) && v.Version == version {
+			return nil
+		}
+	}
+	return fmt.Errorf("version %v of %v is not in the %v channel", version, componentName, channelName)
+}
+
 func ResolveVersion(ctx context.Context, repo Repository, componentName string, channelName string) (string, error) {
 	channel, err := repo.LoadChannel(ctx, channelName)
 	if err != nil {
@@ -35,3 +73,68 @@ func ResolveVersion(ctx context.Context, repo Repository, componentName string,
 	}
 	return version.Version, nil
 }
+
+// ResolveConfigConnectorVersion returns the version of Config Connector to install for the given
+// ConfigConnector object: the pinned version if set, otherwise the latest version in the stable channel.
+func ResolveConfigConnectorVersion(ctx context.Context, repo Repository, cc *corev1beta1.ConfigConnector) (string, error) {
+	componentName := k8s.ConfigConnectorComponentName
+	channelName := k8s.StableChannel
+	if cc.Spec.Version != "" {
+		if err := validateVersionInChannel(ctx, repo, componentName, channelName, cc.Spec.Version); err != nil {
+			return "", fmt.Errorf("error validating the version of ConfigConnector %v: %v", cc.Name, err)
+		}
+		return cc.Spec.Version, nil
+	}
+	version, err := ResolveVersion(ctx, repo, componentName, channelName)
+	if err != nil {
+		return "", fmt.Errorf("error resolving the version for %v in %v channel: %v", componentName, channelName, err)
+	}
+	return version, nil
+}
+
+// ResolveNamespacedVersion returns the version of the per-namespace components to install for the given
+// ConfigConnectorContext object: the canary version of the ConfigConnector object if the ConfigConnectorContext
+// object is selected for the canary, otherwise the version of Config Connector installed for the cluster.
+// cc is nil if there is no ConfigConnector object.
+func ResolveNamespacedVersion(ctx context.Context, repo Repository, cc *corev1beta1.ConfigConnector, ccc *corev1beta1.ConfigConnectorContext) (string, error) {
+	if cc == nil {
+		return ResolveConfigConnectorVersion(ctx, repo, &corev1beta1.ConfigConnector{})
+	}
+	version, err := ResolveConfigConnectorVersion(ctx, repo, cc)
+	if err != nil {
+		return "", err
+	}
+	canary := cc.Spec.Canary
+	if canary == nil {
+		return version, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&canary.Selector)
+	if err != nil {
+		return "", fmt.Errorf("error parsing the canary selector of ConfigConnector %v: %v", cc.Name, err)
+	}
+	if !selector.Matches(labels.Set(ccc.GetLabels())) {
+		return version, nil
+	}
+	if err := validateVersionInChannel(ctx, repo, k8s.ConfigConnectorComponentName, k8s.StableChannel, canary.Version); err != nil {
+		return "", fmt.Errorf("error validating the canary version of ConfigConnector %v: %v", cc.Name, err)
+	}
+	if err := validateCanaryVersion(canary.Version, version); err != nil {
+		return "", err
+	}
+	return canary.Version, nil
+}
+
+func validateCanaryVersion(canaryVersionRaw, versionRaw string) error {
+	canaryVersion, err := semver.ParseTolerant(canaryVersionRaw)
+	if err != nil {
+		return fmt.Errorf("the canary version %v is not a valid semantic version: %v", canaryVersionRaw, err)
+	}
+	version, err := semver.ParseTolerant(versionRaw)
+	if err != nil {
+		return fmt.Errorf("the version %v is not a valid semantic version: %v", versionRaw, err)
+	}
+	if canaryVersion.Major != version.Major {
+		return fmt.Errorf("the canary version %v must have the same major version as the version %v installed for the cluster", canaryVersion, version)
+	}
+	return nil
+}
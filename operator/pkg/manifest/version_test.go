@@ -0,0 +1,220 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest_test
+
+import (
+	"context"
+	"testing"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/manifest"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/addon/pkg/loaders"
+)
+
+// channelRepository is a repository whose stable channel lists the given versions.
+type channelRepository struct {
+	manifest.Repository
+	channel *loaders.Channel
+}
+
+func (r *channelRepository) LoadChannel(ctx context.Context, name string) (*loaders.Channel, error) {
+	return r.channel, nil
+}
+
+func newTestChannelRepository(versions ...string) manifest.Repository {
+	channel := &loaders.Channel{}
+	for _, v := range versions {
+		channel.Manifests = append(channel.Manifests, loaders.Version{Version: v})
+	}
+	return &channelRepository{channel: channel}
+}
+
+func TestValidateVersion(t *testing.T) {
+	t.Parallel()
+	for _, v := range []string{"1.98.0", "0.0.0-test", "2.0.0-rc.1"} {
+		if err := manifest.ValidateVersion(v); err != nil {
+			t.Errorf("unexpected error for version %v: %v", v, err)
+		}
+	}
+	for _, v := range []string{"", "latest", "v1.98.0", "1.98", "../1.98.0", "1.98.0/../../etc", "1.98.0-../x"} {
+		if err := manifest.ValidateVersion(v); err == nil {
+			t.Errorf("expected an error for version %v, got none", v)
+		}
+	}
+}
+
+func TestResolveConfigConnectorVersion(t *testing.T) {
+	t.Parallel()
+	_, localRepo := newTestNewLocalRepository(t)
+	repo := newTestChannelRepository("1.2.3", "1.3.0")
+	tests := []struct {
+		name    string
+		repo    manifest.Repository
+		cc      *corev1beta1.ConfigConnector
+		version string
+		hasErr  bool
+	}{
+		{
+			name:    "latest version in the stable channel",
+			repo:    localRepo,
+			cc:      &corev1beta1.ConfigConnector{},
+			version: "0.0.0-test",
+		},
+		{
+			name: "pinned version",
+			repo: repo,
+			cc: &corev1beta1.ConfigConnector{
+				Spec: corev1beta1.ConfigConnectorSpec{
+					Version: "1.2.3",
+				},
+			},
+			version: "1.2.3",
+		},
+		{
+			name: "pinned version not in the stable channel",
+			repo: repo,
+			cc: &corev1beta1.ConfigConnector{
+				Spec: corev1beta1.ConfigConnectorSpec{
+					Version: "1.4.0",
+				},
+			},
+			hasErr: true,
+		},
+		{
+			name: "invalid pinned version",
+			repo: repo,
+			cc: &corev1beta1.ConfigConnector{
+				Spec: corev1beta1.ConfigConnectorSpec{
+					Version: "../1.2.3",
+				},
+			},
+			hasErr: true,
+		},
+	}
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			v, err := manifest.ResolveConfigConnectorVersion(context.TODO(), tc.repo, tc.cc)
+			if tc.hasErr {
+				if err == nil {
+					t.Fatalf("expected an error, got version %v", v)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if v != tc.version {
+				t.Fatalf("got version %v, want %v", v, tc.version)
+			}
+		})
+	}
+}
+
+func TestResolveNamespacedVersion(t *testing.T) {
+	t.Parallel()
+	repo := newTestChannelRepository("1.2.3", "1.3.0", "2.0.0", "2.1.0")
+	canaryCC := func(canaryVersion string) *corev1beta1.ConfigConnector {
+		return &corev1beta1.ConfigConnector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: k8s.ConfigConnectorAllowedName,
+			},
+			Spec: corev1beta1.ConfigConnectorSpec{
+				Version: "1.2.3",
+				Canary: &corev1beta1.CanarySpec{
+					Version: canaryVersion,
+					Selector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"canary": "true"},
+					},
+				},
+			},
+		}
+	}
+	newCCC := func(labels map[string]string) *corev1beta1.ConfigConnectorContext {
+		return &corev1beta1.ConfigConnectorContext{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      k8s.ConfigConnectorContextAllowedName,
+				Namespace: "foo-ns",
+				Labels:    labels,
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		cc      *corev1beta1.ConfigConnector
+		ccc     *corev1beta1.ConfigConnectorContext
+		version string
+		hasErr  bool
+	}{
+		{
+			name:    "no ConfigConnector object",
+			ccc:     newCCC(nil),
+			version: "2.1.0",
+		},
+		{
+			name:    "not selected for the canary",
+			cc:      canaryCC("1.3.0"),
+			ccc:     newCCC(map[string]string{"canary": "false"}),
+			version: "1.2.3",
+		},
+		{
+			name:    "selected for the canary",
+			cc:      canaryCC("1.3.0"),
+			ccc:     newCCC(map[string]string{"canary": "true"}),
+			version: "1.3.0",
+		},
+		{
+			name:   "canary version with a different major version",
+			cc:     canaryCC("2.0.0"),
+			ccc:    newCCC(map[string]string{"canary": "true"}),
+			hasErr: true,
+		},
+		{
+			name:   "canary version not in the stable channel",
+			cc:     canaryCC("1.4.0"),
+			ccc:    newCCC(map[string]string{"canary": "true"}),
+			hasErr: true,
+		},
+		{
+			name:   "invalid canary version",
+			cc:     canaryCC("latest"),
+			ccc:    newCCC(map[string]string{"canary": "true"}),
+			hasErr: true,
+		},
+	}
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			v, err := manifest.ResolveNamespacedVersion(context.TODO(), repo, tc.cc, tc.ccc)
+			if tc.hasErr {
+				if err == nil {
+					t.Fatalf("expected an error, got version %v", v)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if v != tc.version {
+				t.Fatalf("got version %v, want %v", v, tc.version)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/manifest"
+
+	"github.com/blang/semver"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	mlog = ctrl.Log.WithName("Migrator")
+)
+
+// Migrator executes the migration steps declared by the version of Config Connector to deploy
+// for upgrades from the version of Config Connector that is currently installed.
+type Migrator struct {
+	client client.Client
+	repo   manifest.Repository
+}
+
+func NewMigrator(client client.Client, repo manifest.Repository) *Migrator {
+	return &Migrator{client: client, repo: repo}
+}
+
+// Migrate executes the pending migration steps, if any, and records the progress in the status of the
+// given ConfigConnector object. Completed steps are skipped, so a failed migration resumes at the
+// failed step on the next reconciliation.
+func (m *Migrator) Migrate(ctx context.Context, cc *corev1beta1.ConfigConnector) error {
+	currentVersion, err := m.currentVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if currentVersion == nil {
+		return nil
+	}
+	versionToDeployRaw, err := manifest.ResolveConfigConnectorVersion(ctx, m.repo, cc)
+	if err != nil {
+		return fmt.Errorf("error resolving the version to deploy: %w", err)
+	}
+	versionToDeploy, err := semver.ParseTolerant(versionToDeployRaw)
+	if err != nil {
+		return fmt.Errorf("the version to deploy %v is not a valid semantic version: %w", versionToDeployRaw, err)
+	}
+	if !versionToDeploy.GT(*currentVersion) {
+		return nil
+	}
+	plan, err := FindPlan(ctx, m.repo, k8s.ConfigConnectorComponentName, versionToDeployRaw, *currentVersion)
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		return nil
+	}
+
+	status := cc.Status.Migration
+	if status == nil || status.FromVersion != currentVersion.String() || status.ToVersion != versionToDeploy.String() {
+		status = &corev1beta1.MigrationStatus{
+			FromVersion: currentVersion.String(),
+			ToVersion:   versionToDeploy.String(),
+		}
+	}
+	if status.Completed {
+		return nil
+	}
+	completed := make(map[string]bool)
+	for _, s := range status.CompletedSteps {
+		completed[s] = true
+	}
+	for _, step := range plan.Steps {
+		if completed[step.Name] {
+			continue
+		}
+		mlog.Info("executing migration step", "step", step.Name, "from", status.FromVersion, "to", status.ToVersion)
+		if err := m.execute(ctx, step); err != nil {
+			return fmt.Errorf("error executing migration step %v for the upgrade from version %v to %v: %w", step.Name, status.FromVersion, status.ToVersion, err)
+		}
+		status.CompletedSteps = append(status.CompletedSteps, step.Name)
+		if err := m.updateStatus(ctx, cc, status); err != nil {
+			return err
+		}
+	}
+	status.Completed = true
+	return m.updateStatus(ctx, cc, status)
+}
+
+// currentVersion returns the version of Config Connector annotated on the cnrm-system namespace,
+// or nil if Config Connector is not installed yet.
+func (m *Migrator) currentVersion(ctx context.Context) (*semver.Version, error) {
+	ns := &corev1.Namespace{}
+	if err := m.client.Get(ctx, types.NamespacedName{Name: k8s.CNRMSystemNamespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting %v namespace: %w", k8s.CNRMSystemNamespace, err)
+	}
+	raw := ns.GetAnnotations()[k8s.VersionAnnotation]
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := semver.ParseTolerant(raw)
+	if err != nil {
+		return nil, fmt.Errorf("current version %v is not a valid semantic version: %w", raw, err)
+	}
+	return &v, nil
+}
+
+func (m *Migrator) updateStatus(ctx context.Context, cc *corev1beta1.ConfigConnector, status *corev1beta1.MigrationStatus) error {
+	cc.Status.Migration = status.DeepCopy()
+	if err := m.client.Status().Update(ctx, cc); err != nil {
+		return fmt.Errorf("failed to update the migration status of ConfigConnector %v on API server: %w", cc.GetName(), err)
+	}
+	return nil
+}
+
+func (m *Migrator) execute(ctx context.Context, step Step) error {
+	switch {
+	case step.StorageVersionMigration != nil:
+		return migrateStorageVersion(ctx, m.client, step.StorageVersionMigration)
+	case step.AnnotationRewrite != nil:
+		return rewriteAnnotation(ctx, m.client, step.AnnotationRewrite)
+	case step.ComponentRemoval != nil:
+		return removeComponent(ctx, m.client, step.ComponentRemoval)
+	default:
+		return fmt.Errorf("no action is set")
+	}
+}
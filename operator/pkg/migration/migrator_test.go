@@ -0,0 +1,498 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/addon/pkg/loaders"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name             string
+		installedVersion string
+		status           *corev1beta1.MigrationStatus
+		failingObject    string
+		expectedStatus   *corev1beta1.MigrationStatus
+		expectedUpdates  []string
+		expectedDeletes  []string
+		shouldErr        bool
+	}{
+		{
+			name:             "nothing is migrated if Config Connector is not installed",
+			installedVersion: "",
+		},
+		{
+			name:             "nothing is migrated if the installed version is the version to deploy",
+			installedVersion: "2.0.0",
+		},
+		{
+			name:             "all the steps are executed for an upgrade with a plan",
+			installedVersion: "1.5.0",
+			expectedStatus: &corev1beta1.MigrationStatus{
+				FromVersion:    "1.5.0",
+				ToVersion:      "2.0.0",
+				CompletedSteps: []string{"migrate-foos-storage-version", "rename-foo-annotation", "remove-recorder"},
+				Completed:      true,
+			},
+			expectedUpdates: []string{"Foo default/foo-1", "Foo default/foo-2", "Foo default/foo-1"},
+			expectedDeletes: []string{"Deployment cnrm-system/cnrm-resource-stats-recorder"},
+		},
+		{
+			name:             "completed steps are skipped when resuming from the status",
+			installedVersion: "1.5.0",
+			status: &corev1beta1.MigrationStatus{
+				FromVersion:    "1.5.0",
+				ToVersion:      "2.0.0",
+				CompletedSteps: []string{"migrate-foos-storage-version", "rename-foo-annotation"},
+			},
+			expectedStatus: &corev1beta1.MigrationStatus{
+				FromVersion:    "1.5.0",
+				ToVersion:      "2.0.0",
+				CompletedSteps: []string{"migrate-foos-storage-version", "rename-foo-annotation", "remove-recorder"},
+				Completed:      true,
+			},
+			expectedDeletes: []string{"Deployment cnrm-system/cnrm-resource-stats-recorder"},
+		},
+		{
+			name:             "the status of a previous upgrade is not resumed",
+			installedVersion: "1.5.0",
+			status: &corev1beta1.MigrationStatus{
+				FromVersion:    "0.9.0",
+				ToVersion:      "1.5.0",
+				CompletedSteps: []string{"remove-recorder"},
+				Completed:      true,
+			},
+			expectedStatus: &corev1beta1.MigrationStatus{
+				FromVersion:    "1.5.0",
+				ToVersion:      "2.0.0",
+				CompletedSteps: []string{"migrate-foos-storage-version", "rename-foo-annotation", "remove-recorder"},
+				Completed:      true,
+			},
+			expectedUpdates: []string{"Foo default/foo-1", "Foo default/foo-2", "Foo default/foo-1"},
+			expectedDeletes: []string{"Deployment cnrm-system/cnrm-resource-stats-recorder"},
+		},
+		{
+			name:             "a completed migration is not executed again",
+			installedVersion: "1.5.0",
+			status: &corev1beta1.MigrationStatus{
+				FromVersion:    "1.5.0",
+				ToVersion:      "2.0.0",
+				CompletedSteps: []string{"migrate-foos-storage-version", "rename-foo-annotation", "remove-recorder"},
+				Completed:      true,
+			},
+			expectedStatus: &corev1beta1.MigrationStatus{
+				FromVersion:    "1.5.0",
+				ToVersion:      "2.0.0",
+				CompletedSteps: []string{"migrate-foos-storage-version", "rename-foo-annotation", "remove-recorder"},
+				Completed:      true,
+			},
+		},
+		{
+			name:             "the steps completed before a failed step are recorded",
+			installedVersion: "1.5.0",
+			failingObject:    "cnrm-resource-stats-recorder",
+			expectedStatus: &corev1beta1.MigrationStatus{
+				FromVersion:    "1.5.0",
+				ToVersion:      "2.0.0",
+				CompletedSteps: []string{"migrate-foos-storage-version", "rename-foo-annotation"},
+			},
+			expectedUpdates: []string{"Foo default/foo-1", "Foo default/foo-2", "Foo default/foo-1"},
+			shouldErr:       true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+			cc := &corev1beta1.ConfigConnector{
+				ObjectMeta: metav1.ObjectMeta{Name: k8s.ConfigConnectorAllowedName},
+				Spec:       corev1beta1.ConfigConnectorSpec{Version: "2.0.0"},
+				Status:     corev1beta1.ConfigConnectorStatus{Migration: tc.status},
+			}
+			objs := []client.Object{cc, newFooCRD(), newFoo("foo-1", "bar"), newFoo("foo-2", ""), newRecorder()}
+			if tc.installedVersion != "" {
+				objs = append(objs, newCNRMSystemNamespace(tc.installedVersion))
+			}
+			c := newFakeClient(t, objs...)
+			if tc.failingObject != "" {
+				c.errs[tc.failingObject] = fmt.Errorf("injected error")
+			}
+			m := NewMigrator(c, newFakeRepository("1.5.0", "2.0.0"))
+			err := m.Migrate(ctx, cc)
+			if tc.shouldErr != (err != nil) {
+				t.Fatalf("got error '%v', want an error: %v", err, tc.shouldErr)
+			}
+			got := &corev1beta1.ConfigConnector{}
+			if err := c.Get(ctx, types.NamespacedName{Name: cc.Name}, got); err != nil {
+				t.Fatalf("error getting ConfigConnector: %v", err)
+			}
+			expectedStatus := tc.expectedStatus
+			if expectedStatus == nil {
+				expectedStatus = tc.status
+			}
+			if !reflect.DeepEqual(got.Status.Migration, expectedStatus) {
+				t.Errorf("got migration status %+v, want %+v", got.Status.Migration, expectedStatus)
+			}
+			if !reflect.DeepEqual(c.updates, tc.expectedUpdates) {
+				t.Errorf("got updates %v, want %v", c.updates, tc.expectedUpdates)
+			}
+			if !reflect.DeepEqual(c.deletes, tc.expectedDeletes) {
+				t.Errorf("got deletes %v, want %v", c.deletes, tc.expectedDeletes)
+			}
+		})
+	}
+}
+
+func TestMigrateResumesAfterFailedStep(t *testing.T) {
+	ctx := context.TODO()
+	cc := &corev1beta1.ConfigConnector{
+		ObjectMeta: metav1.ObjectMeta{Name: k8s.ConfigConnectorAllowedName},
+		Spec:       corev1beta1.ConfigConnectorSpec{Version: "2.0.0"},
+	}
+	c := newFakeClient(t, cc, newCNRMSystemNamespace("1.5.0"), newFooCRD(), newFoo("foo-1", "bar"), newRecorder())
+	c.errs["cnrm-resource-stats-recorder"] = fmt.Errorf("injected error")
+	m := NewMigrator(c, newFakeRepository("1.5.0", "2.0.0"))
+	if err := m.Migrate(ctx, cc); err == nil {
+		t.Fatalf("got nil error, want an error")
+	}
+	delete(c.errs, "cnrm-resource-stats-recorder")
+	c.updates = nil
+	if err := m.Migrate(ctx, cc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.updates != nil {
+		t.Errorf("got updates %v after resuming, want the completed steps to be skipped", c.updates)
+	}
+	expectedDeletes := []string{"Deployment cnrm-system/cnrm-resource-stats-recorder"}
+	if !reflect.DeepEqual(c.deletes, expectedDeletes) {
+		t.Errorf("got deletes %v, want %v", c.deletes, expectedDeletes)
+	}
+	if cc.Status.Migration == nil || !cc.Status.Migration.Completed {
+		t.Errorf("got migration status %+v, want the migration to be completed", cc.Status.Migration)
+	}
+}
+
+func newCNRMSystemNamespace(version string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        k8s.CNRMSystemNamespace,
+			Annotations: map[string]string{k8s.VersionAnnotation: version},
+		},
+	}
+}
+
+func newFooCRD() *apiextensions.CustomResourceDefinition {
+	return &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "foos.test.cnrm.cloud.google.com",
+			Labels: map[string]string{k8s.KCCSystemLabelSelectorRaw: "true"},
+		},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group: "test.cnrm.cloud.google.com",
+			Names: apiextensions.CustomResourceDefinitionNames{Kind: "Foo", Plural: "foos"},
+			Versions: []apiextensions.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: true},
+				{Name: "v1beta1", Served: true, Storage: true},
+			},
+		},
+		Status: apiextensions.CustomResourceDefinitionStatus{
+			StoredVersions: []string{"v1alpha1", "v1beta1"},
+		},
+	}
+}
+
+func newFoo(name, fooAnnotation string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("test.cnrm.cloud.google.com/v1beta1")
+	u.SetKind("Foo")
+	u.SetNamespace("default")
+	u.SetName(name)
+	if fooAnnotation != "" {
+		u.SetAnnotations(map[string]string{"cnrm.cloud.google.com/foo": fooAnnotation})
+	}
+	return u
+}
+
+func newRecorder() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("apps/v1")
+	u.SetKind("Deployment")
+	u.SetNamespace(k8s.CNRMSystemNamespace)
+	u.SetName("cnrm-resource-stats-recorder")
+	return u
+}
+
+// fakeRepository serves a stable channel with the given versions, each of which declares the test migrations.
+type fakeRepository struct {
+	versions []string
+}
+
+func newFakeRepository(versions ...string) *fakeRepository {
+	return &fakeRepository{versions: versions}
+}
+
+func (r *fakeRepository) LoadChannel(_ context.Context, _ string) (*loaders.Channel, error) {
+	channel := &loaders.Channel{}
+	for _, v := range r.versions {
+		channel.Manifests = append(channel.Manifests, loaders.Version{Package: k8s.ConfigConnectorComponentName, Version: v})
+	}
+	return channel, nil
+}
+
+func (r *fakeRepository) LoadManifest(_ context.Context, _ string, _ string, _ declarative.DeclarativeObject) (map[string]string, error) {
+	return nil, nil
+}
+
+func (r *fakeRepository) LoadNamespacedComponents(_ context.Context, _ string, _ string) (map[string]string, error) {
+	return nil, nil
+}
+
+func (r *fakeRepository) LoadMigrations(_ context.Context, _ string, _ string) (string, error) {
+	return testMigrations, nil
+}
+
+// fakeClient is an in-memory client.Client which supports the operations used by the migration steps. Objects are
+// stored by group and kind, so that, like on an API server, they can be read at any version. Objects with
+// finalizers are only marked for deletion until their finalizers are removed.
+type fakeClient struct {
+	// unimplemented methods panic
+	client.Client
+	t       *testing.T
+	scheme  *runtime.Scheme
+	objects map[objectKey]*unstructured.Unstructured
+	// errs holds the errors returned when updating or deleting objects, keyed by object name
+	errs map[string]error
+	// the updated and deleted objects, in order, formatted as '<kind> <namespace>/<name>'
+	updates []string
+	deletes []string
+}
+
+type objectKey struct {
+	groupKind schema.GroupKind
+	namespace string
+	name      string
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) *fakeClient {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		corev1.AddToScheme,
+		apiextensions.AddToScheme,
+		corev1beta1.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			t.Fatalf("error building scheme: %v", err)
+		}
+	}
+	c := &fakeClient{
+		t:       t,
+		scheme:  scheme,
+		objects: make(map[objectKey]*unstructured.Unstructured),
+		errs:    make(map[string]error),
+	}
+	for _, obj := range objs {
+		u := c.toUnstructured(obj)
+		c.objects[keyFor(u)] = u
+	}
+	return c
+}
+
+func (c *fakeClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	gvk := c.gvkFor(obj)
+	u, ok := c.objects[objectKey{groupKind: gvk.GroupKind(), namespace: key.Namespace, name: key.Name}]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind}, key.Name)
+	}
+	c.fromUnstructured(u, gvk, obj)
+	return nil
+}
+
+func (c *fakeClient) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	listGVK := c.gvkFor(list)
+	gvk := listGVK.GroupVersion().WithKind(listGVK.Kind[:len(listGVK.Kind)-len("List")])
+	var keys []objectKey
+	for key := range c.objects {
+		if key.groupKind == gvk.GroupKind() {
+			keys = append(keys, key)
+		}
+	}
+	// list in a stable order, like an API server
+	sort.Slice(keys, func(i, j int) bool {
+		return formatKey(keys[i]) < formatKey(keys[j])
+	})
+	var items []runtime.Object
+	for _, key := range keys {
+		u := c.objects[key]
+		if listOpts.Namespace != "" && key.namespace != listOpts.Namespace {
+			continue
+		}
+		if listOpts.LabelSelector != nil && !listOpts.LabelSelector.Matches(labels.Set(u.GetLabels())) {
+			continue
+		}
+		var item client.Object = &unstructured.Unstructured{}
+		if _, ok := list.(*unstructured.UnstructuredList); !ok {
+			typed, err := c.scheme.New(gvk)
+			if err != nil {
+				c.t.Fatalf("error creating %v: %v", gvk, err)
+			}
+			item = typed.(client.Object)
+		}
+		c.fromUnstructured(u, gvk, item)
+		items = append(items, item)
+	}
+	if err := meta.SetList(list, items); err != nil {
+		c.t.Fatalf("error setting list items: %v", err)
+	}
+	return nil
+}
+
+func (c *fakeClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	u := c.toUnstructured(obj)
+	key := keyFor(u)
+	existing, ok := c.objects[key]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{Group: key.groupKind.Group, Resource: key.groupKind.Kind}, key.name)
+	}
+	if err, ok := c.errs[key.name]; ok {
+		return err
+	}
+	c.updates = append(c.updates, formatKey(key))
+	// the deletion timestamp can't be changed and the status is only updated through the status writer
+	u.SetDeletionTimestamp(existing.GetDeletionTimestamp())
+	if status, ok := existing.Object["status"]; ok {
+		u.Object["status"] = status
+	} else {
+		delete(u.Object, "status")
+	}
+	c.store(key, u)
+	return nil
+}
+
+func (c *fakeClient) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	key := keyFor(c.toUnstructured(obj))
+	existing, ok := c.objects[key]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{Group: key.groupKind.Group, Resource: key.groupKind.Kind}, key.name)
+	}
+	if err, ok := c.errs[key.name]; ok {
+		return err
+	}
+	c.deletes = append(c.deletes, formatKey(key))
+	now := metav1.Now()
+	existing.SetDeletionTimestamp(&now)
+	c.store(key, existing)
+	return nil
+}
+
+func (c *fakeClient) Status() client.StatusWriter {
+	return &fakeStatusWriter{c: c}
+}
+
+// store saves the given object, or removes it if it is marked for deletion and has no finalizers left.
+func (c *fakeClient) store(key objectKey, u *unstructured.Unstructured) {
+	if u.GetDeletionTimestamp() != nil && len(u.GetFinalizers()) == 0 {
+		delete(c.objects, key)
+		return
+	}
+	c.objects[key] = u
+}
+
+func (c *fakeClient) gvkFor(obj runtime.Object) schema.GroupVersionKind {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		c.t.Fatalf("error getting GroupVersionKind: %v", err)
+	}
+	return gvk
+}
+
+func (c *fakeClient) toUnstructured(obj client.Object) *unstructured.Unstructured {
+	gvk := c.gvkFor(obj)
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		c.t.Fatalf("error converting %v to unstructured: %v", gvk, err)
+	}
+	u := &unstructured.Unstructured{Object: raw}
+	u.SetGroupVersionKind(gvk)
+	return u
+}
+
+// fromUnstructured copies the given stored object into obj at the given version.
+func (c *fakeClient) fromUnstructured(u *unstructured.Unstructured, gvk schema.GroupVersionKind, obj client.Object) {
+	copied := u.DeepCopy()
+	copied.SetGroupVersionKind(gvk)
+	if out, ok := obj.(*unstructured.Unstructured); ok {
+		out.Object = copied.Object
+		return
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(copied.Object, obj); err != nil {
+		c.t.Fatalf("error converting unstructured to %v: %v", gvk, err)
+	}
+}
+
+type fakeStatusWriter struct {
+	c *fakeClient
+}
+
+func (w *fakeStatusWriter) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	u := w.c.toUnstructured(obj)
+	key := keyFor(u)
+	existing, ok := w.c.objects[key]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{Group: key.groupKind.Group, Resource: key.groupKind.Kind}, key.name)
+	}
+	updated := existing.DeepCopy()
+	updated.Object["status"] = u.Object["status"]
+	w.c.objects[key] = updated
+	return nil
+}
+
+func (w *fakeStatusWriter) Patch(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	panic("not implemented")
+}
+
+func keyFor(u *unstructured.Unstructured) objectKey {
+	return objectKey{
+		groupKind: u.GroupVersionKind().GroupKind(),
+		namespace: u.GetNamespace(),
+		name:      u.GetName(),
+	}
+}
+
+func formatKey(key objectKey) string {
+	return fmt.Sprintf("%v %v/%v", key.groupKind.Kind, key.namespace, key.name)
+}
@@ -0,0 +1,157 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/manifest"
+
+	"github.com/blang/semver"
+	"sigs.k8s.io/yaml"
+)
+
+// Migrations is the content of the migrations file of a channel version. It declares
+// the steps that the operator executes before rolling out the manifests of the version.
+type Migrations struct {
+	Plans []Plan `json:"migrations"`
+}
+
+// Plan declares the steps to execute when upgrading to the version that declares the plan
+// from any of the versions in FromVersions.
+type Plan struct {
+	// A semantic version range, e.g. ">=1.0.0 <2.0.0".
+	FromVersions string `json:"fromVersions"`
+	Steps        []Step `json:"steps"`
+
+	fromVersions semver.Range
+}
+
+// Step is a single migration step. Exactly one of its actions must be set.
+// The name of the step is used to record its completion in the status of the ConfigConnector object.
+type Step struct {
+	Name                    string                   `json:"name"`
+	StorageVersionMigration *StorageVersionMigration `json:"storageVersionMigration,omitempty"`
+	AnnotationRewrite       *AnnotationRewrite       `json:"annotationRewrite,omitempty"`
+	ComponentRemoval        *ComponentRemoval        `json:"componentRemoval,omitempty"`
+}
+
+// StorageVersionMigration rewrites all the objects of a CRD so that they are stored at the current
+// storage version of the CRD, then drops all the other versions from the stored versions of the CRD.
+// This is required before rolling out a CRD that no longer serves a previously stored version.
+type StorageVersionMigration struct {
+	CRD string `json:"crd"`
+}
+
+// AnnotationRewrite renames an annotation on all the Config Connector resources of the given CRDs,
+// or of all the Config Connector CRDs if no CRD is given.
+type AnnotationRewrite struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	CRDs []string `json:"crds,omitempty"`
+}
+
+// ComponentRemoval deletes an object that is no longer part of the new version.
+type ComponentRemoval struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// Parse parses and validates the content of a migrations file.
+func Parse(raw string) (*Migrations, error) {
+	m := &Migrations{}
+	if err := yaml.Unmarshal([]byte(raw), m); err != nil {
+		return nil, fmt.Errorf("error parsing migrations: %w", err)
+	}
+	for i := range m.Plans {
+		p := &m.Plans[i]
+		r, err := semver.ParseRange(p.FromVersions)
+		if err != nil {
+			return nil, fmt.Errorf("fromVersions %q of migration %v is not a valid semantic version range: %w", p.FromVersions, i, err)
+		}
+		p.fromVersions = r
+		names := make(map[string]bool)
+		for _, s := range p.Steps {
+			if s.Name == "" {
+				return nil, fmt.Errorf("migration %v has a step without a name", i)
+			}
+			if names[s.Name] {
+				return nil, fmt.Errorf("migration %v has more than one step named %v", i, s.Name)
+			}
+			names[s.Name] = true
+			if err := s.validate(); err != nil {
+				return nil, fmt.Errorf("invalid step %v in migration %v: %w", s.Name, i, err)
+			}
+		}
+	}
+	return m, nil
+}
+
+func (s *Step) validate() error {
+	actions := 0
+	if s.StorageVersionMigration != nil {
+		actions++
+		if s.StorageVersionMigration.CRD == "" {
+			return fmt.Errorf("storageVersionMigration.crd is required")
+		}
+	}
+	if s.AnnotationRewrite != nil {
+		actions++
+		if s.AnnotationRewrite.From == "" || s.AnnotationRewrite.To == "" {
+			return fmt.Errorf("annotationRewrite.from and annotationRewrite.to are required")
+		}
+	}
+	if s.ComponentRemoval != nil {
+		actions++
+		c := s.ComponentRemoval
+		if c.APIVersion == "" || c.Kind == "" || c.Name == "" {
+			return fmt.Errorf("componentRemoval.apiVersion, componentRemoval.kind and componentRemoval.name are required")
+		}
+	}
+	if actions != 1 {
+		return fmt.Errorf("exactly one action must be set, got %v", actions)
+	}
+	return nil
+}
+
+// PlanFor returns the first plan that applies to upgrades from the given version, or nil if there is none.
+func (m *Migrations) PlanFor(from semver.Version) *Plan {
+	for i := range m.Plans {
+		if m.Plans[i].fromVersions(from) {
+			return &m.Plans[i]
+		}
+	}
+	return nil
+}
+
+// FindPlan returns the plan declared by the given version of the component for upgrades from the
+// given version, or nil if there is none.
+func FindPlan(ctx context.Context, repo manifest.Repository, componentName string, version string, from semver.Version) (*Plan, error) {
+	raw, err := repo.LoadMigrations(ctx, componentName, version)
+	if err != nil {
+		return nil, err
+	}
+	if raw == "" {
+		return nil, nil
+	}
+	m, err := Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("error loading the migrations of version %v: %w", version, err)
+	}
+	return m.PlanFor(from), nil
+}
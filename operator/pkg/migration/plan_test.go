@@ -0,0 +1,165 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+)
+
+var testMigrations = `
+migrations:
+- fromVersions: ">=1.0.0 <2.0.0"
+  steps:
+  - name: migrate-foos-storage-version
+    storageVersionMigration:
+      crd: foos.test.cnrm.cloud.google.com
+  - name: rename-foo-annotation
+    annotationRewrite:
+      from: cnrm.cloud.google.com/foo
+      to: cnrm.cloud.google.com/bar
+  - name: remove-recorder
+    componentRemoval:
+      apiVersion: apps/v1
+      kind: Deployment
+      namespace: cnrm-system
+      name: cnrm-resource-stats-recorder
+- fromVersions: "<1.0.0"
+  steps:
+  - name: remove-recorder
+    componentRemoval:
+      apiVersion: apps/v1
+      kind: Deployment
+      namespace: cnrm-system
+      name: cnrm-resource-stats-recorder
+`
+
+func TestPlanFor(t *testing.T) {
+	m, err := Parse(testMigrations)
+	if err != nil {
+		t.Fatalf("unexpected error parsing migrations: %v", err)
+	}
+	tests := []struct {
+		name  string
+		from  string
+		steps int
+	}{
+		{
+			name:  "from a version in the first plan",
+			from:  "1.98.0",
+			steps: 3,
+		},
+		{
+			name:  "from a version in the second plan",
+			from:  "0.9.0",
+			steps: 1,
+		},
+		{
+			name:  "from a version without a plan",
+			from:  "2.1.0",
+			steps: 0,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			plan := m.PlanFor(semver.MustParse(tc.from))
+			if tc.steps == 0 {
+				if plan != nil {
+					t.Fatalf("expected no plan, got %v", plan)
+				}
+				return
+			}
+			if plan == nil {
+				t.Fatalf("expected a plan, got nil")
+			}
+			if len(plan.Steps) != tc.steps {
+				t.Fatalf("got %v steps, want %v", len(plan.Steps), tc.steps)
+			}
+		})
+	}
+}
+
+func TestParseInvalidMigrations(t *testing.T) {
+	tests := []struct {
+		name       string
+		migrations string
+	}{
+		{
+			name: "invalid version range",
+			migrations: `
+migrations:
+- fromVersions: "one"
+  steps: []
+`,
+		},
+		{
+			name: "step without a name",
+			migrations: `
+migrations:
+- fromVersions: "<1.0.0"
+  steps:
+  - storageVersionMigration:
+      crd: foos.test.cnrm.cloud.google.com
+`,
+		},
+		{
+			name: "duplicate step names",
+			migrations: `
+migrations:
+- fromVersions: "<1.0.0"
+  steps:
+  - name: foo
+    storageVersionMigration:
+      crd: foos.test.cnrm.cloud.google.com
+  - name: foo
+    storageVersionMigration:
+      crd: bars.test.cnrm.cloud.google.com
+`,
+		},
+		{
+			name: "step with more than one action",
+			migrations: `
+migrations:
+- fromVersions: "<1.0.0"
+  steps:
+  - name: foo
+    storageVersionMigration:
+      crd: foos.test.cnrm.cloud.google.com
+    annotationRewrite:
+      from: cnrm.cloud.google.com/foo
+      to: cnrm.cloud.google.com/bar
+`,
+		},
+		{
+			name: "step without an action",
+			migrations: `
+migrations:
+- fromVersions: "<1.0.0"
+  steps:
+  - name: foo
+`,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Parse(tc.migrations); err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/controllers"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func migrateStorageVersion(ctx context.Context, c client.Client, m *StorageVersionMigration) error {
+	crd := &apiextensions.CustomResourceDefinition{}
+	if err := c.Get(ctx, types.NamespacedName{Name: m.CRD}, crd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("error getting CRD %v: %w", m.CRD, err)
+	}
+	storageVersion := getStorageVersion(crd)
+	if storageVersion == "" {
+		return fmt.Errorf("CRD %v has no storage version", m.CRD)
+	}
+	// A no-op update makes the API server store the object at the current storage version.
+	err := forEachResource(ctx, c, crdGVK(crd, storageVersion), func(u *unstructured.Unstructured) error {
+		if err := c.Update(ctx, u); err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+			return fmt.Errorf("error rewriting %v %v/%v: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	crd.Status.StoredVersions = []string{storageVersion}
+	if err := c.Status().Update(ctx, crd); err != nil {
+		return fmt.Errorf("error updating the stored versions of CRD %v: %w", m.CRD, err)
+	}
+	return nil
+}
+
+func rewriteAnnotation(ctx context.Context, c client.Client, m *AnnotationRewrite) error {
+	crds, err := getCRDs(ctx, c, m.CRDs)
+	if err != nil {
+		return err
+	}
+	for _, crd := range crds {
+		storageVersion := getStorageVersion(&crd)
+		if storageVersion == "" {
+			continue
+		}
+		err := forEachResource(ctx, c, crdGVK(&crd, storageVersion), func(u *unstructured.Unstructured) error {
+			annotations := u.GetAnnotations()
+			val, ok := annotations[m.From]
+			if !ok {
+				return nil
+			}
+			if _, ok := annotations[m.To]; !ok {
+				annotations[m.To] = val
+			}
+			delete(annotations, m.From)
+			u.SetAnnotations(annotations)
+			if err := c.Update(ctx, u); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("error rewriting annotation %v of %v %v/%v: %w", m.From, u.GetKind(), u.GetNamespace(), u.GetName(), err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeComponent(ctx context.Context, c client.Client, m *ComponentRemoval) error {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(m.APIVersion)
+	u.SetKind(m.Kind)
+	if err := c.Get(ctx, types.NamespacedName{Namespace: m.Namespace, Name: m.Name}, u); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("error getting %v %v: %w", m.Kind, m.Name, err)
+	}
+	return controllers.DeleteObject(ctx, c, u)
+}
+
+// getCRDs returns the CRDs with the given names, or all the Config Connector CRDs if no name is given.
+func getCRDs(ctx context.Context, c client.Client, names []string) ([]apiextensions.CustomResourceDefinition, error) {
+	var crds []apiextensions.CustomResourceDefinition
+	if len(names) > 0 {
+		for _, name := range names {
+			crd := apiextensions.CustomResourceDefinition{}
+			if err := c.Get(ctx, types.NamespacedName{Name: name}, &crd); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("error getting CRD %v: %w", name, err)
+			}
+			crds = append(crds, crd)
+		}
+		return crds, nil
+	}
+	pageToken := ""
+	for ok := true; ok; ok = pageToken != "" {
+		var list []apiextensions.CustomResourceDefinition
+		var err error
+		list, pageToken, err = k8s.ListCRDs(ctx, c, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		crds = append(crds, list...)
+	}
+	return crds, nil
+}
+
+func getStorageVersion(crd *apiextensions.CustomResourceDefinition) string {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+func crdGVK(crd *apiextensions.CustomResourceDefinition, version string) schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: version,
+		Kind:    crd.Spec.Names.Kind,
+	}
+}
+
+// forEachResource calls fn on every object of the given kind in all namespaces.
+func forEachResource(ctx context.Context, c client.Client, gvk schema.GroupVersionKind, fn func(u *unstructured.Unstructured) error) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   gvk.Group,
+		Version: gvk.Version,
+		Kind:    gvk.Kind + "List",
+	})
+	opts := &client.ListOptions{Limit: 100}
+	for {
+		if err := c.List(ctx, list, opts); err != nil {
+			return fmt.Errorf("error listing %v: %w", gvk, err)
+		}
+		for i := range list.Items {
+			if err := fn(&list.Items[i]); err != nil {
+				return err
+			}
+		}
+		if list.GetContinue() == "" {
+			return nil
+		}
+		opts.Continue = list.GetContinue()
+	}
+}
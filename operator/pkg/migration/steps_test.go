@@ -0,0 +1,233 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestMigrateStorageVersion(t *testing.T) {
+	noStorageVersionCRD := newFooCRD()
+	for i := range noStorageVersionCRD.Spec.Versions {
+		noStorageVersionCRD.Spec.Versions[i].Storage = false
+	}
+	tests := []struct {
+		name                   string
+		objs                   []client.Object
+		failingObject          string
+		expectedUpdates        []string
+		expectedStoredVersions []string
+		shouldErr              bool
+	}{
+		{
+			name:                   "all the objects are rewritten and only the storage version is stored",
+			objs:                   []client.Object{newFooCRD(), newFoo("foo-1", ""), newFoo("foo-2", "")},
+			expectedUpdates:        []string{"Foo default/foo-1", "Foo default/foo-2"},
+			expectedStoredVersions: []string{"v1beta1"},
+		},
+		{
+			name:                   "the stored versions are updated if there are no objects",
+			objs:                   []client.Object{newFooCRD()},
+			expectedStoredVersions: []string{"v1beta1"},
+		},
+		{
+			name: "a missing CRD is skipped",
+		},
+		{
+			name:      "a CRD without a storage version fails",
+			objs:      []client.Object{noStorageVersionCRD},
+			shouldErr: true,
+		},
+		{
+			name:                   "the stored versions are kept if an object can't be rewritten",
+			objs:                   []client.Object{newFooCRD(), newFoo("foo-1", ""), newFoo("foo-2", "")},
+			failingObject:          "foo-2",
+			expectedUpdates:        []string{"Foo default/foo-1"},
+			expectedStoredVersions: []string{"v1alpha1", "v1beta1"},
+			shouldErr:              true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+			c := newFakeClient(t, tc.objs...)
+			if tc.failingObject != "" {
+				c.errs[tc.failingObject] = fmt.Errorf("injected error")
+			}
+			err := migrateStorageVersion(ctx, c, &StorageVersionMigration{CRD: "foos.test.cnrm.cloud.google.com"})
+			if tc.shouldErr != (err != nil) {
+				t.Fatalf("got error '%v', want an error: %v", err, tc.shouldErr)
+			}
+			if !reflect.DeepEqual(c.updates, tc.expectedUpdates) {
+				t.Errorf("got updates %v, want %v", c.updates, tc.expectedUpdates)
+			}
+			if tc.expectedStoredVersions == nil {
+				return
+			}
+			crd := &apiextensions.CustomResourceDefinition{}
+			if err := c.Get(ctx, types.NamespacedName{Name: "foos.test.cnrm.cloud.google.com"}, crd); err != nil {
+				t.Fatalf("error getting CRD: %v", err)
+			}
+			if !reflect.DeepEqual(crd.Status.StoredVersions, tc.expectedStoredVersions) {
+				t.Errorf("got stored versions %v, want %v", crd.Status.StoredVersions, tc.expectedStoredVersions)
+			}
+		})
+	}
+}
+
+func TestRewriteAnnotation(t *testing.T) {
+	bothAnnotations := newFoo("foo-2", "bar")
+	bothAnnotations.SetAnnotations(map[string]string{
+		"cnrm.cloud.google.com/foo": "bar",
+		"cnrm.cloud.google.com/baz": "qux",
+	})
+	tests := []struct {
+		name                string
+		crds                []string
+		objs                []client.Object
+		expectedUpdates     []string
+		expectedAnnotations map[string]map[string]string
+	}{
+		{
+			name:            "the annotation is renamed on the resources of all the Config Connector CRDs",
+			objs:            []client.Object{newFooCRD(), newFoo("foo-1", "bar"), newFoo("foo-2", "")},
+			expectedUpdates: []string{"Foo default/foo-1"},
+			expectedAnnotations: map[string]map[string]string{
+				"foo-1": {"cnrm.cloud.google.com/baz": "bar"},
+				"foo-2": nil,
+			},
+		},
+		{
+			name:            "the annotation is renamed on the resources of the given CRDs",
+			crds:            []string{"foos.test.cnrm.cloud.google.com"},
+			objs:            []client.Object{newFooCRD(), newFoo("foo-1", "bar")},
+			expectedUpdates: []string{"Foo default/foo-1"},
+			expectedAnnotations: map[string]map[string]string{
+				"foo-1": {"cnrm.cloud.google.com/baz": "bar"},
+			},
+		},
+		{
+			name:            "an existing value of the new annotation is kept",
+			objs:            []client.Object{newFooCRD(), bothAnnotations},
+			expectedUpdates: []string{"Foo default/foo-2"},
+			expectedAnnotations: map[string]map[string]string{
+				"foo-2": {"cnrm.cloud.google.com/baz": "qux"},
+			},
+		},
+		{
+			name: "missing CRDs are skipped",
+			crds: []string{"bars.test.cnrm.cloud.google.com"},
+			objs: []client.Object{newFooCRD(), newFoo("foo-1", "bar")},
+			expectedAnnotations: map[string]map[string]string{
+				"foo-1": {"cnrm.cloud.google.com/foo": "bar"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+			c := newFakeClient(t, tc.objs...)
+			m := &AnnotationRewrite{From: "cnrm.cloud.google.com/foo", To: "cnrm.cloud.google.com/baz", CRDs: tc.crds}
+			if err := rewriteAnnotation(ctx, c, m); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(c.updates, tc.expectedUpdates) {
+				t.Errorf("got updates %v, want %v", c.updates, tc.expectedUpdates)
+			}
+			for name, expected := range tc.expectedAnnotations {
+				u := newFoo(name, "")
+				if err := c.Get(ctx, types.NamespacedName{Namespace: u.GetNamespace(), Name: name}, u); err != nil {
+					t.Fatalf("error getting %v: %v", name, err)
+				}
+				if got := u.GetAnnotations(); !reflect.DeepEqual(got, expected) {
+					t.Errorf("got annotations %v on %v, want %v", got, name, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveComponent(t *testing.T) {
+	withFinalizer := newRecorder()
+	withFinalizer.SetFinalizers([]string{k8s.OperatorFinalizer})
+	tests := []struct {
+		name            string
+		objs            []client.Object
+		failingObject   string
+		expectedDeletes []string
+		shouldErr       bool
+	}{
+		{
+			name:            "the component is deleted",
+			objs:            []client.Object{newRecorder()},
+			expectedDeletes: []string{"Deployment cnrm-system/cnrm-resource-stats-recorder"},
+		},
+		{
+			name:            "the operator finalizer of the component is removed",
+			objs:            []client.Object{withFinalizer},
+			expectedDeletes: []string{"Deployment cnrm-system/cnrm-resource-stats-recorder"},
+		},
+		{
+			name: "a missing component is skipped",
+		},
+		{
+			name:          "an error deleting the component is returned",
+			objs:          []client.Object{newRecorder()},
+			failingObject: "cnrm-resource-stats-recorder",
+			shouldErr:     true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+			c := newFakeClient(t, tc.objs...)
+			if tc.failingObject != "" {
+				c.errs[tc.failingObject] = fmt.Errorf("injected error")
+			}
+			m := &ComponentRemoval{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Namespace:  k8s.CNRMSystemNamespace,
+				Name:       "cnrm-resource-stats-recorder",
+			}
+			err := removeComponent(ctx, c, m)
+			if tc.shouldErr != (err != nil) {
+				t.Fatalf("got error '%v', want an error: %v", err, tc.shouldErr)
+			}
+			if !reflect.DeepEqual(c.deletes, tc.expectedDeletes) {
+				t.Errorf("got deletes %v, want %v", c.deletes, tc.expectedDeletes)
+			}
+			if tc.shouldErr {
+				return
+			}
+			u := &unstructured.Unstructured{}
+			u.SetAPIVersion(m.APIVersion)
+			u.SetKind(m.Kind)
+			if err := c.Get(ctx, types.NamespacedName{Namespace: m.Namespace, Name: m.Name}, u); err == nil {
+				t.Errorf("got %v %v after removing it, want it to be deleted", m.Kind, m.Name)
+			}
+		})
+	}
+}
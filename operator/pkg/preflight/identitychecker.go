@@ -0,0 +1,194 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/gcp"
+
+	iamv1 "google.golang.org/api/iam/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative"
+)
+
+var (
+	ilog = ctrl.Log.WithName("IdentityChecker")
+)
+
+// ServiceAccountClient reads Google Service Accounts and their IAM policies.
+type ServiceAccountClient interface {
+	GetServiceAccount(ctx context.Context, email string) (*iamv1.ServiceAccount, error)
+	GetServiceAccountIAMPolicy(ctx context.Context, email string) (*iamv1.Policy, error)
+}
+
+// IdentityChecker verifies that the Google Service Account of a ConfigConnectorContext object exists
+// and that the Kubernetes Service Account of the namespace's controller manager is allowed to impersonate
+// it through Workload Identity. The result is recorded as the `IdentityVerified` condition of the object.
+//
+// The preflight check fails if the Google Service Account doesn't exist or if the Workload Identity binding
+// is missing. If the check cannot be performed, e.g. because the operator is not allowed to read the
+// Google Service Account, the condition is set to Unknown and the reconciliation proceeds.
+type IdentityChecker struct {
+	client    client.Client
+	gsaClient ServiceAccountClient
+}
+
+func NewIdentityChecker(client client.Client, gsaClient ServiceAccountClient) *IdentityChecker {
+	return &IdentityChecker{
+		client:    client,
+		gsaClient: gsaClient,
+	}
+}
+
+func (i *IdentityChecker) Preflight(ctx context.Context, o declarative.DeclarativeObject) error {
+	ccc, ok := o.(*corev1beta1.ConfigConnectorContext)
+	if !ok {
+		return fmt.Errorf("expected the resource to be a ConfigConnectorContext, but it was not. Object: %v", o)
+	}
+	if !ccc.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	condition, err := i.verify(ctx, ccc)
+	if updateErr := i.setCondition(ctx, ccc, condition); updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+// verify returns the condition describing the result of the verification, and an error if the
+// verification failed.
+func (i *IdentityChecker) verify(ctx context.Context, ccc *corev1beta1.ConfigConnectorContext) (metav1.Condition, error) {
+	gsa := ccc.Spec.GoogleServiceAccount
+	if _, err := i.gsaClient.GetServiceAccount(ctx, gsa); err != nil {
+		if gcp.IsNotFoundError(err) {
+			msg := fmt.Sprintf("Google Service Account %v does not exist", gsa)
+			return newIdentityCondition(metav1.ConditionFalse, k8s.ServiceAccountNotFoundReason, msg), errors.New(msg)
+		}
+		ilog.Info("unable to verify the Google Service Account", "namespace", ccc.Namespace, "serviceAccount", gsa, "error", err.Error())
+		msg := fmt.Sprintf("unable to verify Google Service Account %v: %v", gsa, err)
+		return newIdentityCondition(metav1.ConditionUnknown, k8s.IdentityVerificationFailedReason, msg), nil
+	}
+	policy, err := i.gsaClient.GetServiceAccountIAMPolicy(ctx, gsa)
+	if err != nil {
+		ilog.Info("unable to read the IAM policy of the Google Service Account", "namespace", ccc.Namespace, "serviceAccount", gsa, "error", err.Error())
+		msg := fmt.Sprintf("unable to read the IAM policy of Google Service Account %v: %v", gsa, err)
+		return newIdentityCondition(metav1.ConditionUnknown, k8s.IdentityVerificationFailedReason, msg), nil
+	}
+	ksa := k8s.ServiceAccountNamePrefix + ccc.Namespace
+	if !hasWorkloadIdentityBinding(policy, k8s.CNRMSystemNamespace, ksa) {
+		msg := fmt.Sprintf("Google Service Account %v does not grant %v to the Kubernetes Service Account %v/%v; "+
+			"add an IAM policy binding for member 'serviceAccount:<PROJECT_ID>.svc.id.goog[%v/%v]'",
+			gsa, k8s.WorkloadIdentityUserRole, k8s.CNRMSystemNamespace, ksa, k8s.CNRMSystemNamespace, ksa)
+		return newIdentityCondition(metav1.ConditionFalse, k8s.WorkloadIdentityBindingNotFoundReason, msg), errors.New(msg)
+	}
+	msg := fmt.Sprintf("Google Service Account %v exists and has a Workload Identity binding for %v/%v", gsa, k8s.CNRMSystemNamespace, ksa)
+	return newIdentityCondition(metav1.ConditionTrue, k8s.IdentityVerifiedReason, msg), nil
+}
+
+func (i *IdentityChecker) setCondition(ctx context.Context, ccc *corev1beta1.ConfigConnectorContext, condition metav1.Condition) error {
+	condition.ObservedGeneration = ccc.GetGeneration()
+	existing := apimeta.FindStatusCondition(ccc.Status.Conditions, condition.Type)
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason &&
+		existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+		return nil
+	}
+	apimeta.SetStatusCondition(&ccc.Status.Conditions, condition)
+	if err := i.client.Status().Update(ctx, ccc); err != nil {
+		return fmt.Errorf("failed to update the %v condition of ConfigConnectorContext %v/%v on API server: %v", condition.Type, ccc.Namespace, ccc.Name, err)
+	}
+	return nil
+}
+
+func newIdentityCondition(status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    k8s.IdentityVerifiedCondition,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// hasWorkloadIdentityBinding returns true if the policy grants the Workload Identity User role to the given
+// Kubernetes Service Account, in any workload identity pool.
+func hasWorkloadIdentityBinding(policy *iamv1.Policy, namespace, name string) bool {
+	memberSuffix := fmt.Sprintf(".svc.id.goog[%v/%v]", namespace, name)
+	for _, b := range policy.Bindings {
+		if b.Role != k8s.WorkloadIdentityUserRole || b.Condition != nil {
+			continue
+		}
+		for _, m := range b.Members {
+			if strings.HasPrefix(m, "serviceAccount:") && strings.HasSuffix(m, memberSuffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// iamServiceAccountClient is a ServiceAccountClient that calls the IAM API with the operator's
+// application default credentials. The IAM service is created on first use, so that the operator
+// can start without credentials, and is only kept once it has been created successfully.
+type iamServiceAccountClient struct {
+	mu      sync.Mutex
+	service *iamv1.Service
+}
+
+func NewIAMServiceAccountClient() ServiceAccountClient {
+	return &iamServiceAccountClient{}
+}
+
+func (c *iamServiceAccountClient) getService() (*iamv1.Service, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.service != nil {
+		return c.service, nil
+	}
+	service, err := iamv1.NewService(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error creating the IAM client: %v", err)
+	}
+	c.service = service
+	return c.service, nil
+}
+
+func (c *iamServiceAccountClient) GetServiceAccount(ctx context.Context, email string) (*iamv1.ServiceAccount, error) {
+	s, err := c.getService()
+	if err != nil {
+		return nil, err
+	}
+	return s.Projects.ServiceAccounts.Get(serviceAccountResourceName(email)).Context(ctx).Do()
+}
+
+func (c *iamServiceAccountClient) GetServiceAccountIAMPolicy(ctx context.Context, email string) (*iamv1.Policy, error) {
+	s, err := c.getService()
+	if err != nil {
+		return nil, err
+	}
+	return s.Projects.ServiceAccounts.GetIamPolicy(serviceAccountResourceName(email)).Context(ctx).Do()
+}
+
+func serviceAccountResourceName(email string) string {
+	return fmt.Sprintf("projects/-/serviceAccounts/%v", email)
+}
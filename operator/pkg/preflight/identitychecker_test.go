@@ -0,0 +1,158 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/test/util/asserts"
+
+	"google.golang.org/api/googleapi"
+	iamv1 "google.golang.org/api/iam/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/test/mocks"
+)
+
+const testGSA = "cnrm-system-foo-ns@foo-project.iam.gserviceaccount.com"
+
+type fakeServiceAccountClient struct {
+	getErr    error
+	policy    *iamv1.Policy
+	policyErr error
+}
+
+func (f *fakeServiceAccountClient) GetServiceAccount(ctx context.Context, email string) (*iamv1.ServiceAccount, error) {
+	if f.getErr != nil {
+		return nil, f.getErr
+	}
+	return &iamv1.ServiceAccount{Email: email}, nil
+}
+
+func (f *fakeServiceAccountClient) GetServiceAccountIAMPolicy(ctx context.Context, email string) (*iamv1.Policy, error) {
+	if f.policyErr != nil {
+		return nil, f.policyErr
+	}
+	return f.policy, nil
+}
+
+func TestIdentityChecker(t *testing.T) {
+	t.Parallel()
+	wiBinding := &iamv1.Binding{
+		Role:    k8s.WorkloadIdentityUserRole,
+		Members: []string{"serviceAccount:foo-project.svc.id.goog[cnrm-system/cnrm-controller-manager-foo-ns]"},
+	}
+	tests := []struct {
+		name            string
+		gsaClient       *fakeServiceAccountClient
+		conditionStatus metav1.ConditionStatus
+		reason          string
+		err             error
+	}{
+		{
+			name: "service account exists with a workload identity binding",
+			gsaClient: &fakeServiceAccountClient{
+				policy: &iamv1.Policy{Bindings: []*iamv1.Binding{wiBinding}},
+			},
+			conditionStatus: metav1.ConditionTrue,
+			reason:          k8s.IdentityVerifiedReason,
+		},
+		{
+			name: "service account doesn't exist",
+			gsaClient: &fakeServiceAccountClient{
+				getErr: &googleapi.Error{Code: http.StatusNotFound},
+			},
+			conditionStatus: metav1.ConditionFalse,
+			reason:          k8s.ServiceAccountNotFoundReason,
+			err:             fmt.Errorf("Google Service Account %v does not exist", testGSA),
+		},
+		{
+			name: "workload identity binding for another namespace",
+			gsaClient: &fakeServiceAccountClient{
+				policy: &iamv1.Policy{Bindings: []*iamv1.Binding{
+					{
+						Role:    k8s.WorkloadIdentityUserRole,
+						Members: []string{"serviceAccount:foo-project.svc.id.goog[cnrm-system/cnrm-controller-manager-bar-ns]"},
+					},
+				}},
+			},
+			conditionStatus: metav1.ConditionFalse,
+			reason:          k8s.WorkloadIdentityBindingNotFoundReason,
+			err:             fmt.Errorf("does not grant %v", k8s.WorkloadIdentityUserRole),
+		},
+		{
+			name: "workload identity binding with a condition",
+			gsaClient: &fakeServiceAccountClient{
+				policy: &iamv1.Policy{Bindings: []*iamv1.Binding{
+					{
+						Role:      wiBinding.Role,
+						Members:   wiBinding.Members,
+						Condition: &iamv1.Expr{Expression: "request.time < timestamp('2020-01-01T00:00:00Z')"},
+					},
+				}},
+			},
+			conditionStatus: metav1.ConditionFalse,
+			reason:          k8s.WorkloadIdentityBindingNotFoundReason,
+			err:             fmt.Errorf("does not grant %v", k8s.WorkloadIdentityUserRole),
+		},
+		{
+			name: "not allowed to read the service account",
+			gsaClient: &fakeServiceAccountClient{
+				getErr: &googleapi.Error{Code: http.StatusForbidden},
+			},
+			conditionStatus: metav1.ConditionUnknown,
+			reason:          k8s.IdentityVerificationFailedReason,
+		},
+		{
+			name: "not allowed to read the IAM policy",
+			gsaClient: &fakeServiceAccountClient{
+				policyErr: &googleapi.Error{Code: http.StatusForbidden},
+			},
+			conditionStatus: metav1.ConditionUnknown,
+			reason:          k8s.IdentityVerificationFailedReason,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ccc := &corev1beta1.ConfigConnectorContext{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      k8s.ConfigConnectorContextAllowedName,
+					Namespace: "foo-ns",
+				},
+				Spec: corev1beta1.ConfigConnectorContextSpec{
+					GoogleServiceAccount: testGSA,
+				},
+			}
+			mgr := mocks.Manager{}
+			c := NewIdentityChecker(mgr.GetClient(), tc.gsaClient)
+			err := c.Preflight(context.Background(), ccc)
+			asserts.AssertErrorIsExpected(t, err, tc.err)
+			condition := apimeta.FindStatusCondition(ccc.Status.Conditions, k8s.IdentityVerifiedCondition)
+			if condition == nil {
+				t.Fatalf("expected the %v condition to be set", k8s.IdentityVerifiedCondition)
+			}
+			if condition.Status != tc.conditionStatus || condition.Reason != tc.reason {
+				t.Fatalf("got condition status %v and reason %v, want %v and %v", condition.Status, condition.Reason, tc.conditionStatus, tc.reason)
+			}
+		})
+	}
+}
@@ -18,8 +18,10 @@ import (
 	"context"
 	"fmt"
 
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/manifest"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/migration"
 
 	"github.com/blang/semver"
 	corev1 "k8s.io/api/core/v1"
@@ -36,7 +38,8 @@ var (
 
 // NewUpgradeChecker provides an implementation of declarative.Preflight that
 // does version comparison between the version of the existing KCC and and the version to deploy.
-// If it's a major version change, it returns error and surface the error status on the DeclarativeObject.
+// If it's a major version change, it returns error and surface the error status on the DeclarativeObject,
+// unless it's an upgrade for which the version to deploy declares migration steps.
 func NewUpgradeChecker(client client.Client, repo manifest.Repository) *UpgradeChecker {
 	return &UpgradeChecker{client: client, repo: repo}
 }
@@ -66,18 +69,10 @@ func (u *UpgradeChecker) Preflight(ctx context.Context, o declarative.Declarativ
 		return nil
 	}
 
-	channel, err := u.repo.LoadChannel(ctx, k8s.StableChannel)
+	versionToDeployRaw, err := u.resolveVersionToDeploy(ctx, o)
 	if err != nil {
-		return fmt.Errorf("preflight check failed loading the channel %v: %v", k8s.StableChannel, err)
-	}
-	version, err := channel.Latest(ctx, k8s.ConfigConnectorComponentName)
-	if err != nil {
-		return fmt.Errorf("preflight check failed resolving the version to deploy: %v", err)
-	}
-	if version == nil {
-		return fmt.Errorf("could not find the latest version in channel %v", k8s.StableChannel)
+		return err
 	}
-	versionToDeployRaw := version.Version
 	currentVersion, err := semver.ParseTolerant(currentVersionRaw)
 	if err != nil {
 		return fmt.Errorf("current version %v is not a valid semantic version: %v", currentVersionRaw, err)
@@ -89,11 +84,49 @@ func (u *UpgradeChecker) Preflight(ctx context.Context, o declarative.Declarativ
 	}
 	ulog.Info("Checking version", "version to deploy", versionToDeploy)
 	if compareMajorOnly(currentVersion, versionToDeploy) != 0 {
-		return fmt.Errorf("incompatible version: stop reconciling the existing ConfigConnector of version %v to version %v since it's a major version change. Please kubectl delete the existing ConfigConnector object and recreate it", currentVersion, versionToDeploy)
+		if versionToDeploy.GT(currentVersion) {
+			plan, err := migration.FindPlan(ctx, u.repo, k8s.ConfigConnectorComponentName, versionToDeployRaw, currentVersion)
+			if err != nil {
+				return fmt.Errorf("preflight check failed loading the migrations of version %v: %v", versionToDeploy, err)
+			}
+			if plan != nil {
+				ulog.Info("major version upgrade is supported by migration steps", "current version", currentVersion, "version to deploy", versionToDeploy)
+				return nil
+			}
+		}
+		return fmt.Errorf("incompatible version: stop reconciling the existing ConfigConnector of version %v to version %v since it's a major version change without migration steps. Please kubectl delete the existing ConfigConnector object and recreate it", currentVersion, versionToDeploy)
 	}
 	return nil
 }
 
+// resolveVersionToDeploy returns the version of Config Connector that the reconciliation of the
+// given object deploys: the version pinned in the ConfigConnector object if any, or its canary
+// version for the ConfigConnectorContext objects selected by its canary, otherwise the latest
+// version in the stable channel.
+func (u *UpgradeChecker) resolveVersionToDeploy(ctx context.Context, o declarative.DeclarativeObject) (string, error) {
+	var version string
+	var err error
+	switch obj := o.(type) {
+	case *corev1beta1.ConfigConnector:
+		version, err = manifest.ResolveConfigConnectorVersion(ctx, u.repo, obj)
+	case *corev1beta1.ConfigConnectorContext:
+		cc := &corev1beta1.ConfigConnector{}
+		if err := u.client.Get(ctx, types.NamespacedName{Name: k8s.ConfigConnectorAllowedName}, cc); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return "", fmt.Errorf("preflight check failed getting ConfigConnector %v: %v", k8s.ConfigConnectorAllowedName, err)
+			}
+			cc = nil
+		}
+		version, err = manifest.ResolveNamespacedVersion(ctx, u.repo, cc, obj)
+	default:
+		return "", fmt.Errorf("preflight check failed: unexpected object of kind %v", o.GetObjectKind().GroupVersionKind().Kind)
+	}
+	if err != nil {
+		return "", fmt.Errorf("preflight check failed resolving the version to deploy: %v", err)
+	}
+	return version, nil
+}
+
 func compareMajorOnly(v, w semver.Version) int {
 	if v.Major != w.Major {
 		if v.Major > w.Major {
@@ -46,7 +46,8 @@ func init() {
 }
 
 type FakeRepo struct {
-	channel *loaders.Channel
+	channel    *loaders.Channel
+	migrations string
 }
 
 var _ manifest.Repository = &FakeRepo{}
@@ -63,6 +64,10 @@ func (r *FakeRepo) LoadNamespacedComponents(ctx context.Context, componentName s
 	panic("implement me")
 }
 
+func (r *FakeRepo) LoadMigrations(ctx context.Context, componentName string, version string) (string, error) {
+	return r.migrations, nil
+}
+
 func TestUpgradeChecker_Preflight(t *testing.T) {
 	t.Parallel()
 	curTime := metav1.Now()
@@ -75,12 +80,13 @@ func TestUpgradeChecker_Preflight(t *testing.T) {
 		},
 	}
 	tests := []struct {
-		name    string
-		cc      *corev1beta1.ConfigConnector
-		ns      *corev1.Namespace
-		channel *loaders.Channel
-		err     error
-		delete  bool
+		name       string
+		cc         *corev1beta1.ConfigConnector
+		ns         *corev1.Namespace
+		channel    *loaders.Channel
+		migrations string
+		err        error
+		delete     bool
 	}{
 		{
 			name:    "no existing instance, can upgrade/deploy the new version",
@@ -152,6 +158,70 @@ func TestUpgradeChecker_Preflight(t *testing.T) {
 			},
 			err: fmt.Errorf("incompatible version"),
 		},
+		{
+			name: "major change with migration steps, can upgrade it",
+			cc:   testConfigConnector,
+			ns: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: k8s.CNRMSystemNamespace,
+					Annotations: map[string]string{
+						k8s.VersionAnnotation: "1.2.3",
+					},
+				},
+			},
+			channel: &loaders.Channel{
+				Manifests: []loaders.Version{
+					{
+						Package: "configconnector",
+						Version: "2.0.0",
+					},
+				},
+			},
+			migrations: `
+migrations:
+- fromVersions: ">=1.0.0 <2.0.0"
+  steps:
+  - name: remove-recorder
+    componentRemoval:
+      apiVersion: apps/v1
+      kind: Deployment
+      namespace: cnrm-system
+      name: cnrm-resource-stats-recorder
+`,
+			err: nil,
+		},
+		{
+			name: "major change with migration steps from other versions, no upgrade",
+			cc:   testConfigConnector,
+			ns: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: k8s.CNRMSystemNamespace,
+					Annotations: map[string]string{
+						k8s.VersionAnnotation: "1.2.3",
+					},
+				},
+			},
+			channel: &loaders.Channel{
+				Manifests: []loaders.Version{
+					{
+						Package: "configconnector",
+						Version: "3.0.0",
+					},
+				},
+			},
+			migrations: `
+migrations:
+- fromVersions: ">=2.0.0 <3.0.0"
+  steps:
+  - name: remove-recorder
+    componentRemoval:
+      apiVersion: apps/v1
+      kind: Deployment
+      namespace: cnrm-system
+      name: cnrm-resource-stats-recorder
+`,
+			err: fmt.Errorf("incompatible version"),
+		},
 		{
 			name: "major change, no downgrade",
 			cc:   testConfigConnector,
@@ -220,7 +290,8 @@ func TestUpgradeChecker_Preflight(t *testing.T) {
 				}
 			}
 			repo := FakeRepo{
-				channel: tc.channel,
+				channel:    tc.channel,
+				migrations: tc.migrations,
 			}
 			u := NewUpgradeChecker(client, &repo)
 			err := u.Preflight(ctx, tc.cc)
@@ -228,3 +299,104 @@ func TestUpgradeChecker_Preflight(t *testing.T) {
 		})
 	}
 }
+
+func TestUpgradeChecker_PreflightConfigConnectorContext(t *testing.T) {
+	t.Parallel()
+	channel := &loaders.Channel{
+		Manifests: []loaders.Version{
+			{
+				Package: "configconnector",
+				Version: "1.2.3",
+			},
+			{
+				Package: "configconnector",
+				Version: "1.3.0",
+			},
+			{
+				Package: "configconnector",
+				Version: "2.0.0",
+			},
+		},
+	}
+	cnrmSystemNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: k8s.CNRMSystemNamespace,
+			Annotations: map[string]string{
+				k8s.VersionAnnotation: "1.2.3",
+			},
+		},
+	}
+	newCC := func(version string, canary *corev1beta1.CanarySpec) *corev1beta1.ConfigConnector {
+		return &corev1beta1.ConfigConnector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: k8s.ConfigConnectorAllowedName,
+			},
+			Spec: corev1beta1.ConfigConnectorSpec{
+				Mode:    k8s.NamespacedMode,
+				Version: version,
+				Canary:  canary,
+			},
+		}
+	}
+	ccc := &corev1beta1.ConfigConnectorContext{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      k8s.ConfigConnectorContextAllowedName,
+			Namespace: "foo-ns",
+			Labels:    map[string]string{"canary": "true"},
+		},
+		Spec: corev1beta1.ConfigConnectorContextSpec{
+			GoogleServiceAccount: "foo@bar.iam.gserviceaccount.com",
+		},
+	}
+	tests := []struct {
+		name string
+		cc   *corev1beta1.ConfigConnector
+		err  error
+	}{
+		{
+			name: "no ConfigConnector object, major change to the latest version, no upgrade",
+			err:  fmt.Errorf("incompatible version"),
+		},
+		{
+			name: "version pinned in the ConfigConnector object",
+			cc:   newCC("1.2.3", nil),
+		},
+		{
+			name: "canary version of the ConfigConnector object",
+			cc: newCC("1.2.3", &corev1beta1.CanarySpec{
+				Version: "1.3.0",
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"canary": "true"},
+				},
+			}),
+		},
+		{
+			name: "pinned version not in the channel",
+			cc:   newCC("1.4.0", nil),
+			err:  fmt.Errorf("is not in the stable channel"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mgr := mocks.Manager{}
+			ctx := context.Background()
+			client := mgr.GetClient()
+			if tc.cc != nil {
+				if err := client.Create(ctx, tc.cc); err != nil {
+					t.Fatalf("error creating %v %v: %v", tc.cc.Kind, tc.cc.Name, err)
+				}
+			}
+			if err := client.Create(ctx, cnrmSystemNamespace.DeepCopy()); err != nil {
+				t.Fatalf("error creating namespace %v: %v", cnrmSystemNamespace.Name, err)
+			}
+			repo := FakeRepo{
+				channel: channel,
+			}
+			u := NewUpgradeChecker(client, &repo)
+			err := u.Preflight(ctx, ccc.DeepCopy())
+			asserts.AssertErrorIsExpected(t, err, tc.err)
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/test/util/paths"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/scripts/utils"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative/pkg/manifest"
 )
 
@@ -56,6 +57,9 @@ func copyDependencies(objects []*manifest.Object) error {
 	if err := copyViewerRole(objects); err != nil {
 		return err
 	}
+	if err := writeMigratorRole(objects); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -73,6 +77,45 @@ func copyViewerRole(objects []*manifest.Object) error {
 	return nil
 }
 
+// writeMigratorRole writes the role that allows the operator to execute the migration steps of an upgrade, which
+// list and update the Config Connector resources of all the API groups of the viewer role.
+func writeMigratorRole(objects []*manifest.Object) error {
+	viewerRoleName := "cnrm-viewer"
+	viewerRole, ok := findObject(objects, "ClusterRole", viewerRoleName)
+	if !ok {
+		return fmt.Errorf("unable to find ClusterRole '%v' in manifests", viewerRoleName)
+	}
+	u := viewerRole.UnstructuredObject().DeepCopy()
+	u.SetName("cnrm-migrator")
+	// the migrator role must not be aggregated into the 'view' role as the viewer role is
+	labels := u.GetLabels()
+	delete(labels, "rbac.authorization.k8s.io/aggregate-to-view")
+	u.SetLabels(labels)
+	rules, _, err := unstructured.NestedSlice(u.Object, "rules")
+	if err != nil {
+		return fmt.Errorf("error getting the rules of ClusterRole '%v': %v", viewerRoleName, err)
+	}
+	for _, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected rule format in ClusterRole '%v'", viewerRoleName)
+		}
+		rule["verbs"] = []interface{}{"list", "update"}
+	}
+	if err := unstructured.SetNestedSlice(u.Object, rules, "rules"); err != nil {
+		return fmt.Errorf("error setting the rules of ClusterRole '%v': %v", u.GetName(), err)
+	}
+	bytes, err := utils.UnstructToYaml(u)
+	if err != nil {
+		return fmt.Errorf("error serializing ClusterRole '%v' to yaml", u.GetName())
+	}
+	outputPath := path.Join(paths.GetOperatorSrcRootOrLogFatal(), rbacDir, "cnrm_migrator_role.yaml")
+	if err := ioutil.WriteFile(outputPath, bytes, fileMode); err != nil {
+		return fmt.Errorf("error writing ClusterRole '%v' to file", u.GetName())
+	}
+	return nil
+}
+
 func findObject(objects []*manifest.Object, kind, name string) (*manifest.Object, bool) {
 	for _, o := range objects {
 		if o.Kind == kind && o.GetName() == name {
@@ -0,0 +1,121 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/k8s/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IAMDenyPolicySpec defines the desired state of IAMDenyPolicy.
+type IAMDenyPolicySpec struct {
+	// Immutable. Required. The GCP resource to attach the deny policy to.
+	// Only Projects, Folders and Organizations are supported.
+	ResourceReference ResourceReference `json:"resourceRef"`
+	// Immutable. Optional. The ID of the deny policy. If not given, the
+	// metadata.name of the resource is used.
+	ResourceID *string `json:"resourceID,omitempty"`
+	// Optional. The display name of the deny policy.
+	DisplayName string `json:"displayName,omitempty"`
+	// Required. The rules of the deny policy.
+	Rules []IAMDenyPolicyRule `json:"rules"`
+}
+
+// IAMDenyPolicyRule is a rule of an IAM deny policy.
+type IAMDenyPolicyRule struct {
+	// Optional. The description of the rule.
+	Description string `json:"description,omitempty"`
+	// Required. The deny rule.
+	DenyRule IAMDenyRule `json:"denyRule"`
+}
+
+// IAMDenyRule denies a set of permissions to a set of principals.
+type IAMDenyRule struct {
+	// The principals that are denied the permissions, e.g.
+	// 'principal://goog/subject/foo@example.com' or
+	// 'principalSet://goog/group/bar@example.com'.
+	DeniedPrincipals []string `json:"deniedPrincipals,omitempty"`
+	// The principals that are excluded from the deny rule, even if they are
+	// listed in the deniedPrincipals.
+	ExceptionPrincipals []string `json:"exceptionPrincipals,omitempty"`
+	// The permissions that are denied, in the format
+	// '{service-fqdn}/{resource}.{verb}', e.g. 'iam.googleapis.com/roles.list'.
+	DeniedPermissions []string `json:"deniedPermissions,omitempty"`
+	// The permissions that are excluded from the denied permissions, in the
+	// same format as deniedPermissions.
+	ExceptionPermissions []string `json:"exceptionPermissions,omitempty"`
+	// Optional. The condition under which the deny rule applies.
+	DenialCondition *IAMDenialCondition `json:"denialCondition,omitempty"`
+}
+
+// IAMDenialCondition is a CEL expression restricting when a deny rule applies.
+type IAMDenialCondition struct {
+	// Required. The textual representation of the expression in Common
+	// Expression Language syntax.
+	Expression string `json:"expression"`
+	// Optional. A short string describing the purpose of the expression.
+	Title string `json:"title,omitempty"`
+	// Optional. A longer description of the expression.
+	Description string `json:"description,omitempty"`
+	// Optional. The location of the expression for error reporting, e.g. a
+	// file name and a position in the file.
+	Location string `json:"location,omitempty"`
+}
+
+// IAMDenyPolicyStatus defines the observed state of IAMDenyPolicy.
+type IAMDenyPolicyStatus struct {
+	// Conditions represent the latest available observations of the
+	// IAMDenyPolicy's current state.
+	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller.
+	// If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The etag of the deny policy.
+	// +optional
+	Etag string `json:"etag,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IAMDenyPolicy is the schema for the IAM deny policy API.
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.conditions[?(@.type=='Ready')].status",description="When 'True' the most recent reconcile of the resource succeeded"
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=".status.conditions[?(@.type=='Ready')].reason",description="The reason for the value in 'Ready'"
+// +kubebuilder:printcolumn:name="Status Age",type="date",JSONPath=".status.conditions[?(@.type=='Ready')].lastTransitionTime"
+// +kubebuilder:subresource:status
+// +k8s:openapi-gen=true
+type IAMDenyPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IAMDenyPolicySpec   `json:"spec,omitempty"`
+	Status IAMDenyPolicyStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IAMDenyPolicyList contains a list of IAMDenyPolicy.
+type IAMDenyPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IAMDenyPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IAMDenyPolicy{}, &IAMDenyPolicyList{})
+}
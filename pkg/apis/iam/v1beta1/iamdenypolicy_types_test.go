@@ -0,0 +1,75 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestStorageIAMDenyPolicy(t *testing.T) {
+	key := types.NamespacedName{Name: "foo", Namespace: "default"}
+	testCases := []*IAMDenyPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+			Spec: IAMDenyPolicySpec{
+				ResourceReference: ResourceReference{Kind: "Project", Name: "bar"},
+				DisplayName:       "Deny role deletion",
+				Rules: []IAMDenyPolicyRule{
+					{
+						Description: "Deny role deletion to everyone but the admins",
+						DenyRule: IAMDenyRule{
+							DeniedPrincipals:     []string{"principalSet://goog/public:all"},
+							ExceptionPrincipals:  []string{"principalSet://goog/group/admins@example.com"},
+							DeniedPermissions:    []string{"iam.googleapis.com/roles.delete"},
+							ExceptionPermissions: []string{"iam.googleapis.com/roles.get"},
+							DenialCondition: &IAMDenialCondition{
+								Title:      "Production resources",
+								Expression: "resource.matchTag('12345678/env', 'prod')",
+							},
+						},
+					},
+				},
+			},
+			Status: IAMDenyPolicyStatus{},
+		},
+	}
+	g := gomega.NewGomegaWithT(t)
+
+	for _, created := range testCases {
+		// Test Create
+		fetched := &IAMDenyPolicy{}
+		g.Expect(c.Create(context.TODO(), created)).NotTo(gomega.HaveOccurred())
+
+		g.Expect(c.Get(context.TODO(), key, fetched)).NotTo(gomega.HaveOccurred())
+		g.Expect(fetched).To(gomega.Equal(created))
+
+		// Test Updating the Labels
+		updated := fetched.DeepCopy()
+		updated.Labels = map[string]string{"hello": "world"}
+		g.Expect(c.Update(context.TODO(), updated)).NotTo(gomega.HaveOccurred())
+
+		g.Expect(c.Get(context.TODO(), key, fetched)).NotTo(gomega.HaveOccurred())
+		g.Expect(fetched).To(gomega.Equal(updated))
+
+		// Test Delete
+		g.Expect(c.Delete(context.TODO(), fetched)).NotTo(gomega.HaveOccurred())
+		g.Expect(c.Get(context.TODO(), key, fetched)).To(gomega.HaveOccurred())
+	}
+}
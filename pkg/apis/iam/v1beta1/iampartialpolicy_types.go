@@ -40,6 +40,24 @@ type IAMPartialPolicyBinding struct {
 	Role string `json:"role"`
 	// Optional. The condition under which the binding applies.
 	Condition *IAMCondition `json:"condition,omitempty"`
+	// Optional. The time after which the binding no longer applies. It is
+	// enforced through an IAM condition on the request time, and the binding
+	// is removed from the IAM policy once it has expired.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// Specifies the Config Connector objects that grant an IAM member a role.
+type IAMBindingSource struct {
+	// The role bound to the member.
+	Role string `json:"role"`
+	// The condition under which the binding applies.
+	Condition *IAMCondition `json:"condition,omitempty"`
+	// The IAM identity bound to the role.
+	Member Member `json:"member"`
+	// The IAMPolicy, IAMPartialPolicy and IAMPolicyMember objects granting the
+	// binding, in the form '<kind>/<namespace>/<name>'. Empty if the binding is
+	// not granted by any of them.
+	Sources []string `json:"sources,omitempty"`
 }
 
 // IAMPartialPolicySpec defines the desired state of IAMPartialPolicy
@@ -64,6 +82,10 @@ type IAMPartialPolicyStatus struct {
 	LastAppliedBindings []IAMPolicyBinding `json:"lastAppliedBindings,omitempty"`
 	// AllBindings surfaces all IAM bindings for the referenced resource.
 	AllBindings []IAMPolicyBinding `json:"allBindings,omitempty"`
+	// BindingSources lists, for each member of AllBindings, the IAMPolicy, IAMPartialPolicy
+	// and IAMPolicyMember objects in the same namespace that grant it. Members granted by none
+	// of them were added outside of Config Connector or by objects in other namespaces.
+	BindingSources []IAMBindingSource `json:"bindingSources,omitempty"`
 }
 
 // +genclient
@@ -40,6 +40,11 @@ type IAMPolicyMemberSpec struct {
 	Role string `json:"role"`
 	// Immutable. Optional. The condition under which the binding applies.
 	Condition *IAMCondition `json:"condition,omitempty"`
+	// Immutable. Optional. The time after which the binding no longer
+	// applies. It is enforced through an IAM condition on the request time,
+	// and the binding is removed from the IAM policy once it has expired, at
+	// which point the IAMPolicyMember is marked as 'Expired'.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
 }
 
 // IAMPolicyMemberStatus defines the observed state of IAMPolicyMember
@@ -50,6 +50,53 @@ type MemberSource struct {
 	// The SQLInstance whose service account (i.e. its
 	// 'status.serviceAccountEmailAddress') is to be bound to the role.
 	SQLInstanceRef *MemberReference `json:"sqlInstanceRef,omitempty"`
+
+	// The Kubernetes ServiceAccount to be bound to the role through Workload
+	// Identity (i.e. 'serviceAccount:PROJECT_ID.svc.id.goog[NAMESPACE/NAME]'),
+	// where PROJECT_ID is the project of the ServiceAccount's namespace, as set
+	// by its 'cnrm.cloud.google.com/project-id' annotation.
+	KubernetesServiceAccountRef *MemberReference `json:"kubernetesServiceAccountRef,omitempty"`
+
+	// The Google-managed service agent of a service in a project to be bound
+	// to the role.
+	ServiceAgent *ServiceAgentSource `json:"serviceAgent,omitempty"`
+
+	// The set of identities of an IAMWorkloadIdentityPool to be bound to the
+	// role.
+	WorkloadIdentityPoolPrincipalSet *WorkloadIdentityPoolPrincipalSet `json:"workloadIdentityPoolPrincipalSet,omitempty"`
+}
+
+// ServiceAgentSource represents the Google-managed service agent of a service
+// in a project, e.g. 'service-PROJECT_NUMBER@gcp-sa-pubsub.iam.gserviceaccount.com'
+// for the 'pubsub.googleapis.com' service.
+type ServiceAgentSource struct {
+	// The project of the service agent.
+	ProjectRef ProjectReference `json:"projectRef"`
+	// The name of the service, e.g. 'pubsub.googleapis.com'. The service
+	// agents of services whose service agent is not known to Config Connector
+	// must be bound with 'member' instead.
+	Service string `json:"service"`
+}
+
+// WorkloadIdentityPoolPrincipalSet represents a set of identities of a
+// workload identity pool, i.e. 'principalSet://iam.googleapis.com/projects/PROJECT_NUMBER/locations/global/workloadIdentityPools/POOL_ID/ATTRIBUTE'.
+type WorkloadIdentityPoolPrincipalSet struct {
+	// The IAMWorkloadIdentityPool whose identities are to be bound to the role.
+	PoolRef MemberReference `json:"poolRef"`
+	// The attribute selecting the identities of the pool, e.g.
+	// 'group/GROUP_ID' or 'attribute.ATTRIBUTE_NAME/ATTRIBUTE_VALUE'. All the
+	// identities of the pool are selected if empty.
+	Attribute string `json:"attribute,omitempty"`
+}
+
+// ProjectReference represents a project, either through its Project resource
+// or through its project ID or number.
+type ProjectReference struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// The project ID or number, optionally prefixed with 'projects/'. Exactly
+	// one of 'name' or 'external' must be used.
+	External string `json:"external,omitempty"`
 }
 
 // MemberReference represents a resource with an IAM identity
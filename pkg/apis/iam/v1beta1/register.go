@@ -56,6 +56,11 @@ var (
 		Version: SchemeGroupVersion.Version,
 		Kind:    reflect.TypeOf(IAMAuditConfig{}).Name(),
 	}
+	IAMDenyPolicyGVK = schema.GroupVersionKind{
+		Group:   SchemeGroupVersion.Group,
+		Version: SchemeGroupVersion.Version,
+		Kind:    reflect.TypeOf(IAMDenyPolicy{}).Name(),
+	}
 	IAMAPIVersion = SchemeGroupVersion.String()
 )
 
@@ -63,7 +68,7 @@ var (
 // handwritten IAM resource.
 func IsHandwrittenIAM(gvk schema.GroupVersionKind) bool {
 	switch gvk {
-	case IAMPolicyGVK, IAMPolicyMemberGVK, IAMAuditConfigGVK, IAMPartialPolicyGVK:
+	case IAMPolicyGVK, IAMPolicyMemberGVK, IAMAuditConfigGVK, IAMPartialPolicyGVK, IAMDenyPolicyGVK:
 		return true
 	default:
 		return false
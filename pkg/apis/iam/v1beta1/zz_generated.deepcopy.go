@@ -150,6 +150,32 @@ func (in *IAMAuditConfigStatus) DeepCopy() *IAMAuditConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMBindingSource) DeepCopyInto(out *IAMBindingSource) {
+	*out = *in
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(IAMCondition)
+		**out = **in
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMBindingSource.
+func (in *IAMBindingSource) DeepCopy() *IAMBindingSource {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMBindingSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IAMCondition) DeepCopyInto(out *IAMCondition) {
 	*out = *in
@@ -166,6 +192,191 @@ func (in *IAMCondition) DeepCopy() *IAMCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMDenialCondition) DeepCopyInto(out *IAMDenialCondition) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMDenialCondition.
+func (in *IAMDenialCondition) DeepCopy() *IAMDenialCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMDenialCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMDenyPolicy) DeepCopyInto(out *IAMDenyPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMDenyPolicy.
+func (in *IAMDenyPolicy) DeepCopy() *IAMDenyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMDenyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IAMDenyPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMDenyPolicyList) DeepCopyInto(out *IAMDenyPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IAMDenyPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMDenyPolicyList.
+func (in *IAMDenyPolicyList) DeepCopy() *IAMDenyPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMDenyPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IAMDenyPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMDenyPolicyRule) DeepCopyInto(out *IAMDenyPolicyRule) {
+	*out = *in
+	in.DenyRule.DeepCopyInto(&out.DenyRule)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMDenyPolicyRule.
+func (in *IAMDenyPolicyRule) DeepCopy() *IAMDenyPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMDenyPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMDenyPolicySpec) DeepCopyInto(out *IAMDenyPolicySpec) {
+	*out = *in
+	out.ResourceReference = in.ResourceReference
+	if in.ResourceID != nil {
+		in, out := &in.ResourceID, &out.ResourceID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]IAMDenyPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMDenyPolicySpec.
+func (in *IAMDenyPolicySpec) DeepCopy() *IAMDenyPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMDenyPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMDenyPolicyStatus) DeepCopyInto(out *IAMDenyPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1alpha1.Condition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMDenyPolicyStatus.
+func (in *IAMDenyPolicyStatus) DeepCopy() *IAMDenyPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMDenyPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMDenyRule) DeepCopyInto(out *IAMDenyRule) {
+	*out = *in
+	if in.DeniedPrincipals != nil {
+		in, out := &in.DeniedPrincipals, &out.DeniedPrincipals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExceptionPrincipals != nil {
+		in, out := &in.ExceptionPrincipals, &out.ExceptionPrincipals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedPermissions != nil {
+		in, out := &in.DeniedPermissions, &out.DeniedPermissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExceptionPermissions != nil {
+		in, out := &in.ExceptionPermissions, &out.ExceptionPermissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DenialCondition != nil {
+		in, out := &in.DenialCondition, &out.DenialCondition
+		*out = new(IAMDenialCondition)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMDenyRule.
+func (in *IAMDenyRule) DeepCopy() *IAMDenyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMDenyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IAMPartialPolicy) DeepCopyInto(out *IAMPartialPolicy) {
 	*out = *in
@@ -209,6 +420,10 @@ func (in *IAMPartialPolicyBinding) DeepCopyInto(out *IAMPartialPolicyBinding) {
 		*out = new(IAMCondition)
 		**out = **in
 	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -322,6 +537,13 @@ func (in *IAMPartialPolicyStatus) DeepCopyInto(out *IAMPartialPolicyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BindingSources != nil {
+		in, out := &in.BindingSources, &out.BindingSources
+		*out = make([]IAMBindingSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -520,6 +742,10 @@ func (in *IAMPolicyMemberSpec) DeepCopyInto(out *IAMPolicyMemberSpec) {
 		*out = new(IAMCondition)
 		**out = **in
 	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -640,6 +866,21 @@ func (in *MemberSource) DeepCopyInto(out *MemberSource) {
 		*out = new(MemberReference)
 		**out = **in
 	}
+	if in.KubernetesServiceAccountRef != nil {
+		in, out := &in.KubernetesServiceAccountRef, &out.KubernetesServiceAccountRef
+		*out = new(MemberReference)
+		**out = **in
+	}
+	if in.ServiceAgent != nil {
+		in, out := &in.ServiceAgent, &out.ServiceAgent
+		*out = new(ServiceAgentSource)
+		**out = **in
+	}
+	if in.WorkloadIdentityPoolPrincipalSet != nil {
+		in, out := &in.WorkloadIdentityPoolPrincipalSet, &out.WorkloadIdentityPoolPrincipalSet
+		*out = new(WorkloadIdentityPoolPrincipalSet)
+		**out = **in
+	}
 	return
 }
 
@@ -653,6 +894,22 @@ func (in *MemberSource) DeepCopy() *MemberSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectReference) DeepCopyInto(out *ProjectReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectReference.
+func (in *ProjectReference) DeepCopy() *ProjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
 	*out = *in
@@ -668,3 +925,37 @@ func (in *ResourceReference) DeepCopy() *ResourceReference {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAgentSource) DeepCopyInto(out *ServiceAgentSource) {
+	*out = *in
+	out.ProjectRef = in.ProjectRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAgentSource.
+func (in *ServiceAgentSource) DeepCopy() *ServiceAgentSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAgentSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolPrincipalSet) DeepCopyInto(out *WorkloadIdentityPoolPrincipalSet) {
+	*out = *in
+	out.PoolRef = in.PoolRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolPrincipalSet.
+func (in *WorkloadIdentityPoolPrincipalSet) DeepCopy() *WorkloadIdentityPoolPrincipalSet {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolPrincipalSet)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourcePolicySpec defines the desired state of ResourcePolicy.
+type ResourcePolicySpec struct {
+	// Required. The resources that the rules apply to.
+	Match ResourcePolicyMatch `json:"match"`
+	// Required. The rules that the matched resources must satisfy to be
+	// admitted.
+	Rules []ResourcePolicyRule `json:"rules"`
+}
+
+// ResourcePolicyMatch selects the Config Connector resources that the rules
+// of a ResourcePolicy apply to.
+type ResourcePolicyMatch struct {
+	// Required. The kinds of the resources that the rules apply to.
+	Kinds []ResourcePolicyKind `json:"kinds"`
+	// Optional. The namespaces of the resources that the rules apply to. If
+	// not set, the rules apply to resources in all namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Optional. The selector on the labels of the namespaces of the resources
+	// that the rules apply to. If not set, the rules apply to resources in
+	// all namespaces.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// ResourcePolicyKind identifies a kind of Config Connector resource.
+type ResourcePolicyKind struct {
+	// Required. The API group of the resources, e.g.
+	// 'storage.cnrm.cloud.google.com'.
+	Group string `json:"group"`
+	// Optional. The kind of the resources, e.g. 'StorageBucket'. If not set,
+	// all the kinds of the API group are matched.
+	Kind string `json:"kind,omitempty"`
+}
+
+// ResourcePolicyRule is a rule that the resources matched by a
+// ResourcePolicy must satisfy.
+type ResourcePolicyRule struct {
+	// Required. The name of the rule, which is reported when a resource
+	// violates it.
+	Name string `json:"name"`
+	// Required. The CEL expression that must evaluate to true for a resource
+	// to be admitted. The expression has access to the following variables:
+	// 'object', the resource being admitted; 'oldObject', the existing
+	// resource on updates and null on creations; 'namespaceObject', with the
+	// 'name', 'labels' and 'annotations' of the namespace of the resource;
+	// and 'resource', with the 'project' and 'location' of the underlying
+	// GCP resource as determined from the service mappings, or empty strings
+	// if they can't be determined.
+	Expression string `json:"expression"`
+	// Optional. The message returned when a resource violates the rule. If
+	// not set, a message quoting the expression of the rule is returned.
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResourcePolicy is the schema for policies evaluated by Config Connector on
+// the admission of its resources.
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+type ResourcePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ResourcePolicySpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResourcePolicyList contains a list of ResourcePolicy.
+type ResourcePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourcePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ResourcePolicy{}, &ResourcePolicyList{})
+}
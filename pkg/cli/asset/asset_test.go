@@ -39,3 +39,43 @@ func TestGetServiceMappingAndResourceConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestGetLocation(t *testing.T) {
+	testCases := []struct {
+		Name             string
+		Asset            *asset.Asset
+		ExpectedLocation string
+	}{
+		{
+			Name: "location from resource",
+			Asset: &asset.Asset{
+				Name:     "//storage.googleapis.com/my-bucket",
+				Resource: &asset.Resource{Location: "us"},
+			},
+			ExpectedLocation: "us",
+		},
+		{
+			Name:             "zone from name",
+			Asset:            &asset.Asset{Name: "//compute.googleapis.com/projects/my-project/zones/us-central1-a/instances/my-instance"},
+			ExpectedLocation: "us-central1-a",
+		},
+		{
+			Name:             "region from name",
+			Asset:            &asset.Asset{Name: "//compute.googleapis.com/projects/my-project/regions/us-east1/subnetworks/my-subnetwork"},
+			ExpectedLocation: "us-east1",
+		},
+		{
+			Name:             "no location",
+			Asset:            &asset.Asset{Name: "//pubsub.googleapis.com/projects/my-project/topics/my-topic"},
+			ExpectedLocation: "global",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := asset.GetLocation(tc.Asset); got != tc.ExpectedLocation {
+				t.Errorf("got location '%v', want '%v'", got, tc.ExpectedLocation)
+			}
+		})
+	}
+}
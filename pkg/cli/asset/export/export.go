@@ -35,7 +35,10 @@ import (
 // projects/projectId
 // folders/folderNumber
 // organizations/orgNumber
-func ForParentToStorageObject(ctx context.Context, httpClient *http.Client, parent, bucketName, objectName string) error {
+//
+// if 'withResourceContent' is true, the export includes the resource content of each asset, e.g. its labels and
+// location. It is larger and slower to produce, so it should only be requested when needed.
+func ForParentToStorageObject(ctx context.Context, httpClient *http.Client, parent, bucketName, objectName string, withResourceContent bool) error {
 	gcsDestination := fmt.Sprintf("gs://%v/%v", bucketName, objectName)
 	exportAssetsRequest := cloudasset.ExportAssetsRequest{
 		OutputConfig: &cloudasset.OutputConfig{
@@ -44,6 +47,9 @@ func ForParentToStorageObject(ctx context.Context, httpClient *http.Client, pare
 			},
 		},
 	}
+	if withResourceContent {
+		exportAssetsRequest.ContentType = "RESOURCE"
+	}
 	assetClient, err := newAssetInventoryClient(ctx, httpClient)
 	if err != nil {
 		return fmt.Errorf("error creating asset client: %v", err)
@@ -35,7 +35,7 @@ func TestForParentToStorageObject(t *testing.T) {
 	defer testexport.DeleteTemporaryBucket(t, httpClient, bucketName)
 	projectId := testgcp.GetDefaultProjectID(t)
 	if err := export.ForParentToStorageObject(context.TODO(), httpClient,
-		fmt.Sprintf("projects/%v", projectId), bucketName, prefix); err != nil {
+		fmt.Sprintf("projects/%v", projectId), bucketName, prefix, false); err != nil {
 		t.Fatalf("error exporting asset inventory: %v", err)
 	}
 	defer testexport.DeleteExport(t, httpClient, bucketName, prefix)
@@ -0,0 +1,59 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asset
+
+import (
+	"fmt"
+	"strings"
+)
+
+const GlobalLocation = "global"
+
+// GetLabels returns the GCP labels of the asset's resource. Assets exported without the resource content have no
+// labels.
+func GetLabels(asset *Asset) map[string]string {
+	if asset.Resource == nil {
+		return nil
+	}
+	rawLabels, ok := asset.Resource.Data["labels"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	labels := make(map[string]string, len(rawLabels))
+	for k, v := range rawLabels {
+		labels[k] = fmt.Sprint(v)
+	}
+	return labels
+}
+
+// GetLocation returns the location of the asset's resource, i.e. a region, a zone, a multi-region or 'global'. When
+// the asset was exported without the resource content, the location is taken from the asset's name, e.g.
+//
+//	//compute.googleapis.com/projects/my-project/zones/us-central1-a/instances/my-instance
+//
+// is in 'us-central1-a'.
+func GetLocation(asset *Asset) string {
+	if asset.Resource != nil && asset.Resource.Location != "" {
+		return asset.Resource.Location
+	}
+	pieces := strings.Split(asset.Name, "/")
+	for i := 0; i < len(pieces)-1; i++ {
+		switch pieces[i] {
+		case "locations", "regions", "zones":
+			return pieces[i+1]
+		}
+	}
+	return GlobalLocation
+}
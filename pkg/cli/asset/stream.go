@@ -31,6 +31,13 @@ type Asset struct {
 	Name      string
 	AssetType string `json:"asset_type,omitempty"`
 	Ancestors []string
+	// Resource is only present when the asset inventory was exported with the 'RESOURCE' content type
+	Resource *Resource `json:"resource,omitempty"`
+}
+
+type Resource struct {
+	Data     map[string]interface{} `json:"data,omitempty"`
+	Location string                 `json:"location,omitempty"`
 }
 
 type Stream struct {
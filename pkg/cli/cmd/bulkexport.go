@@ -40,6 +40,10 @@ var (
 			ctx := cmd.Context()
 
 			fillRootFlagsOnBulkExportParams(&bulkExportParams)
+			// IAM policies cannot be exported offline, so default to not exporting them
+			if bulkExportParams.Offline && !cmd.Flags().Changed(commonparams.IAMFormatParamName) {
+				bulkExportParams.IAMFormat = commonparams.NoneIAMFormatOption
+			}
 			if err := parameters.Validate(&bulkExportParams, os.Stdin); err != nil {
 				return err
 			}
@@ -68,6 +72,26 @@ func init() {
 	bulkExportCmd.Flags().IntVar(&bulkExportParams.FolderId, parameters.FolderIdParam, 0, folderUsage)
 	organizationUsage := fmt.Sprintf("an optional organization id for which a cloud asset inventory will be exported to a temporary bucket; use the '%v' parameter to avoid the creation of a temporary bucket", parameters.StorageKeyParam)
 	bulkExportCmd.Flags().IntVar(&bulkExportParams.OrganizationId, parameters.OrganizationIdParam, 0, organizationUsage)
+	resolveReferencesUsage := "rewrite 'external' references to resources that are part of the export into 'name' references; as references can point at resources exported later, no output is written until all resources have been exported"
+	bulkExportCmd.Flags().BoolVar(&bulkExportParams.ResolveReferences, parameters.ResolveReferencesParam, false, resolveReferencesUsage)
+	includeKindsUsage := "an optional comma-separated list of Config Connector kinds to export, example: 'PubSubTopic,StorageBucket'; all supported kinds are exported by default"
+	bulkExportCmd.Flags().StringSliceVar(&bulkExportParams.IncludeKinds, parameters.IncludeKindsParam, nil, includeKindsUsage)
+	excludeKindsUsage := "an optional comma-separated list of Config Connector kinds to skip, example: 'IAMServiceAccount'"
+	bulkExportCmd.Flags().StringSliceVar(&bulkExportParams.ExcludeKinds, parameters.ExcludeKindsParam, nil, excludeKindsUsage)
+	labelSelectorUsage := "an optional selector matched against the GCP labels of each resource, supports the same syntax as kubectl's '--selector', example: 'env=prod,team!=infra'"
+	bulkExportCmd.Flags().StringVar(&bulkExportParams.LabelSelector, parameters.LabelSelectorParam, "", labelSelectorUsage)
+	locationUsage := "an optional location to export resources from, example: 'us-central1'; resources in the zones of a region are included when filtering by the region, use 'global' for resources without a location"
+	bulkExportCmd.Flags().StringVar(&bulkExportParams.Location, parameters.LocationParam, "", locationUsage)
+	parallelismUsage := "the number of resources to fetch from GCP concurrently; resources are always output in the order of the asset inventory"
+	bulkExportCmd.Flags().IntVar(&bulkExportParams.Parallelism, parameters.ParallelismParam, 1, parallelismUsage)
+	checkpointUsage := fmt.Sprintf("an optional file path where the names of exported assets are recorded; rerunning an interrupted export with the same checkpoint skips the assets it records, cannot be used with '%v'", parameters.ResolveReferencesParam)
+	bulkExportCmd.Flags().StringVar(&bulkExportParams.Checkpoint, parameters.CheckpointParam, "", checkpointUsage)
+	errorReportUsage := "an optional file path where a report of every asset that failed to export is written, as JSON if the path ends in '.json' and as YAML if it ends in '.yaml' or '.yml'"
+	bulkExportCmd.Flags().StringVar(&bulkExportParams.ErrorReport, parameters.ErrorReportParam, "", errorReportUsage)
+	offlineUsage := fmt.Sprintf("convert the resource data contained in the asset inventory to Config Connector resources instead of reading the resources from GCP; the asset inventory must be exported with the 'RESOURCE' content type and supplied on 'stdin' or the '%v' parameter, and IAM policies are not exported", parameters.InputParam)
+	bulkExportCmd.Flags().BoolVar(&bulkExportParams.Offline, parameters.OfflineParam, false, offlineUsage)
+	projectIDsUsage := fmt.Sprintf("an optional comma-separated list of project numbers and the IDs of the projects, example: '123456789=my-project'; only used with '%v' to look up the IDs of the projects that are not part of the asset inventory", parameters.OfflineParam)
+	bulkExportCmd.Flags().StringToStringVar(&bulkExportParams.ProjectIDs, parameters.ProjectIDsParam, nil, projectIDsUsage)
 }
 
 func fillRootFlagsOnBulkExportParams(params *parameters.Parameters) {
@@ -0,0 +1,104 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkpoint
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Checkpoint is a file containing the names of the assets which have been exported, one per line. A bulk export which
+// is interrupted can be resumed by rerunning it with the same checkpoint, in which case the assets already in the
+// checkpoint are skipped.
+//
+// A Checkpoint is safe for concurrent use: with parallel exports, assets are checked against the checkpoint by the
+// conversion workers while the completed assets are recorded by the caller.
+type Checkpoint struct {
+	mu        sync.RWMutex
+	file      *os.File
+	completed map[string]bool
+}
+
+// Open reads the names of the completed assets from the checkpoint file at the given path, creating the file if it
+// does not exist. New names are appended to the file.
+func Open(filePath string) (*Checkpoint, error) {
+	file, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening checkpoint file '%v': %v", filePath, err)
+	}
+	completed := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// an interrupted write may leave an incomplete last line, which will not match any asset name and so is
+		// harmless
+		if name := strings.TrimSpace(scanner.Text()); name != "" {
+			completed[name] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error reading checkpoint file '%v': %v", filePath, err)
+	}
+	checkpoint := Checkpoint{
+		file:      file,
+		completed: completed,
+	}
+	return &checkpoint, nil
+}
+
+// IsCompleted returns true if the asset with the given name was recorded as completed.
+func (c *Checkpoint) IsCompleted(assetName string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.completed[assetName]
+}
+
+// Len returns the number of completed assets.
+func (c *Checkpoint) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.completed)
+}
+
+// MarkCompleted records the asset with the given name as completed. The name is written to the file immediately so that
+// it is not lost if the export is interrupted.
+func (c *Checkpoint) MarkCompleted(assetName string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.completed[assetName] {
+		return nil
+	}
+	if _, err := fmt.Fprintln(c.file, assetName); err != nil {
+		return fmt.Errorf("error writing to checkpoint file '%v': %v", c.file.Name(), err)
+	}
+	c.completed[assetName] = true
+	return nil
+}
+
+func (c *Checkpoint) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file == nil {
+		return nil
+	}
+	if err := c.file.Close(); err != nil {
+		return fmt.Errorf("error closing checkpoint file '%v': %v", c.file.Name(), err)
+	}
+	c.file = nil
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkpoint_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/checkpoint"
+)
+
+func TestCheckpointIsResumable(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "checkpoint")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	filePath := filepath.Join(tmpDir, "checkpoint.txt")
+	firstAsset := "//pubsub.googleapis.com/projects/my-project/topics/first"
+	secondAsset := "//pubsub.googleapis.com/projects/my-project/topics/second"
+
+	c := openCheckpoint(t, filePath)
+	if c.Len() != 0 {
+		t.Fatalf("got %v completed assets in a new checkpoint, want 0", c.Len())
+	}
+	if err := c.MarkCompleted(firstAsset); err != nil {
+		t.Fatalf("error marking asset completed: %v", err)
+	}
+	if !c.IsCompleted(firstAsset) || c.IsCompleted(secondAsset) {
+		t.Fatalf("unexpected completed assets after marking '%v' completed", firstAsset)
+	}
+	closeCheckpoint(t, c)
+
+	c = openCheckpoint(t, filePath)
+	defer closeCheckpoint(t, c)
+	if !c.IsCompleted(firstAsset) {
+		t.Fatalf("got '%v' not completed after reopening the checkpoint, want completed", firstAsset)
+	}
+	for _, name := range []string{firstAsset, secondAsset} {
+		if err := c.MarkCompleted(name); err != nil {
+			t.Fatalf("error marking asset completed: %v", err)
+		}
+	}
+	bytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("error reading checkpoint file: %v", err)
+	}
+	expected := firstAsset + "\n" + secondAsset + "\n"
+	if string(bytes) != expected {
+		t.Fatalf("unexpected checkpoint file contents: got '%v', want '%v'", string(bytes), expected)
+	}
+}
+
+// TestCheckpointConcurrentUse checks assets against the checkpoint while others are being marked completed, as
+// the conversion workers of a parallel export do. Run with '-race' to detect unsynchronized access.
+func TestCheckpointConcurrentUse(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "checkpoint")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	c := openCheckpoint(t, filepath.Join(tmpDir, "checkpoint.txt"))
+	defer closeCheckpoint(t, c)
+	assetCount := 100
+	assetName := func(i int) string {
+		return fmt.Sprintf("//pubsub.googleapis.com/projects/my-project/topics/topic-%v", i)
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, assetCount)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < assetCount; i++ {
+			if err := c.MarkCompleted(assetName(i)); err != nil {
+				errs <- err
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < assetCount; i++ {
+			c.IsCompleted(assetName(i))
+			c.Len()
+		}
+	}()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("error marking asset completed: %v", err)
+	}
+	if c.Len() != assetCount {
+		t.Fatalf("got %v completed assets, want %v", c.Len(), assetCount)
+	}
+	for i := 0; i < assetCount; i++ {
+		if !c.IsCompleted(assetName(i)) {
+			t.Fatalf("got '%v' not completed, want completed", assetName(i))
+		}
+	}
+}
+
+func openCheckpoint(t *testing.T, filePath string) *checkpoint.Checkpoint {
+	t.Helper()
+	c, err := checkpoint.Open(filePath)
+	if err != nil {
+		t.Fatalf("error opening checkpoint: %v", err)
+	}
+	return c
+}
+
+func closeCheckpoint(t *testing.T, c *checkpoint.Checkpoint) {
+	t.Helper()
+	if err := c.Close(); err != nil {
+		t.Fatalf("error closing checkpoint: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorhandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/stream"
+
+	"github.com/ghodss/yaml"
+)
+
+// Failure describes an error that occurred during the export. Fields which could not be determined for the error are
+// empty.
+type Failure struct {
+	AssetType    string `json:"assetType,omitempty"`
+	AssetName    string `json:"assetName,omitempty"`
+	Kind         string `json:"kind,omitempty"`
+	ResourceName string `json:"resourceName,omitempty"`
+	Stage        string `json:"stage,omitempty"`
+	Error        string `json:"error"`
+}
+
+// Report lists the failures of an export so that they can be triaged programmatically.
+type Report struct {
+	Failures []Failure `json:"failures"`
+}
+
+func NewReport() *Report {
+	return &Report{
+		Failures: make([]Failure, 0),
+	}
+}
+
+// Add records the given error as a failure. The asset of an error which does not contain it is looked up in
+// 'assetTracker' by its resource.
+func (r *Report) Add(err error, assetTracker *stream.AssetTracker) {
+	failure := Failure{
+		Error: err.Error(),
+	}
+	var exportErr *stream.ExportError
+	if errors.As(err, &exportErr) {
+		failure.Stage = string(exportErr.Stage)
+		failure.Kind = exportErr.Kind
+		a := exportErr.Asset
+		if exportErr.Resource != nil {
+			failure.ResourceName = exportErr.Resource.GetName()
+			if a == nil && assetTracker != nil {
+				a, _ = assetTracker.Pop(exportErr.Resource)
+			}
+		}
+		if a != nil {
+			failure.AssetType = a.AssetType
+			failure.AssetName = a.Name
+		}
+	}
+	r.Failures = append(r.Failures, failure)
+}
+
+// WriteFile writes the report to the given path as JSON if the path has a '.json' extension, and as YAML otherwise.
+func (r *Report) WriteFile(filePath string) error {
+	var bytes []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		bytes, err = json.MarshalIndent(r, "", "  ")
+	} else {
+		bytes, err = yaml.Marshal(r)
+	}
+	if err != nil {
+		return fmt.Errorf("error marshalling error report: %v", err)
+	}
+	if err := ioutil.WriteFile(filePath, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing error report to '%v': %v", filePath, err)
+	}
+	return nil
+}
+
+type reportingHandler struct {
+	handler      Handler
+	report       *Report
+	assetTracker *stream.AssetTracker
+}
+
+// NewReporting returns a Handler which adds every error to the given report before passing it on to 'handler'.
+func NewReporting(handler Handler, report *Report, assetTracker *stream.AssetTracker) Handler {
+	return &reportingHandler{
+		handler:      handler,
+		report:       report,
+		assetTracker: assetTracker,
+	}
+}
+
+func (r *reportingHandler) Handle(err error) error {
+	r.report.Add(err, r.assetTracker)
+	return r.handler.Handle(err)
+}
@@ -0,0 +1,124 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorhandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/stream"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestReportingHandler(t *testing.T) {
+	topicAsset := &asset.Asset{
+		Name:      "//pubsub.googleapis.com/projects/my-project/topics/my-topic",
+		AssetType: "pubsub.googleapis.com/Topic",
+	}
+	bucketAsset := &asset.Asset{
+		Name:      "//storage.googleapis.com/my-bucket",
+		AssetType: "storage.googleapis.com/Bucket",
+	}
+	bucket := &unstructured.Unstructured{}
+	bucket.SetKind("StorageBucket")
+	bucket.SetName("my-bucket")
+	assetTracker := stream.NewAssetTracker()
+	assetTracker.Track(bucket, bucketAsset)
+
+	report := NewReport()
+	handler := NewReporting(NewContinue(), report, assetTracker)
+	errs := []error{
+		&stream.ExportError{
+			Stage: stream.GetStage,
+			Asset: topicAsset,
+			Kind:  "PubSubTopic",
+			Err:   errors.New("error getting topic"),
+		},
+		// the asset of errors in later stages is looked up by the resource
+		fmt.Errorf("error getting next YAML: %w", &stream.ExportError{
+			Stage:    stream.IAMFetchStage,
+			Kind:     "StorageBucket",
+			Resource: bucket,
+			Err:      errors.New("error getting iam policy"),
+		}),
+		errors.New("error getting next asset"),
+	}
+	for _, err := range errs {
+		if err := handler.Handle(err); err != nil {
+			t.Fatalf("unexpected error from continue handler: %v", err)
+		}
+	}
+	expectedReport := &Report{
+		Failures: []Failure{
+			{
+				AssetType: "pubsub.googleapis.com/Topic",
+				AssetName: "//pubsub.googleapis.com/projects/my-project/topics/my-topic",
+				Kind:      "PubSubTopic",
+				Stage:     "GET",
+				Error:     "error getting topic",
+			},
+			{
+				AssetType:    "storage.googleapis.com/Bucket",
+				AssetName:    "//storage.googleapis.com/my-bucket",
+				Kind:         "StorageBucket",
+				ResourceName: "my-bucket",
+				Stage:        "IAM fetch",
+				Error:        "error getting next YAML: error getting iam policy",
+			},
+			{
+				Error: "error getting next asset",
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedReport, report); diff != "" {
+		t.Fatalf("unexpected report diff (-want +got):\n%v", diff)
+	}
+
+	tmpDir, err := ioutil.TempDir("", "errorreport")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	for _, fileName := range []string{"report.json", "report.yaml"} {
+		filePath := filepath.Join(tmpDir, fileName)
+		if err := report.WriteFile(filePath); err != nil {
+			t.Fatalf("error writing report: %v", err)
+		}
+		bytes, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("error reading report: %v", err)
+		}
+		var actualReport Report
+		unmarshal := yaml.Unmarshal
+		if filepath.Ext(fileName) == ".json" {
+			unmarshal = json.Unmarshal
+		}
+		if err := unmarshal(bytes, &actualReport); err != nil {
+			t.Fatalf("error unmarshalling '%v': %v", fileName, err)
+		}
+		if diff := cmp.Diff(expectedReport, &actualReport); diff != "" {
+			t.Errorf("unexpected diff for '%v' (-want +got):\n%v", fileName, diff)
+		}
+	}
+}
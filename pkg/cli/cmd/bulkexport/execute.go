@@ -20,62 +20,164 @@ import (
 	"io"
 	"os"
 
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/checkpoint"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/errorhandler"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/filteredinputstream"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/inputstream"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/outputstream"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/parameters"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/outputsink"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/serviceclient"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/stream"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/tf"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func Execute(ctx context.Context, params *parameters.Parameters) error {
+func Execute(ctx context.Context, params *parameters.Parameters) (err error) {
 	errorHandler, err := errorhandler.NewErrorHandler(params)
 	if err != nil {
 		return err
 	}
-	tfProvider, err := tf.NewProvider(params.OAuth2Token)
+	assetTracker := stream.NewAssetTracker()
+	if params.ErrorReport != "" {
+		report := errorhandler.NewReport()
+		errorHandler = errorhandler.NewReporting(errorHandler, report, assetTracker)
+		// the report is written however the export ends, including when it is halted by an error
+		defer func() {
+			if writeErr := report.WriteFile(params.ErrorReport); writeErr != nil && err == nil {
+				err = writeErr
+			}
+		}()
+	}
+	tfProvider, err := newProvider(params)
+	if err != nil {
+		return err
+	}
+	checkpoint, err := openCheckpoint(params)
+	if err != nil {
+		return err
+	}
+	if checkpoint != nil {
+		defer checkpoint.Close()
+	}
+	serviceClient, offlineServiceClient, err := newServiceClient(ctx, params)
 	if err != nil {
 		return err
 	}
-	assetStream, err := newFilteredAssetStream(params, tfProvider)
+	assetStream, err := newFilteredAssetStream(params, tfProvider, checkpoint, offlineServiceClient)
 	if err != nil {
 		return err
 	}
 	defer assetStream.Close()
-	yamlStream, err := outputstream.NewResourceByteStream(params, assetStream)
+	yamlStream, resourceStreamCloser, err := outputstream.NewResourceByteStream(params, tfProvider, serviceClient, assetStream, assetTracker)
 	if err != nil {
 		return err
 	}
+	// deferred after assetStream.Close() so that the assets are no longer read in the background when it is closed
+	defer resourceStreamCloser.Close()
 	recoverableStream := stream.NewRecoverableByteStream(yamlStream)
 	outputSink, err := outputsink.New(tfProvider, params.Output, outputsink.ResourceFormat(params.ResourceFormat))
 	if err != nil {
 		return err
 	}
+	// closing a sink more than once has no further effect, this only closes the sink when returning early
 	defer outputSink.Close()
+	// the asset whose resource was last received by the sink, it is only completed once the resource of the next asset
+	// is received as the IAM resources of an asset follow its resource
+	var lastAsset *asset.Asset
 	for bytes, unstructured, err := recoverableStream.Next(ctx); err != io.EOF; bytes, unstructured, err = recoverableStream.Next(ctx) {
 		if err != nil {
-			if err := errorHandler.Handle(fmt.Errorf("error getting next YAML: %v", err)); err != nil {
+			if err := errorHandler.Handle(fmt.Errorf("error getting next YAML: %w", err)); err != nil {
 				return err
 			}
 			continue
 		}
 		if err := outputSink.Receive(ctx, bytes, unstructured); err != nil {
+			if unstructured != nil {
+				err = &stream.ExportError{Stage: stream.OutputStage, Kind: unstructured.GetKind(), Resource: unstructured, Err: err}
+			}
 			if err := errorHandler.Handle(err); err != nil {
 				return err
 			}
+			continue
+		}
+		if a, ok := assetTracker.Pop(unstructured); ok {
+			if err := completeAsset(checkpoint, lastAsset); err != nil {
+				return err
+			}
+			lastAsset = a
 		}
 	}
-	return nil
+	// sinks may buffer their output until they are closed, so errors on close must not be ignored
+	if err := outputSink.Close(); err != nil {
+		return err
+	}
+	return completeAsset(checkpoint, lastAsset)
 }
 
-func newFilteredAssetStream(params *parameters.Parameters, tfProvider *schema.Provider) (stream.AssetStream, error) {
-	assetStream, err := inputstream.NewAssetStream(params, os.Stdin)
+func completeAsset(checkpoint *checkpoint.Checkpoint, a *asset.Asset) error {
+	if checkpoint == nil || a == nil {
+		return nil
+	}
+	return checkpoint.MarkCompleted(a.Name)
+}
+
+func newProvider(params *parameters.Parameters) (*schema.Provider, error) {
+	if params.Offline {
+		return tf.NewOfflineProvider()
+	}
+	return tf.NewProvider(params.OAuth2Token)
+}
+
+// newServiceClient returns the client used to look up projects. In offline mode, the client is also returned as an
+// OfflineServiceClient so that the Project assets can be recorded in it.
+func newServiceClient(ctx context.Context, params *parameters.Parameters) (serviceclient.ServiceClient, *serviceclient.OfflineServiceClient, error) {
+	if params.Offline {
+		offlineServiceClient := serviceclient.NewOfflineServiceClient(params.ProjectIDs)
+		return offlineServiceClient, offlineServiceClient, nil
+	}
+	httpClient, err := serviceclient.NewHTTPClient(ctx, params.OAuth2Token)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating http client: %v", err)
+	}
+	serviceClient := serviceclient.NewServiceClient(httpClient)
+	return &serviceClient, nil, nil
+}
+
+func newFilteredAssetStream(params *parameters.Parameters, tfProvider *schema.Provider, checkpoint *checkpoint.Checkpoint,
+	offlineServiceClient *serviceclient.OfflineServiceClient) (stream.AssetStream, error) {
+	inputStream, err := inputstream.NewAssetStream(params, os.Stdin)
 	if err != nil {
 		return nil, err
 	}
-	return filteredinputstream.NewFilteredAssetStream(assetStream, tfProvider)
+	var assetStream stream.AssetStream = inputStream
+	if offlineServiceClient != nil {
+		// the Project assets are recorded before any filtering, so that the IDs of the projects are known even if
+		// their assets are not exported
+		assetStream = stream.NewFilteredAssetStream(assetStream, func(a *asset.Asset) bool {
+			offlineServiceClient.RecordProjectAsset(a)
+			return true
+		})
+	}
+	return filteredinputstream.NewFilteredAssetStream(assetStream, tfProvider, params, checkpoint)
+}
+
+func openCheckpoint(params *parameters.Parameters) (*checkpoint.Checkpoint, error) {
+	if params.Checkpoint == "" {
+		return nil, nil
+	}
+	c, err := checkpoint.Open(params.Checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	// the output file sink truncates the file when it is opened, so resuming into it would lose the resources of the
+	// assets that were already completed
+	if fi, err := os.Stat(params.Output); c.Len() > 0 && err == nil && fi.Mode().IsRegular() {
+		c.Close()
+		return nil, fmt.Errorf("cannot resume an export from checkpoint '%v' into the existing file '%v': the resources "+
+			"that were already exported would be overwritten, use an output directory instead", params.Checkpoint, params.Output)
+	}
+	return c, nil
 }
@@ -17,12 +17,17 @@ package filteredinputstream
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/checkpoint"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/parameters"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/log"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/stream"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/servicemapping/servicemappingloader"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 )
 
 var defaultNetworkingNameRegexByAssetType = map[string]string{
@@ -31,6 +36,9 @@ var defaultNetworkingNameRegexByAssetType = map[string]string{
 	"compute.googleapis.com/Route":      ".*default-route-.*$",
 }
 
+// zoneRegex matches zones, e.g. 'us-central1-a', and captures the region they are in.
+var zoneRegex = regexp.MustCompile(`^([a-z]+-[a-z]+[0-9]+)-[a-z]$`)
+
 func isAssetSupported(smLoader *servicemappingloader.ServiceMappingLoader, tfProvider *schema.Provider, a *asset.Asset) bool {
 	_, rc, err := asset.GetServiceMappingAndResourceConfig(smLoader, a)
 	if err != nil {
@@ -57,21 +65,125 @@ func isDefaultNetworkingAsset(smLoader *servicemappingloader.ServiceMappingLoade
 	return false
 }
 
-func NewFilteredAssetStream(assetStream *asset.Stream, tfProvider *schema.Provider) (stream.AssetStream, error) {
+// userFilter holds the filters supplied on the command line. An asset is only exported if it matches all of them.
+type userFilter struct {
+	includeKinds  map[string]bool
+	excludeKinds  map[string]bool
+	labelSelector k8slabels.Selector
+	location      string
+}
+
+func newUserFilter(params *parameters.Parameters) (*userFilter, error) {
+	filter := userFilter{
+		includeKinds:  toSet(params.IncludeKinds),
+		excludeKinds:  toSet(params.ExcludeKinds),
+		labelSelector: k8slabels.Everything(),
+		location:      params.Location,
+	}
+	if params.LabelSelector != "" {
+		selector, err := k8slabels.Parse(params.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing '%v' value of '%v': %v", parameters.LabelSelectorParam, params.LabelSelector, err)
+		}
+		filter.labelSelector = selector
+	}
+	return &filter, nil
+}
+
+func (f *userFilter) matchesKind(smLoader *servicemappingloader.ServiceMappingLoader, a *asset.Asset) bool {
+	if len(f.includeKinds) == 0 && len(f.excludeKinds) == 0 {
+		return true
+	}
+	_, rc, err := asset.GetServiceMappingAndResourceConfig(smLoader, a)
+	if err != nil {
+		return false
+	}
+	if len(f.includeKinds) > 0 && !f.includeKinds[rc.Kind] {
+		return false
+	}
+	return !f.excludeKinds[rc.Kind]
+}
+
+// matchesLabels returns true if the labels of the asset match the label selector. Assets exported without their
+// resource content have no labels, so an error is returned rather than silently dropping them.
+func (f *userFilter) matchesLabels(a *asset.Asset) (bool, error) {
+	if f.labelSelector.Empty() {
+		return true, nil
+	}
+	if a.Resource == nil {
+		return false, fmt.Errorf("unable to apply label selector '%v' to asset %v/%v: the asset has no resource data, "+
+			"export the asset inventory with the resource content type", f.labelSelector, a.AssetType, a.Name)
+	}
+	return f.labelSelector.Matches(k8slabels.Set(asset.GetLabels(a))), nil
+}
+
+// matchesLocation returns true if the asset is in the filtered location. Zonal assets match the filter of the region
+// they are in, e.g. an asset in 'us-central1-a' matches 'us-central1'. Multi-regions, e.g. 'us', only match assets in
+// that multi-region.
+func (f *userFilter) matchesLocation(a *asset.Asset) bool {
+	if f.location == "" {
+		return true
+	}
+	location := strings.ToLower(asset.GetLocation(a))
+	filterLocation := strings.ToLower(f.location)
+	if location == filterLocation {
+		return true
+	}
+	if match := zoneRegex.FindStringSubmatch(location); match != nil {
+		return match[1] == filterLocation
+	}
+	return false
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// NewFilteredAssetStream returns a stream of the supported assets in 'assetStream' which match the filters in 'params'.
+// If 'checkpoint' is non-nil, the assets it records as completed are skipped.
+func NewFilteredAssetStream(assetStream stream.AssetStream, tfProvider *schema.Provider, params *parameters.Parameters, checkpoint *checkpoint.Checkpoint) (stream.AssetStream, error) {
 	smLoader, err := servicemappingloader.New()
 	if err != nil {
 		return nil, fmt.Errorf("error loading service mappings: %v", err)
 	}
-	filter := func(a *asset.Asset) bool {
+	userFilter, err := newUserFilter(params)
+	if err != nil {
+		return nil, err
+	}
+	filter := func(a *asset.Asset) (bool, error) {
+		if checkpoint != nil && checkpoint.IsCompleted(a.Name) {
+			log.Verbose("skipping asset completed in a previous export: %v/%v", a.AssetType, a.Name)
+			return false, nil
+		}
 		if !isAssetSupported(smLoader, tfProvider, a) {
 			log.Verbose("skipping unsupported asset: %v", a.AssetType)
-			return false
+			return false, nil
 		}
 		if isDefaultNetworkingAsset(smLoader, tfProvider, a) {
 			log.Verbose("skipping default asset, as it cannot be normally acquired or imported: %v/%v", a.AssetType, a.Name)
-			return false
+			return false, nil
 		}
-		return true
+		if !userFilter.matchesKind(smLoader, a) {
+			log.Verbose("skipping asset with a kind that is filtered out: %v/%v", a.AssetType, a.Name)
+			return false, nil
+		}
+		if !userFilter.matchesLocation(a) {
+			log.Verbose("skipping asset outside of location '%v': %v/%v", userFilter.location, a.AssetType, a.Name)
+			return false, nil
+		}
+		matches, err := userFilter.matchesLabels(a)
+		if err != nil {
+			return false, err
+		}
+		if !matches {
+			log.Verbose("skipping asset with labels that do not match '%v': %v/%v", userFilter.labelSelector, a.AssetType, a.Name)
+			return false, nil
+		}
+		return true, nil
 	}
-	return stream.NewFilteredAssetStream(assetStream, filter), nil
+	return stream.NewFallibleFilteredAssetStream(assetStream, filter), nil
 }
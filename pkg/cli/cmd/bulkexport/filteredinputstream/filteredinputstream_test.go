@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/parameters"
 	testservicemappingloader "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/test/servicemappingloader"
 	tfprovider "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/tf/provider"
 )
@@ -144,3 +145,199 @@ func TestIsDefaultNetworkingAsset(t *testing.T) {
 		})
 	}
 }
+
+func TestUserFilter(t *testing.T) {
+	smLoader := testservicemappingloader.New(t)
+	topic := &asset.Asset{
+		Name:      "//pubsub.googleapis.com/projects/my-project/topics/my-topic",
+		AssetType: "pubsub.googleapis.com/Topic",
+		Resource: &asset.Resource{
+			Data: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"env": "prod",
+				},
+			},
+		},
+	}
+	zonalInstance := &asset.Asset{
+		Name:      "//compute.googleapis.com/projects/my-project/zones/us-central1-a/instances/my-instance",
+		AssetType: "compute.googleapis.com/Instance",
+	}
+	regionalSubnetwork := &asset.Asset{
+		Name:      "//compute.googleapis.com/projects/my-project/regions/us-central1/subnetworks/my-subnetwork",
+		AssetType: "compute.googleapis.com/Subnetwork",
+		Resource: &asset.Resource{
+			Location: "us-central1",
+		},
+	}
+	multiRegionalBucket := &asset.Asset{
+		Name:      "//storage.googleapis.com/my-bucket",
+		AssetType: "storage.googleapis.com/Bucket",
+		Resource: &asset.Resource{
+			Location: "US",
+		},
+	}
+	regionalBucket := &asset.Asset{
+		Name:      "//storage.googleapis.com/my-regional-bucket",
+		AssetType: "storage.googleapis.com/Bucket",
+		Resource: &asset.Resource{
+			Location: "US-CENTRAL1",
+		},
+	}
+	testCases := []struct {
+		Name           string
+		Params         parameters.Parameters
+		Asset          *asset.Asset
+		ExpectedResult bool
+		ShouldErr      bool
+	}{
+		{
+			Name:           "no filters",
+			Asset:          topic,
+			ExpectedResult: true,
+		},
+		{
+			Name:           "included kind",
+			Params:         parameters.Parameters{IncludeKinds: []string{"PubSubTopic", "StorageBucket"}},
+			Asset:          topic,
+			ExpectedResult: true,
+		},
+		{
+			Name:           "kind not included",
+			Params:         parameters.Parameters{IncludeKinds: []string{"StorageBucket"}},
+			Asset:          topic,
+			ExpectedResult: false,
+		},
+		{
+			Name:           "excluded kind",
+			Params:         parameters.Parameters{ExcludeKinds: []string{"PubSubTopic"}},
+			Asset:          topic,
+			ExpectedResult: false,
+		},
+		{
+			Name:           "matching label selector",
+			Params:         parameters.Parameters{LabelSelector: "env in (prod, staging)"},
+			Asset:          topic,
+			ExpectedResult: true,
+		},
+		{
+			Name:           "non-matching label selector",
+			Params:         parameters.Parameters{LabelSelector: "env=dev"},
+			Asset:          topic,
+			ExpectedResult: false,
+		},
+		{
+			Name:      "label selector for asset without resource data",
+			Params:    parameters.Parameters{LabelSelector: "env"},
+			Asset:     zonalInstance,
+			ShouldErr: true,
+		},
+		{
+			Name:           "zone matches its region",
+			Params:         parameters.Parameters{Location: "us-central1"},
+			Asset:          zonalInstance,
+			ExpectedResult: true,
+		},
+		{
+			Name:           "region does not match a zone",
+			Params:         parameters.Parameters{Location: "us-central1-a"},
+			Asset:          regionalSubnetwork,
+			ExpectedResult: false,
+		},
+		{
+			Name:           "different region",
+			Params:         parameters.Parameters{Location: "us-east1"},
+			Asset:          regionalSubnetwork,
+			ExpectedResult: false,
+		},
+		{
+			Name:           "multi-region us does not match a zone",
+			Params:         parameters.Parameters{Location: "us"},
+			Asset:          zonalInstance,
+			ExpectedResult: false,
+		},
+		{
+			Name:           "multi-region us does not match a region",
+			Params:         parameters.Parameters{Location: "us"},
+			Asset:          regionalSubnetwork,
+			ExpectedResult: false,
+		},
+		{
+			Name:           "multi-region us matches a multi-regional asset",
+			Params:         parameters.Parameters{Location: "us"},
+			Asset:          multiRegionalBucket,
+			ExpectedResult: true,
+		},
+		{
+			Name:           "regional bucket matches its region",
+			Params:         parameters.Parameters{Location: "us-central1"},
+			Asset:          regionalBucket,
+			ExpectedResult: true,
+		},
+		{
+			Name:   "bucket without resource data is global",
+			Params: parameters.Parameters{Location: "us-central1"},
+			Asset: &asset.Asset{
+				Name:      regionalBucket.Name,
+				AssetType: regionalBucket.AssetType,
+			},
+			ExpectedResult: false,
+		},
+		{
+			Name:   "multi-region eu does not match a zone",
+			Params: parameters.Parameters{Location: "eu"},
+			Asset: &asset.Asset{
+				Name:      "//compute.googleapis.com/projects/my-project/zones/europe-west1-b/instances/my-instance",
+				AssetType: "compute.googleapis.com/Instance",
+			},
+			ExpectedResult: false,
+		},
+		{
+			Name:   "multi-region asia does not match a region",
+			Params: parameters.Parameters{Location: "asia"},
+			Asset: &asset.Asset{
+				Name:      "//compute.googleapis.com/projects/my-project/regions/asia-east1/subnetworks/my-subnetwork",
+				AssetType: "compute.googleapis.com/Subnetwork",
+				Resource: &asset.Resource{
+					Location: "asia-east1",
+				},
+			},
+			ExpectedResult: false,
+		},
+		{
+			Name:           "multi-region asia does not match a multi-regional asset in another multi-region",
+			Params:         parameters.Parameters{Location: "asia"},
+			Asset:          multiRegionalBucket,
+			ExpectedResult: false,
+		},
+		{
+			Name:           "global asset",
+			Params:         parameters.Parameters{Location: "global"},
+			Asset:          topic,
+			ExpectedResult: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			filter, err := newUserFilter(&tc.Params)
+			if err != nil {
+				t.Fatalf("error creating filter: %v", err)
+			}
+			matchesLabels, err := filter.matchesLabels(tc.Asset)
+			if tc.ShouldErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := filter.matchesKind(smLoader, tc.Asset) && matchesLabels && filter.matchesLocation(tc.Asset)
+			if got != tc.ExpectedResult {
+				t.Errorf("got '%v', want '%v'", got, tc.ExpectedResult)
+			}
+		})
+	}
+}
@@ -82,13 +82,20 @@ func newStorageObjectStream(params *parameters.Parameters) (*asset.Stream, error
 	exportCtx, cancel := context.WithTimeout(context.Background(), exportTimeout)
 	defer cancel()
 	log.Verbose("Creating asset inventory export at %v", storage.GetFullURI(bucketName, objectName))
-	if err := export.ForParentToStorageObject(exportCtx, httpClient, parent, bucketName, objectName); err != nil {
+	if err := export.ForParentToStorageObject(exportCtx, httpClient, parent, bucketName, objectName, needsResourceContent(params)); err != nil {
 		return nil, fmt.Errorf("error exporting asset inventory: %v", err)
 	}
 	defer deleteExport(httpClient, bucketName, objectName)
 	return newStreamFromStorageObject(httpClient, bucketName, objectName)
 }
 
+// needsResourceContent returns true if the filters in 'params' need the resource content of the assets. The labels of
+// the assets are only part of their resource content, as is the location of the assets whose name does not contain
+// it, e.g. a regional storage bucket.
+func needsResourceContent(params *parameters.Parameters) bool {
+	return params.LabelSelector != "" || params.Location != ""
+}
+
 func newStreamFromStorageObject(httpClient *http.Client, bucketName, objectName string) (*asset.Stream, error) {
 	ctx, cancel := newRequestContext()
 	defer cancel()
@@ -0,0 +1,57 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inputstream
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/parameters"
+)
+
+func TestNeedsResourceContent(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Params         parameters.Parameters
+		ExpectedResult bool
+	}{
+		{
+			Name:           "no filters",
+			ExpectedResult: false,
+		},
+		{
+			Name:           "kind filter",
+			Params:         parameters.Parameters{IncludeKinds: []string{"StorageBucket"}},
+			ExpectedResult: false,
+		},
+		{
+			Name:           "label selector",
+			Params:         parameters.Parameters{LabelSelector: "env=prod"},
+			ExpectedResult: true,
+		},
+		{
+			Name:           "location filter for regional buckets",
+			Params:         parameters.Parameters{IncludeKinds: []string{"StorageBucket"}, Location: "us-central1"},
+			ExpectedResult: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := needsResourceContent(&tc.Params); got != tc.ExpectedResult {
+				t.Errorf("got '%v', want '%v'", got, tc.ExpectedResult)
+			}
+		})
+	}
+}
@@ -15,58 +15,89 @@
 package outputstream
 
 import (
-	"context"
 	"fmt"
+	"io"
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/parameters"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/singleresourceiamclient"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/commonparams"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/gcpclient"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/outputsink"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/referencerewriter"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/serviceclient"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/stream"
-	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/tf"
+	dclmetadata "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/schema/dclschemaloader"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/servicemapping/servicemappingloader"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func NewResourceByteStream(params *parameters.Parameters, assetStream stream.AssetStream) (stream.ByteStream, error) {
-	provider, err := tf.NewProvider(params.OAuth2Token)
+// NewResourceByteStream returns the stream of the resources for the assets in 'assetStream' in the requested format.
+// Projects are looked up with 'serviceClient'. The returned closer stops reading and converting the assets in the
+// background and must be closed before 'assetStream'.
+func NewResourceByteStream(params *parameters.Parameters, provider *schema.Provider, serviceClient serviceclient.ServiceClient, assetStream stream.AssetStream, assetTracker *stream.AssetTracker) (stream.ByteStream, io.Closer, error) {
+	smLoader, err := servicemappingloader.New()
 	if err != nil {
-		return nil, err
+		return nil, nil, fmt.Errorf("error creating service mapping loader: %v", err)
 	}
-	smLoader, err := servicemappingloader.New()
+	unstructuredStream, closer, err := NewUnstructuredStream(params, assetStream, provider, serviceClient, smLoader, assetTracker)
 	if err != nil {
-		return nil, fmt.Errorf("error creating service mapping loader: %v", err)
+		return nil, nil, err
 	}
-	unstructuredStream, err := NewUnstructuredStream(params, assetStream, provider, smLoader)
+	byteStream, err := stream.NewByteStream(outputsink.ResourceFormat(params.ResourceFormat), unstructuredStream, smLoader, provider)
 	if err != nil {
-		return nil, err
+		closer.Close()
+		return nil, nil, err
 	}
-	return stream.NewByteStream(outputsink.ResourceFormat(params.ResourceFormat), unstructuredStream, smLoader, provider)
+	return byteStream, closer, nil
 }
 
-func NewUnstructuredStream(params *parameters.Parameters, assetStream stream.AssetStream, provider *schema.Provider, smLoader *servicemappingloader.ServiceMappingLoader) (stream.UnstructuredStream, error) {
-	httpClient, err := serviceclient.NewHTTPClient(context.TODO(), params.OAuth2Token)
+// NewUnstructuredStream returns the stream of resources, and optionally their IAM policies, for the assets in
+// 'assetStream'. If 'assetTracker' is non-nil, the asset of each resource is recorded in it. The returned closer must
+// be closed before 'assetStream', see NewResourceByteStream(...).
+func NewUnstructuredStream(params *parameters.Parameters, assetStream stream.AssetStream, provider *schema.Provider, serviceClient serviceclient.ServiceClient, smLoader *servicemappingloader.ServiceMappingLoader, assetTracker *stream.AssetTracker) (stream.UnstructuredStream, io.Closer, error) {
+	unstructuredResourceStream, err := newUnstructuredResourceStream(params, assetStream, provider, serviceClient, smLoader)
 	if err != nil {
-		return nil, fmt.Errorf("error creating http client: %v", err)
+		return nil, nil, fmt.Errorf("error creating unstructured resource stream: %v", err)
 	}
-	serviceClient := serviceclient.NewServiceClient(httpClient)
-	gcpClient := gcpclient.New(provider, smLoader)
-	unstructuredResourceStream, err := stream.NewUnstructuredResourceStreamFromAssetStream(assetStream, gcpClient, provider, &serviceClient)
+	outputStream, err := wrapUnstructuredResourceStream(params, unstructuredResourceStream, provider, smLoader, assetTracker)
 	if err != nil {
-		return nil, fmt.Errorf("error creating unstructured resource stream: %v", err)
+		unstructuredResourceStream.Close()
+		return nil, nil, err
+	}
+	return outputStream, unstructuredResourceStream, nil
+}
+
+func wrapUnstructuredResourceStream(params *parameters.Parameters, unstructuredResourceStream *stream.AssetToUnstructuredResourceStream, provider *schema.Provider, smLoader *servicemappingloader.ServiceMappingLoader, assetTracker *stream.AssetTracker) (stream.UnstructuredStream, error) {
+	if assetTracker != nil {
+		unstructuredResourceStream.SetAssetTracker(assetTracker)
 	}
-	fixupStream := stream.NewUnstructuredResourceFixupStream(unstructuredResourceStream)
-	if params.IAMFormat == commonparams.NoneIAMFormatOption {
-		return fixupStream, nil
+	var outputStream stream.UnstructuredStream
+	outputStream = stream.NewUnstructuredResourceFixupStream(unstructuredResourceStream)
+	if params.IAMFormat != commonparams.NoneIAMFormatOption {
+		iamClient := singleresourceiamclient.New(provider, smLoader)
+		iamFormat, err := commonparams.IAMFormatParamToStreamIAMFormat(params.IAMFormat)
+		if err != nil {
+			return nil, err
+		}
+		outputStream = stream.NewUnstructuredResourceAndIAMPolicyStream(outputStream, iamClient, iamFormat, params.FilterDeletedIAMMembers)
 	}
-	iamClient := singleresourceiamclient.New(provider, smLoader)
-	iamFormat, err := commonparams.IAMFormatParamToStreamIAMFormat(params.IAMFormat)
+	if !params.ResolveReferences {
+		return outputStream, nil
+	}
+	dclSchemaLoader, err := dclschemaloader.New()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error creating DCL schema loader: %v", err)
 	}
-	unstructuredResourceAndPolicyStream := stream.NewUnstructuredResourceAndIAMPolicyStream(fixupStream, iamClient, iamFormat, params.FilterDeletedIAMMembers)
-	return unstructuredResourceAndPolicyStream, nil
+	rewriter := referencerewriter.New(smLoader, dclmetadata.New(), dclSchemaLoader)
+	return stream.NewUnstructuredResourceReferenceStream(outputStream, rewriter), nil
+}
+
+func newUnstructuredResourceStream(params *parameters.Parameters, assetStream stream.AssetStream, provider *schema.Provider, serviceClient serviceclient.ServiceClient, smLoader *servicemappingloader.ServiceMappingLoader) (*stream.AssetToUnstructuredResourceStream, error) {
+	if params.Offline {
+		return stream.NewOfflineUnstructuredResourceStreamFromAssetStream(assetStream, provider, serviceClient, params.Parallelism)
+	}
+	gcpClient := gcpclient.New(provider, smLoader)
+	return stream.NewUnstructuredResourceStreamFromAssetStream(assetStream, gcpClient, provider, serviceClient, params.Parallelism)
 }
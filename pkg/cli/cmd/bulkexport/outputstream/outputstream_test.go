@@ -22,8 +22,10 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/inputstream"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/outputstream"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/parameters"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/serviceclient"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/stream"
 	testos "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/test/os"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/tf"
 	testservicemappingloader "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/test/servicemappingloader"
 	tfprovider "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/tf/provider"
 )
@@ -38,8 +40,9 @@ func TestNewUnstructuredSTreamWithUnknownIAMOption(t *testing.T) {
 		t.Fatalf("error creating asset stream: %v", err)
 	}
 	defer closeStream(t, assetStream)
-	_, err = outputstream.NewUnstructuredStream(&params, assetStream, tfprovider.NewOrLogFatal(tfprovider.NewConfig()),
-		testservicemappingloader.New(t))
+	serviceClient := serviceclient.NewMockServiceClient(t)
+	_, _, err = outputstream.NewUnstructuredStream(&params, assetStream, tfprovider.NewOrLogFatal(tfprovider.NewConfig()),
+		&serviceClient, testservicemappingloader.New(t), nil)
 	if err == nil {
 		t.Fatal("invalid error value: got 'nil', want an error")
 	}
@@ -72,11 +75,13 @@ func testNewUnstructuredStreamWithIAMOption(t *testing.T, iamFormatOption string
 	}
 	defer closeStream(t, assetStream)
 	params.IAMFormat = iamFormatOption
-	unstructuredStream, err := outputstream.NewUnstructuredStream(&params, assetStream, tfprovider.NewOrLogFatal(tfprovider.NewConfig()),
-		testservicemappingloader.New(t))
+	serviceClient := serviceclient.NewMockServiceClient(t)
+	unstructuredStream, closer, err := outputstream.NewUnstructuredStream(&params, assetStream, tfprovider.NewOrLogFatal(tfprovider.NewConfig()),
+		&serviceClient, testservicemappingloader.New(t), nil)
 	if err != nil {
 		t.Fatalf("error creating stream: %v", err)
 	}
+	defer closer.Close()
 	expectedType := reflect.TypeOf(instanceOfExpectedType)
 	actualType := reflect.TypeOf(unstructuredStream).Elem()
 	if expectedType != actualType {
@@ -84,6 +89,33 @@ func testNewUnstructuredStreamWithIAMOption(t *testing.T, iamFormatOption string
 	}
 }
 
+func TestNewUnstructuredStreamOffline(t *testing.T) {
+	cleanup, stdin := testos.GetStdin(t, "garbage string")
+	defer cleanup()
+	params := parameters.Parameters{
+		IAMFormat: "none",
+		Offline:   true,
+	}
+	assetStream, err := inputstream.NewAssetStream(&params, stdin)
+	if err != nil {
+		t.Fatalf("error creating asset stream: %v", err)
+	}
+	defer closeStream(t, assetStream)
+	// no credentials are needed to create an offline stream
+	provider, err := tf.NewOfflineProvider()
+	if err != nil {
+		t.Fatalf("error creating provider: %v", err)
+	}
+	unstructuredStream, closer, err := outputstream.NewUnstructuredStream(&params, assetStream, provider, serviceclient.NewOfflineServiceClient(nil), testservicemappingloader.New(t), nil)
+	if err != nil {
+		t.Fatalf("error creating stream: %v", err)
+	}
+	defer closer.Close()
+	if _, ok := unstructuredStream.(*stream.UnstructuredResourceFixupStream); !ok {
+		t.Fatalf("unexpected type for unstructured stream: got '%v', want '%v'", reflect.TypeOf(unstructuredStream), reflect.TypeOf(&stream.UnstructuredResourceFixupStream{}))
+	}
+}
+
 func closeStream(t *testing.T, assetStream *asset.Stream) {
 	err := assetStream.Close()
 	if err != nil {
@@ -17,23 +17,38 @@ package parameters
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/commonparams"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/util/valutil"
+
+	"k8s.io/apimachinery/pkg/labels"
 )
 
+var projectNumberRegex = regexp.MustCompile(`^[0-9]+$`)
+
 type OnErrorOption string
 type IAMFormatOption string
 
 const (
-	InputParam          = "input"
-	OnErrorParam        = "on-error"
-	StorageKeyParam     = "storage-key"
-	ProjectIdParam      = "project"
-	FolderIdParam       = "folder"
-	OrganizationIdParam = "organization"
+	InputParam             = "input"
+	OnErrorParam           = "on-error"
+	StorageKeyParam        = "storage-key"
+	ProjectIdParam         = "project"
+	FolderIdParam          = "folder"
+	OrganizationIdParam    = "organization"
+	ResolveReferencesParam = "resolve-references"
+	IncludeKindsParam      = "include-kinds"
+	ExcludeKindsParam      = "exclude-kinds"
+	LabelSelectorParam     = "label-selector"
+	LocationParam          = "location"
+	ParallelismParam       = "parallelism"
+	CheckpointParam        = "checkpoint"
+	ErrorReportParam       = "error-report"
+	OfflineParam           = "offline"
+	ProjectIDsParam        = "project-ids"
 
 	ContinueOnErrorOption = "continue"
 	HaltOnErrorOption     = "halt"
@@ -60,6 +75,16 @@ type Parameters struct {
 	OrganizationId          int
 	OAuth2Token             string
 	ResourceFormat          string
+	ResolveReferences       bool
+	IncludeKinds            []string
+	ExcludeKinds            []string
+	LabelSelector           string
+	Location                string
+	Parallelism             int
+	Checkpoint              string
+	ErrorReport             string
+	Offline                 bool
+	ProjectIDs              map[string]string
 	Verbose                 bool
 }
 
@@ -102,6 +127,24 @@ func Validate(p *Parameters, stdin *os.File) error {
 	if err := validateOneInput(p, stdin); err != nil {
 		return err
 	}
+	if err := validateKindFilters(p); err != nil {
+		return err
+	}
+	if err := validateLabelSelector(p); err != nil {
+		return err
+	}
+	if err := validateParallelism(p); err != nil {
+		return err
+	}
+	if err := validateCheckpoint(p); err != nil {
+		return err
+	}
+	if err := validateErrorReport(p); err != nil {
+		return err
+	}
+	if err := validateOffline(p); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -162,6 +205,101 @@ func validateOnError(p *Parameters) error {
 	return fmt.Errorf("invalid %v value of '%v': must be one of {%v}", OnErrorParam, p.OnError, strings.Join(onErrorOptions, ", "))
 }
 
+func validateKindFilters(p *Parameters) error {
+	excluded := make(map[string]bool, len(p.ExcludeKinds))
+	for _, k := range p.ExcludeKinds {
+		excluded[k] = true
+	}
+	for _, k := range p.IncludeKinds {
+		if k == "" {
+			return fmt.Errorf("invalid %v value: kinds must not be empty", IncludeKindsParam)
+		}
+		if excluded[k] {
+			return fmt.Errorf("kind '%v' cannot be supplied to both '%v' and '%v'", k, IncludeKindsParam, ExcludeKindsParam)
+		}
+	}
+	if excluded[""] {
+		return fmt.Errorf("invalid %v value: kinds must not be empty", ExcludeKindsParam)
+	}
+	return nil
+}
+
+func validateLabelSelector(p *Parameters) error {
+	if valutil.IsDefaultValue(p.LabelSelector) {
+		return nil
+	}
+	if _, err := labels.Parse(p.LabelSelector); err != nil {
+		return fmt.Errorf("invalid %v value of '%v': %v", LabelSelectorParam, p.LabelSelector, err)
+	}
+	return nil
+}
+
+func validateParallelism(p *Parameters) error {
+	if p.Parallelism < 1 {
+		return fmt.Errorf("invalid %v value of '%v': must be at least 1", ParallelismParam, p.Parallelism)
+	}
+	return nil
+}
+
+// validateCheckpoint ensures the checkpoint is only used when resources are written as they are exported: when the
+// output is buffered until the end of the export, a checkpoint would record assets whose resources were never written.
+func validateCheckpoint(p *Parameters) error {
+	if valutil.IsDefaultValue(p.Checkpoint) {
+		return nil
+	}
+	if p.ResolveReferences {
+		return fmt.Errorf("cannot supply both '%v' and '%v': the parameters are mutually exclusive", CheckpointParam, ResolveReferencesParam)
+	}
+	if p.ResourceFormat == commonparams.KustomizeResourceFormatOption {
+		return fmt.Errorf("cannot supply '%v' with the '%v' resource format", CheckpointParam, commonparams.KustomizeResourceFormatOption)
+	}
+	return nil
+}
+
+func validateErrorReport(p *Parameters) error {
+	if valutil.IsDefaultValue(p.ErrorReport) {
+		return nil
+	}
+	switch strings.ToLower(filepath.Ext(p.ErrorReport)) {
+	case ".json", ".yaml", ".yml":
+		return nil
+	default:
+		return fmt.Errorf("invalid %v value of '%v': the file must have a '.json', '.yaml' or '.yml' extension", ErrorReportParam, p.ErrorReport)
+	}
+}
+
+// validateOffline ensures that no parameters which require requests to GCP are used in offline mode.
+func validateOffline(p *Parameters) error {
+	if !p.Offline {
+		if len(p.ProjectIDs) > 0 {
+			return fmt.Errorf("cannot supply '%v' without '%v': project IDs are only looked up by number in GCP when exporting online",
+				ProjectIDsParam, OfflineParam)
+		}
+		return nil
+	}
+	for number := range p.ProjectIDs {
+		if !projectNumberRegex.MatchString(number) {
+			return fmt.Errorf("invalid %v value: '%v' is not a project number", ProjectIDsParam, number)
+		}
+	}
+	for _, exportParam := range []param{
+		{Value: &p.StorageKey, Name: StorageKeyParam},
+		{Value: &p.ProjectId, Name: ProjectIdParam},
+		{Value: &p.FolderId, Name: FolderIdParam},
+		{Value: &p.OrganizationId, Name: OrganizationIdParam},
+	} {
+		if !valutil.IsDefaultValue(exportParam.Value) {
+			return fmt.Errorf("cannot supply '%v' with '%v': an asset inventory cannot be exported offline, supply it on 'stdin' or the '%v' parameter instead",
+				exportParam.Name, OfflineParam, InputParam)
+		}
+	}
+	if p.IAMFormat != commonparams.NoneIAMFormatOption {
+		return fmt.Errorf("invalid %v value of '%v' with '%v': IAM policies cannot be exported offline, the value must be '%v'",
+			commonparams.IAMFormatParamName, p.IAMFormat, OfflineParam, commonparams.NoneIAMFormatOption)
+	}
+	return nil
+}
+
 func validateStorageKey(p *Parameters) error {
 	if valutil.IsDefaultValue(p.StorageKey) {
 		return nil
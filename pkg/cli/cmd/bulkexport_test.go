@@ -657,7 +657,7 @@ func TestBulkExportValidateFlags(t *testing.T) {
 			ResourceFormat: "garbage",
 			Verbose:        "",
 			Stdin:          "value",
-			Error:          "invalid resource-format value of 'garbage': must be one of {krm, hcl}",
+			Error:          "invalid resource-format value of 'garbage': must be one of {krm, hcl, hcl-import, kustomize}",
 		},
 	}
 	defaultParams := bulkExportParams
@@ -700,3 +700,50 @@ func buildArgs(t *testing.T, verbose string, args ...string) []string {
 	}
 	return results
 }
+
+func TestBulkExportValidateProjectIDsFlag(t *testing.T) {
+	testCases := []struct {
+		Name       string
+		Offline    bool
+		IAMFormat  string
+		ProjectIDs map[string]string
+		Error      string
+	}{
+		{
+			Name:       "project ids in offline mode should succeed",
+			Offline:    true,
+			IAMFormat:  "none",
+			ProjectIDs: map[string]string{"123456789": "my-project-id"},
+			Error:      "",
+		},
+		{
+			Name:       "project ids without offline mode should fail",
+			Offline:    false,
+			IAMFormat:  "policy",
+			ProjectIDs: map[string]string{"123456789": "my-project-id"},
+			Error:      "cannot supply 'project-ids' without 'offline': project IDs are only looked up by number in GCP when exporting online",
+		},
+		{
+			Name:       "non-numeric project number should fail",
+			Offline:    true,
+			IAMFormat:  "none",
+			ProjectIDs: map[string]string{"my-project-number": "my-project-id"},
+			Error:      "invalid project-ids value: 'my-project-number' is not a project number",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			params := bulkExportParams
+			params.Input = "/tmp/my-file"
+			params.Offline = tc.Offline
+			params.IAMFormat = tc.IAMFormat
+			params.ProjectIDs = tc.ProjectIDs
+			cleanup, tmpFile := testos.GetStdin(t, "")
+			defer cleanup()
+			err := parameters.Validate(&params, tmpFile)
+			if (err == nil && tc.Error != "") || (err != nil && err.Error() != tc.Error) {
+				t.Fatalf("error string mismatch:\ngot\n\t'%v'\nwant\n\t'%v'", err, tc.Error)
+			}
+		})
+	}
+}
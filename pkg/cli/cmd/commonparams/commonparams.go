@@ -40,8 +40,10 @@ const (
 	PolicyMemberIAMFormatOption = "policymember"
 	NoneIAMFormatOption         = "none"
 
-	KRMResourceFormatOption = outputsink.KRMResourceFormat
-	HCLResourceFormatOption = outputsink.HCLResourceFormat
+	KRMResourceFormatOption       = outputsink.KRMResourceFormat
+	HCLResourceFormatOption       = outputsink.HCLResourceFormat
+	HCLImportResourceFormatOption = outputsink.HCLImportResourceFormat
+	KustomizeResourceFormatOption = outputsink.KustomizeResourceFormat
 
 	IAMFormatDefault               = PolicyIAMFormatOption
 	FilterDeletedIAMMembersDefault = false
@@ -56,7 +58,7 @@ const (
 var (
 	IAMFormatUsage               = fmt.Sprintf("specify the IAM resource format or disable IAM output, options are '%v', '%v', '%v', or '%v'", PartialPolicyFormatOption, PolicyIAMFormatOption, PolicyMemberIAMFormatOption, NoneIAMFormatOption)
 	FilterDeletedIAMMembersUsage = fmt.Sprintf("specify whether to filter out deleted IAM members, options are '%v' or '%v', (default: '%v')", true, false, FilterDeletedIAMMembersDefault)
-	ResourceFormatUsage          = fmt.Sprintf("specify the format of the outputted resources, options are '%v' or '%v' (default: '%v')", KRMResourceFormatOption, HCLResourceFormatOption, ResourceFormatDefault)
+	ResourceFormatUsage          = fmt.Sprintf("specify the format of the outputted resources, options are '%v', '%v', '%v' or '%v' (default: '%v'), '%v' adds a Terraform import block for each resource, '%v' writes a kustomize package to the output directory", KRMResourceFormatOption, HCLResourceFormatOption, HCLImportResourceFormatOption, KustomizeResourceFormatOption, ResourceFormatDefault, HCLImportResourceFormatOption, KustomizeResourceFormatOption)
 )
 
 func AddOAuth2TokenParam(cmd *cobra.Command, value *string) {
@@ -118,7 +120,7 @@ func ValidateResourceFormat(resourceFormat, iamFormat string) error {
 }
 
 func validateResourceFormatValue(value string) error {
-	resourceFormatOptions := []string{KRMResourceFormatOption, HCLResourceFormatOption}
+	resourceFormatOptions := []string{KRMResourceFormatOption, HCLResourceFormatOption, HCLImportResourceFormatOption, KustomizeResourceFormatOption}
 	if valutil.IsDefaultValue(value) {
 		return fmt.Errorf("invalid empty value for %v: must be one of {%v}", ResourceFormatParamName, strings.Join(resourceFormatOptions, ", "))
 	}
@@ -132,10 +134,10 @@ func validateResourceFormatValue(value string) error {
 
 func validateResourceFormatMutualExclusivity(resourceFormat, iamFormat string) error {
 	switch resourceFormat {
-	case HCLResourceFormatOption:
+	case HCLResourceFormatOption, HCLImportResourceFormatOption:
 		return validateHCLResourceFormatMutualExclusivity(resourceFormat, iamFormat)
-	case KRMResourceFormatOption:
-		// all parameters can be used with the KRM format
+	case KRMResourceFormatOption, KustomizeResourceFormatOption:
+		// all parameters can be used with the KRM formats
 		return nil
 	default:
 		return fmt.Errorf("unhandled resource format %v", resourceFormat)
@@ -147,5 +149,5 @@ func validateHCLResourceFormatMutualExclusivity(resourceFormat, iamFormat string
 		return nil
 	}
 	return fmt.Errorf("unsupported value of '%v' for flag '%v': when '%v' is '%v' the '%v' flag must have a value of '%v'",
-		iamFormat, IAMFormatParamName, ResourceFormatParamName, HCLResourceFormatOption, IAMFormatParamName, NoneIAMFormatOption)
+		iamFormat, IAMFormatParamName, ResourceFormatParamName, resourceFormat, IAMFormatParamName, NoneIAMFormatOption)
 }
@@ -39,6 +39,7 @@ func Execute(ctx context.Context, params *parameters.Parameters) error {
 	if err != nil {
 		return err
 	}
+	// closing a sink more than once has no further effect, this only closes the sink when returning early
 	defer outputSink.Close()
 	for bytes, unstructured, err := recoverableStream.Next(ctx); err != io.EOF; bytes, unstructured, err = recoverableStream.Next(ctx) {
 		if err != nil {
@@ -48,5 +49,6 @@ func Execute(ctx context.Context, params *parameters.Parameters) error {
 			return err
 		}
 	}
-	return nil
+	// sinks may buffer their output until they are closed, so errors on close must not be ignored
+	return outputSink.Close()
 }
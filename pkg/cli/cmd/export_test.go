@@ -182,6 +182,33 @@ func TestExportValidateFlags(t *testing.T) {
 			Verbose:        "",
 			Error:          "unsupported value of 'policymember' for flag 'iam-format': when 'resource-format' is 'hcl' the 'iam-format' flag must have a value of 'none'",
 		},
+		{
+			Name:           "resource-format 'hcl-import' with iam-format 'none' should succeed",
+			IAMFormat:      "none",
+			Output:         "",
+			OAuth2Token:    "",
+			ResourceFormat: "hcl-import",
+			Verbose:        "",
+			Error:          "",
+		},
+		{
+			Name:           "resource-format 'hcl-import' with iam-format 'policy' should fail",
+			IAMFormat:      "policy",
+			Output:         "",
+			OAuth2Token:    "",
+			ResourceFormat: "hcl-import",
+			Verbose:        "",
+			Error:          "unsupported value of 'policy' for flag 'iam-format': when 'resource-format' is 'hcl-import' the 'iam-format' flag must have a value of 'none'",
+		},
+		{
+			Name:           "resource-format 'kustomize' with default iam-format should succeed",
+			IAMFormat:      "",
+			Output:         "",
+			OAuth2Token:    "",
+			ResourceFormat: "kustomize",
+			Verbose:        "",
+			Error:          "",
+		},
 	}
 	defaultParams := exportParams
 	for _, tc := range testCases {
@@ -0,0 +1,76 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/commonparams"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/iamexplain"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/iamexplain/parameters"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	iamCommandName        = "iam"
+	iamExplainCommandName = "explain"
+)
+
+var (
+	iamCmd = &cobra.Command{
+		Use:   iamCommandName,
+		Short: "Inspect the IAM resources managed by Config Connector",
+		Long:  `Inspect the IAM resources managed by Config Connector`,
+	}
+	iamExplainParams = parameters.Parameters{}
+	iamExplainCmd    = &cobra.Command{
+		Use:   iamExplainCommandName,
+		Short: "Explain which IAM resources grant the bindings of a resource's IAM policy",
+		Long: `Explain which IAMPolicy, IAMPartialPolicy and IAMPolicyMember objects grant the bindings of a resource's IAM policy.
+
+The bindings granted by the objects referencing the resource are merged and compared with the live IAM policy. Each member
+is reported with one of the following statuses:
+  Managed:   the member is in the live policy and is granted by the listed objects
+  Unmanaged: the member is in the live policy but is not granted by any object
+  Missing:   the member is granted by the listed objects but is not in the live policy
+
+The references of the objects and the resource given with the '--name' or '--external' parameter are resolved to the
+IDs of the resources they point to before being matched, so an object referencing the resource by name is matched when
+the resource is given by external value, and vice versa. Objects whose members can't be resolved, e.g. because of a
+'memberFrom' referencing a missing resource, are listed as unresolved sources after the table.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			iamExplainParams.Verbose = verbose
+			if err := parameters.Validate(&iamExplainParams); err != nil {
+				return err
+			}
+			rootCmd.SilenceUsage = true
+			return iamexplain.Execute(cmd.Context(), &iamExplainParams, os.Stdout)
+		},
+		Args: cobra.NoArgs,
+	}
+)
+
+func init() {
+	iamCmd.AddCommand(iamExplainCmd)
+	commonparams.AddOAuth2TokenParam(iamExplainCmd, &iamExplainParams.OAuth2Token)
+	iamExplainCmd.Flags().StringVar(&iamExplainParams.APIVersion, parameters.APIVersionParam, "", "the API version of the resource, e.g. 'resourcemanager.cnrm.cloud.google.com/v1beta1'")
+	iamExplainCmd.Flags().StringVar(&iamExplainParams.Kind, parameters.KindParam, "", "the kind of the resource, e.g. 'Project'")
+	iamExplainCmd.Flags().StringVar(&iamExplainParams.Name, parameters.NameParam, "", "the name of the Config Connector resource, exclusive with '--external'")
+	iamExplainCmd.Flags().StringVar(&iamExplainParams.Namespace, parameters.NamespaceParam, "", "the namespace of the Config Connector resource, required with '--name'")
+	iamExplainCmd.Flags().StringVar(&iamExplainParams.External, parameters.ExternalParam, "", "the external reference to the resource, e.g. 'projects/my-project', exclusive with '--name'")
+	iamExplainCmd.Flags().StringVar(&iamExplainParams.SourceNamespace, parameters.SourceNamespaceParam, "", "an optional namespace to restrict the IAM resources considered to, all namespaces are considered by default")
+	iamExplainCmd.Flags().StringVar(&iamExplainParams.Kubeconfig, parameters.KubeconfigParam, "", "an optional path to the kubeconfig file, the default loading rules of kubectl are used if empty")
+}
@@ -0,0 +1,184 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamexplain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	iamv1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/iamexplain/parameters"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/serviceclient"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/tf"
+	kcciamclient "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/iamclient"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/partialpolicy"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/clientconfig"
+	dclconversion "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/conversion"
+	dclmetadata "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/schema/dclschemaloader"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/gcp"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/servicemapping/servicemappingloader"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ManagedStatus is the status of a member of the live policy granted by at least one IAM resource.
+	ManagedStatus = "Managed"
+	// UnmanagedStatus is the status of a member of the live policy granted by no IAM resource.
+	UnmanagedStatus = "Unmanaged"
+	// MissingStatus is the status of a member granted by IAM resources but missing from the live policy.
+	MissingStatus = "Missing"
+)
+
+func Execute(ctx context.Context, params *parameters.Parameters, output io.Writer) error {
+	kubeClient, err := newKubeClient(params.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	iamClient, err := newIAMClient(ctx, params.OAuth2Token, kubeClient)
+	if err != nil {
+		return err
+	}
+	resourceRef := iamv1beta1.ResourceReference{
+		APIVersion: params.APIVersion,
+		Kind:       params.Kind,
+		Name:       params.Name,
+		Namespace:  params.Namespace,
+		External:   params.External,
+	}
+	resolver := &partialpolicy.IAMMemberIdentityResolver{Iamclient: iamClient, Ctx: ctx}
+	sources, err := partialpolicy.ListPolicySources(ctx, kubeClient, iamClient, resourceRef, params.Namespace, params.SourceNamespace, resolver)
+	if err != nil {
+		return err
+	}
+	livePolicy, err := iamClient.GetPolicy(ctx, newPolicySkeleton(resourceRef, params.Namespace))
+	if err != nil {
+		return fmt.Errorf("error getting the live IAM policy: %w", err)
+	}
+	live, missing := partialpolicy.ComputeBindingSources(livePolicy.Spec.Bindings, sources)
+	return render(live, missing, sources, output)
+}
+
+// render writes the given live and missing members as a table, followed by the sources whose bindings
+// couldn't be resolved, as the members they grant may be reported as unmanaged.
+func render(live, missing []iamv1beta1.IAMBindingSource, sources []partialpolicy.PolicySource, output io.Writer) error {
+	w := tabwriter.NewWriter(output, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ROLE\tCONDITION\tMEMBER\tSTATUS\tSOURCES")
+	for _, b := range live {
+		status := ManagedStatus
+		if len(b.Sources) == 0 {
+			status = UnmanagedStatus
+		}
+		writeRow(w, b, status)
+	}
+	for _, b := range missing {
+		writeRow(w, b, MissingStatus)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, s := range sources {
+		if s.Err != nil {
+			if _, err := fmt.Fprintf(output, "Unresolved source %v: %v\n", s.ID(), s.Err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeRow(w io.Writer, b iamv1beta1.IAMBindingSource, status string) {
+	condition := "-"
+	if b.Condition != nil {
+		condition = b.Condition.Title
+	}
+	sources := "-"
+	if len(b.Sources) > 0 {
+		sources = strings.Join(b.Sources, ",")
+	}
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", b.Role, condition, b.Member, status, sources)
+}
+
+func newPolicySkeleton(resourceRef iamv1beta1.ResourceReference, namespace string) *iamv1beta1.IAMPolicy {
+	return &iamv1beta1.IAMPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       iamv1beta1.IAMPolicyGVK.Kind,
+			APIVersion: iamv1beta1.IAMAPIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+		},
+		Spec: iamv1beta1.IAMPolicySpec{
+			ResourceReference: resourceRef,
+		},
+	}
+}
+
+func newKubeClient(kubeconfig string) (client.Client, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error loading kubeconfig: %w", err)
+	}
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("error adding client-go types to the scheme: %w", err)
+	}
+	if err := iamv1beta1.SchemeBuilder.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("error adding IAM types to the scheme: %w", err)
+	}
+	kubeClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("error creating kubernetes client: %w", err)
+	}
+	return kubeClient, nil
+}
+
+func newIAMClient(ctx context.Context, oauth2Token string, kubeClient client.Client) (*kcciamclient.IAMClient, error) {
+	tfProvider, err := tf.NewProvider(oauth2Token)
+	if err != nil {
+		return nil, err
+	}
+	smLoader, err := servicemappingloader.New()
+	if err != nil {
+		return nil, fmt.Errorf("error loading service mappings: %w", err)
+	}
+	dclSchemaLoader, err := dclschemaloader.New()
+	if err != nil {
+		return nil, fmt.Errorf("error creating a DCL schema loader: %w", err)
+	}
+	converter := dclconversion.New(dclSchemaLoader, dclmetadata.New())
+	httpClient, err := serviceclient.NewHTTPClient(ctx, oauth2Token)
+	if err != nil {
+		return nil, err
+	}
+	dclConfig, err := clientconfig.New(ctx, clientconfig.Options{
+		HTTPClient: httpClient,
+		UserAgent:  gcp.KCCUserAgent,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating a DCL client config: %w", err)
+	}
+	return kcciamclient.New(tfProvider, smLoader, kubeClient, converter, dclConfig), nil
+}
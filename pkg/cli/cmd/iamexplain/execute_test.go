@@ -0,0 +1,63 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamexplain
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	iamv1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/partialpolicy"
+)
+
+func TestRender(t *testing.T) {
+	live := []iamv1beta1.IAMBindingSource{
+		{
+			Role:    "roles/editor",
+			Member:  "user:foo@example.com",
+			Sources: []string{"IAMPartialPolicy/foo-ns/partial", "IAMPolicyMember/foo-ns/member"},
+		},
+		{
+			Role:   "roles/owner",
+			Member: "user:admin@example.com",
+		},
+	}
+	missing := []iamv1beta1.IAMBindingSource{
+		{
+			Role:      "roles/viewer",
+			Condition: &iamv1beta1.IAMCondition{Title: "expires", Expression: "request.time < timestamp(\"2020-01-01T00:00:00Z\")"},
+			Member:    "user:bar@example.com",
+			Sources:   []string{"IAMPolicyMember/foo-ns/bar"},
+		},
+	}
+	sources := []partialpolicy.PolicySource{
+		{Kind: "IAMPolicyMember", Namespace: "foo-ns", Name: "member"},
+		{Kind: "IAMPolicyMember", Namespace: "foo-ns", Name: "admin", Err: errors.New("error resolving the member: not found")},
+	}
+	expected := `ROLE          CONDITION  MEMBER                  STATUS     SOURCES
+roles/editor  -          user:foo@example.com    Managed    IAMPartialPolicy/foo-ns/partial,IAMPolicyMember/foo-ns/member
+roles/owner   -          user:admin@example.com  Unmanaged  -
+roles/viewer  expires    user:bar@example.com    Missing    IAMPolicyMember/foo-ns/bar
+Unresolved source IAMPolicyMember/foo-ns/admin: error resolving the member: not found
+`
+	var out bytes.Buffer
+	if err := render(live, missing, sources, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := out.String(); got != expected {
+		t.Fatalf("unexpected output:\n%v\nwant:\n%v", got, expected)
+	}
+}
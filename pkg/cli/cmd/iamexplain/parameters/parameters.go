@@ -0,0 +1,64 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters
+
+import (
+	"fmt"
+)
+
+const (
+	APIVersionParam      = "api-version"
+	KindParam            = "kind"
+	NameParam            = "name"
+	NamespaceParam       = "namespace"
+	ExternalParam        = "external"
+	KubeconfigParam      = "kubeconfig"
+	SourceNamespaceParam = "source-namespace"
+)
+
+type Parameters struct {
+	// The reference to the target resource, in the same form as the 'resourceRef' field of IAM resources
+	APIVersion string
+	Kind       string
+	Name       string
+	Namespace  string
+	External   string
+
+	// SourceNamespace restricts the IAM resources considered to the given namespace, all namespaces are
+	// considered if empty
+	SourceNamespace string
+	Kubeconfig      string
+	OAuth2Token     string
+	Verbose         bool
+}
+
+func Validate(p *Parameters) error {
+	if p.APIVersion == "" {
+		return fmt.Errorf("'%v' parameter can not be empty", APIVersionParam)
+	}
+	if p.Kind == "" {
+		return fmt.Errorf("'%v' parameter can not be empty", KindParam)
+	}
+	if p.Name == "" && p.External == "" {
+		return fmt.Errorf("one of the '%v' or '%v' parameters must be set", NameParam, ExternalParam)
+	}
+	if p.Name != "" && p.External != "" {
+		return fmt.Errorf("only one of the '%v' or '%v' parameters can be set", NameParam, ExternalParam)
+	}
+	if p.Name != "" && p.Namespace == "" {
+		return fmt.Errorf("'%v' parameter can not be empty when '%v' is set", NamespaceParam, NameParam)
+	}
+	return nil
+}
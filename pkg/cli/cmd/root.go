@@ -57,6 +57,7 @@ func init() {
 	rootCmd.AddCommand(printResourcesCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(iamCmd)
 	rootCmd.SilenceErrors = true
 }
 
@@ -31,18 +31,46 @@ import (
 )
 
 func UnstructuredToHCL(ctx context.Context, u *unstructured.Unstructured, smLoader *servicemappingloader.ServiceMappingLoader, tfProvider *schema.Provider) (string, error) {
+	hcl, address, importId, err := unstructuredToHCL(ctx, u, smLoader, tfProvider)
+	if err != nil {
+		return "", err
+	}
+	// append a comment with terraform import command for two reasons:
+	// 1. A human reading the output could use the value to perform an import
+	// 2. gcloud is looking for this output and printing it out for their users
+	//
+	// any changes to the format of this output should be communicated to the gcloud team
+	hcl = fmt.Sprintf("%v# terraform import %v %v\n", hcl, address, importId)
+	return hcl, nil
+}
+
+// UnstructuredToHCLWithImportBlock is like UnstructuredToHCL, but it follows the resource block with a
+// Terraform 'import' block instead of a comment. This allows the exported configuration to be adopted
+// by Terraform with a 'terraform plan' / 'terraform apply', without recreating the underlying resource.
+func UnstructuredToHCLWithImportBlock(ctx context.Context, u *unstructured.Unstructured, smLoader *servicemappingloader.ServiceMappingLoader, tfProvider *schema.Provider) (string, error) {
+	hcl, address, importId, err := unstructuredToHCL(ctx, u, smLoader, tfProvider)
+	if err != nil {
+		return "", err
+	}
+	hcl = fmt.Sprintf("%v\nimport {\n  to = %v\n  id = %v\n}\n", hcl, address, quoteHCLString(importId))
+	return hcl, nil
+}
+
+// unstructuredToHCL returns the HCL resource block for the given resource along with the resource's
+// Terraform address (e.g. 'google_pubsub_topic.my_topic') and import ID.
+func unstructuredToHCL(ctx context.Context, u *unstructured.Unstructured, smLoader *servicemappingloader.ServiceMappingLoader, tfProvider *schema.Provider) (hcl, address, importId string, err error) {
 	gvk := u.GroupVersionKind()
 	sm, err := smLoader.GetServiceMapping(u.GroupVersionKind().Group)
 	if err != nil {
-		return "", err
+		return "", "", "", err
 	}
 	krmResource, err := krmtotf.NewResource(u, sm, tfProvider)
 	if err != nil {
-		return "", fmt.Errorf("could not parse resource %s: %v", u.GetName(), err)
+		return "", "", "", fmt.Errorf("could not parse resource %s: %v", u.GetName(), err)
 	}
 	config, _, err := krmtotf.KRMResourceToTFResourceConfigFull(krmResource, k8s.NewErroringClient(), smLoader, nil, nil, true, map[string]string{})
 	if err != nil {
-		return "", fmt.Errorf("error expanding resource configuration: %v", err)
+		return "", "", "", fmt.Errorf("error expanding resource configuration: %v", err)
 	}
 	configAsMap := krmtotf.ResourceConfigToMap(config)
 	tfResource := krmResource.TFResource
@@ -62,25 +90,20 @@ func UnstructuredToHCL(ctx context.Context, u *unstructured.Unstructured, smLoad
 	}
 
 	if err := resourceoverrides.Handler.PreTerraformExport(ctx, gvk, exportOp); err != nil {
-		return "", err
+		return "", "", "", err
 	}
 
-	hcl, err := serialization.InstanceStateToHCL(exportOp.TerraformState, exportOp.TerraformInfo, tfProvider)
+	hcl, err = serialization.InstanceStateToHCL(exportOp.TerraformState, exportOp.TerraformInfo, tfProvider)
 	if err != nil {
-		return "", fmt.Errorf("error generating hcl: %w", err)
+		return "", "", "", fmt.Errorf("error generating hcl: %w", err)
 	}
 
-	importId, err := krmResource.GetImportID(k8s.NewErroringClient(), smLoader)
+	importId, err = krmResource.GetImportID(k8s.NewErroringClient(), smLoader)
 	if err != nil {
-		return "", fmt.Errorf("error getting import id for '%v': %w", krmResource.GetName(), err)
+		return "", "", "", fmt.Errorf("error getting import id for '%v': %w", krmResource.GetName(), err)
 	}
-	// append a comment with terraform import command for two reasons:
-	// 1. A human reading the output could use the value to perform an import
-	// 2. gcloud is looking for this output and printing it out for their users
-	//
-	// any changes to the format of this output should be communicated to the gcloud team
-	hcl = fmt.Sprintf("%v# terraform import %v.%v %v\n", hcl, exportOp.TerraformInfo.Type, krmResource.TFInfo.Id, importId)
-	return hcl, nil
+	address = fmt.Sprintf("%v.%v", exportOp.TerraformInfo.Type, krmResource.TFInfo.Id)
+	return hcl, address, importId, nil
 }
 
 // removingConflictingFields removes values that conflict with each other
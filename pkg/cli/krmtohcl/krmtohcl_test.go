@@ -55,7 +55,29 @@ func TestUnstructuredToHCL(t *testing.T) {
 	}
 }
 
+func TestUnstructuredToHCLWithImportBlock(t *testing.T) {
+	smLoader := testservicemappingloader.New(t)
+	tfProvider := tfprovider.NewOrLogFatal(tfprovider.DefaultConfig)
+	testDir := "testdata"
+
+	testCases := FindTestCases(t, testDir, ".golden.import.tf")
+	for _, testCase := range testCases {
+		t.Run(testCase, func(t *testing.T) {
+			krmFile := testCase + ".yaml"
+			goldenHCLFile := testCase + ".golden.import.tf"
+
+			testUnstructuredToHCLWithConverter(t, krmFile, goldenHCLFile, smLoader, tfProvider, krmtohcl.UnstructuredToHCLWithImportBlock)
+		})
+	}
+}
+
 func testUnstructuredToHCL(t *testing.T, krmFile, goldenHCLFile string, smLoader *servicemappingloader.ServiceMappingLoader, tfProvider *schema.Provider) {
+	testUnstructuredToHCLWithConverter(t, krmFile, goldenHCLFile, smLoader, tfProvider, krmtohcl.UnstructuredToHCL)
+}
+
+type converterFunc func(context.Context, *unstructured.Unstructured, *servicemappingloader.ServiceMappingLoader, *schema.Provider) (string, error)
+
+func testUnstructuredToHCLWithConverter(t *testing.T, krmFile, goldenHCLFile string, smLoader *servicemappingloader.ServiceMappingLoader, tfProvider *schema.Provider, convert converterFunc) {
 	ctx := context.TODO()
 
 	var u unstructured.Unstructured
@@ -64,7 +86,7 @@ func testUnstructuredToHCL(t *testing.T, krmFile, goldenHCLFile string, smLoader
 	labels := u.GetLabels()
 	delete(labels, "managed-by-cnrm")
 	u.SetLabels(labels)
-	hcl, err := krmtohcl.UnstructuredToHCL(ctx, &u, smLoader, tfProvider)
+	hcl, err := convert(ctx, &u, smLoader, tfProvider)
 	if err != nil {
 		t.Fatalf("error converting unstructured to HCL: %v", err)
 	}
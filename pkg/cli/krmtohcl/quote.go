@@ -0,0 +1,54 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package krmtohcl
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// quoteHCLString returns the given value as a quoted HCL string literal. HCL only supports the '\n', '\r', '\t',
+// '\"', '\\', '\uNNNN' and '\UNNNNNNNN' escape sequences, so Go's strconv.Quote() can't be used: it also emits
+// escapes such as '\x00' and '\a'. Template sequences ('${' and '%{') are escaped as '$${' and '%%{'.
+func quoteHCLString(value string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i, r := range value {
+		switch {
+		case r == '"':
+			sb.WriteString(`\"`)
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(value[i+1:], "{"):
+			sb.WriteRune(r)
+			sb.WriteRune(r)
+		case r <= 0xFFFF && !unicode.IsPrint(r):
+			// control and other non-printable characters; those outside of the Basic Multilingual Plane are
+			// valid in HCL source and are kept as is
+			sb.WriteString(fmt.Sprintf(`\u%04x`, r))
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
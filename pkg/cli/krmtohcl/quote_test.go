@@ -0,0 +1,70 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package krmtohcl
+
+import (
+	"testing"
+)
+
+func TestQuoteHCLString(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     "plain string",
+			value:    "projects/my-project/topics/my-topic",
+			expected: `"projects/my-project/topics/my-topic"`,
+		},
+		{
+			name:     "quotes and backslashes",
+			value:    `say "hi" \ bye`,
+			expected: `"say \"hi\" \\ bye"`,
+		},
+		{
+			name:     "whitespace escapes",
+			value:    "a\nb\rc\td",
+			expected: `"a\nb\rc\td"`,
+		},
+		{
+			name:     "control characters use unicode escapes",
+			value:    "a\x00b\ac\x1bd\u0085e",
+			expected: `"a\u0000b\u0007c\u001bd\u0085e"`,
+		},
+		{
+			name:     "non-printable characters use unicode escapes",
+			value:    "a\u200bb\ufeffc",
+			expected: `"a\u200bb\ufeffc"`,
+		},
+		{
+			name:     "printable unicode characters are kept",
+			value:    "café ☕ 𝄞",
+			expected: `"café ☕ 𝄞"`,
+		},
+		{
+			name:     "template sequences are escaped",
+			value:    "${var.foo} %{if true} $ % {",
+			expected: `"$${var.foo} %%{if true} $ % {"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := quoteHCLString(tc.value); got != tc.expected {
+				t.Errorf("got %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package offline converts the resource data in a Cloud Asset Inventory export to KRM without making any requests to
+// GCP.
+package offline
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/execution"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/krmtotf"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/servicemapping/servicemappingloader"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/text"
+
+	tfschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// NewUnstructuredFromAsset returns the resource for the asset, taking the place of a GET from GCP. 'skel' is the
+// skeleton of the resource, see resourceskeleton.NewFromAsset(...).
+//
+// The asset's 'resource.data' is in the format of the GCP API, whose fields are matched to the resource's TF schema
+// by name, e.g. the 'messageRetentionDuration' field of a Pub/Sub topic is the value of the TF field
+// 'message_retention_duration'. Fields which do not have a TF field of the same name, or whose value does not fit the
+// TF field's type, are dropped, so the result is a best effort which may be less complete than that of a GET.
+func NewUnstructuredFromAsset(ctx context.Context, a *asset.Asset, skel *unstructured.Unstructured, smLoader *servicemappingloader.ServiceMappingLoader, tfProvider *tfschema.Provider) (u *unstructured.Unstructured, err error) {
+	// the krmtotf conversion functions panic on values that do not match the schema
+	defer execution.RecoverWithGenericError(&err)
+	if a.Resource == nil || a.Resource.Data == nil {
+		return nil, fmt.Errorf("asset '%v' has no resource data: the asset inventory must be exported with the 'RESOURCE' content type", a.Name)
+	}
+	sm, err := smLoader.GetServiceMapping(skel.GroupVersionKind().Group)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := krmtotf.NewResource(skel, sm, tfProvider)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse resource %s: %v", skel.GetName(), err)
+	}
+	id, err := resource.GetImportID(k8s.NewErroringClient(), smLoader)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ID for resource: %v", err)
+	}
+	// importing only parses the ID, it does not make any requests to GCP
+	imported, err := krmtotf.ImportState(ctx, id, resource.TFInfo, tfProvider)
+	if err != nil {
+		return nil, err
+	}
+	state := krmtotf.InstanceStateToMap(resource.TFResource, imported)
+	// the values parsed from the ID take precedence as the API returns some of them in a different form, e.g. a
+	// Pub/Sub topic's 'name' is 'projects/{{project}}/topics/{{name}}' in the API but '{{name}}' in TF
+	for k, v := range dataToTFObject(a.Resource.Data, resource.TFResource.Schema) {
+		if isEmpty(state[k]) {
+			state[k] = v
+		}
+	}
+	instanceState := krmtotf.MapToInstanceState(resource.TFResource, state)
+	instanceState.ID = imported.ID
+	resource.Name = krmtotf.GetNameFromState(resource, instanceState)
+	resource.Labels = krmtotf.GetLabelsFromState(resource, instanceState)
+	resource.Annotations = krmtotf.GetAnnotationsFromState(resource, instanceState)
+	resource.Spec, resource.Status = krmtotf.ResolveSpecAndStatusWithResourceID(resource, instanceState)
+	return resource.MarshalAsUnstructured()
+}
+
+// dataToTFObject converts an object from the GCP API to a TF object with the given schema.
+func dataToTFObject(data map[string]interface{}, schemas map[string]*tfschema.Schema) map[string]interface{} {
+	obj := make(map[string]interface{})
+	for tfKey, schema := range schemas {
+		val, ok := data[text.SnakeCaseToLowerCamelCase(tfKey)]
+		if !ok {
+			continue
+		}
+		if converted, ok := dataToTFValue(val, schema); ok {
+			obj[tfKey] = converted
+		}
+	}
+	return obj
+}
+
+func dataToTFValue(val interface{}, schema *tfschema.Schema) (interface{}, bool) {
+	switch schema.Type {
+	case tfschema.TypeString:
+		switch v := val.(type) {
+		case string:
+			return v, true
+		case float64, bool:
+			return fmt.Sprint(v), true
+		}
+	case tfschema.TypeInt, tfschema.TypeFloat:
+		switch v := val.(type) {
+		case float64:
+			return v, true
+		case string:
+			// the API represents 64-bit integers as strings
+			f, err := strconv.ParseFloat(v, 64)
+			return f, err == nil
+		}
+	case tfschema.TypeBool:
+		switch v := val.(type) {
+		case bool:
+			return v, true
+		case string:
+			b, err := strconv.ParseBool(v)
+			return b, err == nil
+		}
+	case tfschema.TypeMap:
+		m, ok := val.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		elemSchema, ok := schema.Elem.(*tfschema.Schema)
+		if !ok {
+			elemSchema = &tfschema.Schema{Type: tfschema.TypeString}
+		}
+		result := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			converted, ok := dataToTFValue(v, elemSchema)
+			if !ok {
+				return nil, false
+			}
+			result[k] = converted
+		}
+		return result, true
+	case tfschema.TypeList, tfschema.TypeSet:
+		return dataToTFList(val, schema)
+	}
+	return nil, false
+}
+
+// dataToTFList converts a list, or a single object which TF represents as a list with at most one item, from the GCP
+// API to a TF list.
+func dataToTFList(val interface{}, schema *tfschema.Schema) (interface{}, bool) {
+	var items []interface{}
+	switch v := val.(type) {
+	case []interface{}:
+		items = v
+	case map[string]interface{}:
+		items = []interface{}{v}
+	default:
+		return nil, false
+	}
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		switch elem := schema.Elem.(type) {
+		case *tfschema.Resource:
+			obj, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			result = append(result, dataToTFObject(obj, elem.Schema))
+		case *tfschema.Schema:
+			converted, ok := dataToTFValue(item, elem)
+			if !ok {
+				return nil, false
+			}
+			result = append(result, converted)
+		default:
+			return nil, false
+		}
+	}
+	return result, true
+}
+
+func isEmpty(val interface{}) bool {
+	switch v := val.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/offline"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/serviceclient"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/tf"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/resourceskeleton"
+	testservicemappingloader "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/test/servicemappingloader"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewUnstructuredFromAsset(t *testing.T) {
+	smLoader := testservicemappingloader.New(t)
+	// an offline provider is used to verify that no credentials are required
+	tfProvider, err := tf.NewOfflineProvider()
+	if err != nil {
+		t.Fatalf("error creating provider: %v", err)
+	}
+	a := &asset.Asset{
+		Name:      "//pubsub.googleapis.com/projects/my-project/topics/my-topic",
+		AssetType: "pubsub.googleapis.com/Topic",
+		Ancestors: []string{"projects/1234567890"},
+		Resource: &asset.Resource{
+			Data: map[string]interface{}{
+				"name": "projects/my-project/topics/my-topic",
+				"labels": map[string]interface{}{
+					"env": "prod",
+				},
+				"kmsKeyName":               "projects/my-project/locations/global/keyRings/my-ring/cryptoKeys/my-key",
+				"messageRetentionDuration": "86400s",
+				"messageStoragePolicy": map[string]interface{}{
+					"allowedPersistenceRegions": []interface{}{"us-central1", "us-east1"},
+				},
+				"unknownField": "dropped",
+			},
+		},
+	}
+	skel, err := resourceskeleton.NewFromAsset(a, smLoader, tfProvider, serviceclient.NewOfflineServiceClient(nil))
+	if err != nil {
+		t.Fatalf("error creating skeleton: %v", err)
+	}
+	u, err := offline.NewUnstructuredFromAsset(context.TODO(), a, skel, smLoader, tfProvider)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.GetKind() != "PubSubTopic" || u.GetName() != "my-topic" {
+		t.Errorf("got %v '%v', want PubSubTopic 'my-topic'", u.GetKind(), u.GetName())
+	}
+	if diff := cmp.Diff(map[string]string{"env": "prod"}, u.GetLabels()); diff != "" {
+		t.Errorf("unexpected labels diff (-want +got):\n%v", diff)
+	}
+	expectedSpec := map[string]interface{}{
+		"kmsKeyRef": map[string]interface{}{
+			"external": "projects/my-project/locations/global/keyRings/my-ring/cryptoKeys/my-key",
+		},
+		"messageRetentionDuration": "86400s",
+		"messageStoragePolicy": map[string]interface{}{
+			"allowedPersistenceRegions": []interface{}{"us-central1", "us-east1"},
+		},
+		"resourceID": "my-topic",
+	}
+	if diff := cmp.Diff(expectedSpec, u.Object["spec"]); diff != "" {
+		t.Errorf("unexpected spec diff (-want +got):\n%v", diff)
+	}
+}
+
+func TestNewUnstructuredFromAssetWithoutResourceData(t *testing.T) {
+	smLoader := testservicemappingloader.New(t)
+	tfProvider, err := tf.NewOfflineProvider()
+	if err != nil {
+		t.Fatalf("error creating provider: %v", err)
+	}
+	a := &asset.Asset{
+		Name:      "//pubsub.googleapis.com/projects/my-project/topics/my-topic",
+		AssetType: "pubsub.googleapis.com/Topic",
+	}
+	skel, err := resourceskeleton.NewFromAsset(a, smLoader, tfProvider, serviceclient.NewOfflineServiceClient(nil))
+	if err != nil {
+		t.Fatalf("error creating skeleton: %v", err)
+	}
+	if _, err := offline.NewUnstructuredFromAsset(context.TODO(), a, skel, smLoader, tfProvider); err == nil {
+		t.Fatalf("got nil error for an asset without resource data, want an error")
+	}
+}
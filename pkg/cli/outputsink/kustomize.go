@@ -0,0 +1,265 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outputsink
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/referencerewriter"
+	dclmetadata "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/schema/dclschemaloader"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/servicemapping/servicemappingloader"
+
+	"github.com/ghodss/yaml"
+	"github.com/gosimple/slug"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	kustomizationFileName = "kustomization.yaml"
+	kptfileFileName       = "Kptfile"
+)
+
+// KustomizeDirectorySink writes the received resources out as a kustomize (and kpt) package. Resources are grouped
+// in a directory per project (or folder or organization) and a sub-directory per service, each of which contains a
+// kustomization.yaml listing its contents. 'external' references to resources that are part of the same package are
+// rewritten to 'name' references.
+//
+// Each resource is written to its file as it is received. As references can only be rewritten once all the resources
+// are known, the files are rewritten and the kustomization.yaml files are written when Close() is called. Only the
+// file names and the identifiers of the resources are kept in memory.
+type KustomizeDirectorySink struct {
+	dir      string
+	rewriter *referencerewriter.Rewriter
+	// kustomization file contents keyed by the directory they are in, relative to the package root
+	kustomizations map[string][]string
+	// the files of the received resources, relative to the package root
+	resourceFiles []string
+	// the file names used in each directory, relative to the package root, so that unique names are found without
+	// scanning the directory's kustomization
+	fileNames map[string]map[string]bool
+}
+
+// NewKustomizeDirectory returns a KustomizeDirectorySink which writes a package to the given directory.
+func NewKustomizeDirectory(dir string) OutputSink {
+	return &KustomizeDirectorySink{
+		dir: dir,
+	}
+}
+
+func (ks *KustomizeDirectorySink) Receive(ctx context.Context, bytes []byte, u *unstructured.Unstructured) error {
+	if isYAMLTerminator(bytes) || u == nil {
+		return nil
+	}
+	if ks.rewriter == nil {
+		rewriter, err := newReferenceRewriter()
+		if err != nil {
+			return err
+		}
+		ks.rewriter = rewriter
+		ks.kustomizations = map[string][]string{"": nil}
+		ks.fileNames = make(map[string]map[string]bool)
+	}
+	ks.rewriter.Add(u)
+	// the directory is chosen before rewriting as the parent of a resource may be determined by its references
+	dir := path.Join(slug.Make(getPackageParentDir(u)), slug.Make(getPackageServiceDir(u)))
+	if ks.fileNames[dir] == nil {
+		ks.fileNames[dir] = make(map[string]bool)
+	}
+	fileName := getUniqueFileName(ks.fileNames[dir], fmt.Sprintf("%v-%v", strings.ToLower(u.GetKind()), slug.Make(u.GetName())))
+	if err := writeResource(filepath.Join(ks.dir, dir, fileName), u); err != nil {
+		return err
+	}
+	addKustomizationResource(ks.kustomizations, dir, fileName)
+	ks.resourceFiles = append(ks.resourceFiles, path.Join(dir, fileName))
+	return nil
+}
+
+// Close rewrites the references of the written resources and writes the kustomization.yaml files and the Kptfile.
+// Calling Close more than once has no further effect.
+func (ks *KustomizeDirectorySink) Close() error {
+	if ks.rewriter == nil {
+		return nil
+	}
+	for _, resourceFile := range ks.resourceFiles {
+		if err := ks.rewriteFile(filepath.Join(ks.dir, resourceFile)); err != nil {
+			return err
+		}
+	}
+	for dir, resources := range ks.kustomizations {
+		if err := writeKustomization(filepath.Join(ks.dir, dir), resources); err != nil {
+			return err
+		}
+	}
+	if err := writeKptfile(ks.dir); err != nil {
+		return err
+	}
+	ks.rewriter = nil
+	ks.kustomizations = nil
+	ks.resourceFiles = nil
+	ks.fileNames = nil
+	return nil
+}
+
+// rewriteFile reads the resource in the given file back and writes it again if any of its references were rewritten.
+func (ks *KustomizeDirectorySink) rewriteFile(filePath string) error {
+	bytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("error reading '%v': %w", filePath, err)
+	}
+	u := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(bytes, &u.Object); err != nil {
+		return fmt.Errorf("error unmarshalling '%v': %w", filePath, err)
+	}
+	if !ks.rewriter.Rewrite(u) {
+		return nil
+	}
+	return writeResource(filePath, u)
+}
+
+func newReferenceRewriter() (*referencerewriter.Rewriter, error) {
+	smLoader, err := servicemappingloader.New()
+	if err != nil {
+		return nil, fmt.Errorf("error creating service mapping loader: %w", err)
+	}
+	dclSchemaLoader, err := dclschemaloader.New()
+	if err != nil {
+		return nil, fmt.Errorf("error creating DCL schema loader: %w", err)
+	}
+	return referencerewriter.New(smLoader, dclmetadata.New(), dclSchemaLoader), nil
+}
+
+func writeResource(filePath string, u *unstructured.Unstructured) error {
+	bytes, err := yaml.Marshal(u.Object)
+	if err != nil {
+		return fmt.Errorf("error marshalling %v '%v' to YAML: %w", u.GetKind(), u.GetName(), err)
+	}
+	return writeFile(filePath, bytes)
+}
+
+// addKustomizationResource adds the given resource to the kustomization in the given directory, and adds the
+// directory to the kustomizations of all its parents.
+func addKustomizationResource(kustomizations map[string][]string, dir, resource string) {
+	for {
+		resources, ok := kustomizations[dir]
+		kustomizations[dir] = append(resources, resource)
+		if ok || dir == "" {
+			return
+		}
+		resource = path.Base(dir)
+		dir = path.Dir(dir)
+		if dir == "." {
+			dir = ""
+		}
+	}
+}
+
+// getUniqueFileName returns a file name for the given base name that is not in the given set of used names, and adds
+// it to the set.
+func getUniqueFileName(used map[string]bool, baseName string) string {
+	fileName := baseName + ".yaml"
+	for i := 2; used[fileName]; i++ {
+		fileName = fmt.Sprintf("%v-%v.yaml", baseName, i)
+	}
+	used[fileName] = true
+	return fileName
+}
+
+// getPackageParentDir returns the name of the directory for the project, folder or organization of the resource.
+func getPackageParentDir(u *unstructured.Unstructured) string {
+	if u.GetKind() == "Project" {
+		if id, ok, _ := unstructured.NestedString(u.Object, strings.Split(k8s.ResourceIDFieldPath, ".")...); ok {
+			return id
+		}
+		return u.GetName()
+	}
+	for _, annotation := range []string{k8s.ProjectIDAnnotation, k8s.FolderIDAnnotation, k8s.OrgIDAnnotation} {
+		if val, ok := k8s.GetAnnotation(annotation, u); ok {
+			return val
+		}
+	}
+	for _, refField := range []string{"projectRef", "folderRef", "organizationRef", "resourceRef"} {
+		if external, ok, _ := unstructured.NestedString(u.Object, "spec", refField, "external"); ok && external != "" {
+			splits := strings.Split(strings.Trim(external, "/"), "/")
+			if len(splits) >= 2 && (splits[0] == "projects" || splits[0] == "folders" || splits[0] == "organizations") {
+				return splits[1]
+			}
+			if refField != "resourceRef" {
+				return splits[0]
+			}
+		}
+	}
+	return "global"
+}
+
+// getPackageServiceDir returns the name of the directory for the service of the resource, e.g. 'pubsub' for
+// PubSubTopic.
+func getPackageServiceDir(u *unstructured.Unstructured) string {
+	return strings.TrimSuffix(u.GroupVersionKind().Group, "."+k8s.CNRMGroup)
+}
+
+func writeKustomization(dir string, resources []string) error {
+	sort.Strings(resources)
+	kustomization := map[string]interface{}{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  resources,
+	}
+	bytes, err := yaml.Marshal(kustomization)
+	if err != nil {
+		return fmt.Errorf("error marshalling kustomization for '%v': %w", dir, err)
+	}
+	return writeFile(filepath.Join(dir, kustomizationFileName), bytes)
+}
+
+func writeKptfile(dir string) error {
+	kptfile := map[string]interface{}{
+		"apiVersion": "kpt.dev/v1",
+		"kind":       "Kptfile",
+		"metadata": map[string]interface{}{
+			"name": slug.Make(filepath.Base(filepath.Clean(dir))),
+			"annotations": map[string]interface{}{
+				"config.kubernetes.io/local-config": "true",
+			},
+		},
+		"info": map[string]interface{}{
+			"description": "Config Connector resources exported by the config-connector CLI",
+		},
+	}
+	bytes, err := yaml.Marshal(kptfile)
+	if err != nil {
+		return fmt.Errorf("error marshalling Kptfile: %w", err)
+	}
+	return writeFile(filepath.Join(dir, kptfileFileName), bytes)
+}
+
+func writeFile(filePath string, bytes []byte) error {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error ensuring parent path '%v' exists: %w", dir, err)
+	}
+	if err := ioutil.WriteFile(filePath, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing bytes to '%v': %w", filePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outputsink_test
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/outputsink"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestKustomizeDirectorySink(t *testing.T) {
+	ctx := context.TODO()
+	tmpDir, cleanup := newTmpDir(t)
+	defer cleanup()
+	sink := outputsink.NewKustomizeDirectory(tmpDir)
+	resources := []*unstructured.Unstructured{
+		unstructuredFromYamlFile(t, "pubsubtopic-project1.yaml"),
+		unstructuredFromYamlFile(t, "storagebucket.yaml"),
+		{
+			Object: map[string]interface{}{
+				"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1",
+				"kind":       "PubSubSubscription",
+				"metadata": map[string]interface{}{
+					"name": "my-subscription",
+					"annotations": map[string]interface{}{
+						"cnrm.cloud.google.com/project-id": "project1",
+					},
+				},
+				"spec": map[string]interface{}{
+					"topicRef": map[string]interface{}{
+						"external": "projects/project1/topics/pubsubtopic",
+					},
+				},
+			},
+		},
+	}
+	for _, u := range resources {
+		if err := sink.Receive(ctx, []byte("---\n"), u); err != nil {
+			t.Fatalf("error receiving resource: %v", err)
+		}
+	}
+	if err := sink.Receive(ctx, []byte("..."), nil); err != nil {
+		t.Fatalf("unexpected error receiving transmission terminator: %v", err)
+	}
+	// the resources are written as they are received, the kustomizations only once the sink is closed
+	if files := findFilesRecursive(t, tmpDir); len(files) != len(resources) {
+		t.Fatalf("got files %v before closing the sink, want only the %v resource files", files, len(resources))
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("error closing sink: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("error closing sink a second time: %v", err)
+	}
+
+	expectedFiles := []string{
+		"Kptfile",
+		"kustomization.yaml",
+		"my-project-id/kustomization.yaml",
+		"my-project-id/storage/kustomization.yaml",
+		"my-project-id/storage/storagebucket-deleteoutofband-0ba21344-d250-11e8-bf9c-dc4a3e7de811.yaml",
+		"project1/kustomization.yaml",
+		"project1/pubsub/kustomization.yaml",
+		"project1/pubsub/pubsubsubscription-my-subscription.yaml",
+		"project1/pubsub/pubsubtopic-pubsubtopic.yaml",
+	}
+	files := findFilesRecursive(t, tmpDir)
+	for i, f := range files {
+		rel, err := filepath.Rel(tmpDir, f)
+		if err != nil {
+			t.Fatalf("error getting relative path for '%v': %v", f, err)
+		}
+		files[i] = rel
+	}
+	sort.Strings(files)
+	if diff := cmp.Diff(expectedFiles, files); diff != "" {
+		t.Fatalf("unexpected files diff (-want +got):\n%v", diff)
+	}
+
+	kustomization := readYAMLFile(t, filepath.Join(tmpDir, "project1", "pubsub", "kustomization.yaml"))
+	expectedKustomization := map[string]interface{}{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources": []interface{}{
+			"pubsubsubscription-my-subscription.yaml",
+			"pubsubtopic-pubsubtopic.yaml",
+		},
+	}
+	if diff := cmp.Diff(expectedKustomization, kustomization); diff != "" {
+		t.Errorf("unexpected kustomization diff (-want +got):\n%v", diff)
+	}
+	rootKustomization := readYAMLFile(t, filepath.Join(tmpDir, "kustomization.yaml"))
+	if diff := cmp.Diff([]interface{}{"my-project-id", "project1"}, rootKustomization["resources"]); diff != "" {
+		t.Errorf("unexpected root kustomization resources diff (-want +got):\n%v", diff)
+	}
+
+	subscription := readYAMLFile(t, filepath.Join(tmpDir, "project1", "pubsub", "pubsubsubscription-my-subscription.yaml"))
+	topicRef, _, _ := unstructured.NestedMap(subscription, "spec", "topicRef")
+	if diff := cmp.Diff(map[string]interface{}{"name": "pubsubtopic"}, topicRef); diff != "" {
+		t.Errorf("unexpected topicRef diff (-want +got):\n%v", diff)
+	}
+}
+
+func TestKustomizeDirectorySinkResourcesWithTheSameName(t *testing.T) {
+	ctx := context.TODO()
+	tmpDir, cleanup := newTmpDir(t)
+	defer cleanup()
+	sink := outputsink.NewKustomizeDirectory(tmpDir)
+	// the third topic's file name collides with the name given to the second one
+	for _, name := range []string{"my-topic", "my-topic", "my-topic-2"} {
+		u := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1",
+				"kind":       "PubSubTopic",
+				"metadata": map[string]interface{}{
+					"name": name,
+					"annotations": map[string]interface{}{
+						"cnrm.cloud.google.com/project-id": "project1",
+					},
+				},
+			},
+		}
+		if err := sink.Receive(ctx, []byte("---\n"), u); err != nil {
+			t.Fatalf("error receiving resource: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("error closing sink: %v", err)
+	}
+	kustomization := readYAMLFile(t, filepath.Join(tmpDir, "project1", "pubsub", "kustomization.yaml"))
+	expectedResources := []interface{}{
+		"pubsubtopic-my-topic-2-2.yaml",
+		"pubsubtopic-my-topic-2.yaml",
+		"pubsubtopic-my-topic.yaml",
+	}
+	if diff := cmp.Diff(expectedResources, kustomization["resources"]); diff != "" {
+		t.Errorf("unexpected kustomization resources diff (-want +got):\n%v", diff)
+	}
+}
+
+func TestNewKustomizeRequiresDirectory(t *testing.T) {
+	tmpDir, cleanup := newTmpDir(t)
+	defer cleanup()
+	filePath := filepath.Join(tmpDir, "file.yaml")
+	if err := ioutil.WriteFile(filePath, []byte{}, 0644); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+	for _, outputParam := range []string{"", filePath} {
+		if _, err := outputsink.New(nil, outputParam, outputsink.KustomizeResourceFormat); err == nil {
+			t.Errorf("got nil error for output parameter '%v', want an error", outputParam)
+		}
+	}
+	sink, err := outputsink.New(nil, filepath.Join(tmpDir, "package"), outputsink.KustomizeResourceFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertTypeMatches(t, sink, outputsink.KustomizeDirectorySink{})
+}
+
+func readYAMLFile(t *testing.T, filePath string) map[string]interface{} {
+	t.Helper()
+	bytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("error reading file '%v': %v", filePath, err)
+	}
+	var result map[string]interface{}
+	if err := yaml.Unmarshal(bytes, &result); err != nil {
+		t.Fatalf("error unmarshalling '%v': %v", filePath, err)
+	}
+	return result
+}
@@ -41,10 +41,14 @@ func init() {
 }
 
 const (
-	KRMResourceFormat = "krm"
-	HCLResourceFormat = "hcl"
+	KRMResourceFormat       = "krm"
+	HCLResourceFormat       = "hcl"
+	HCLImportResourceFormat = "hcl-import"
+	KustomizeResourceFormat = "kustomize"
 )
 
+// OutputSink receives the exported resources. Sinks may buffer their output until they are closed, and calling Close
+// more than once must have no further effect so that callers can both defer Close and check its error.
 type OutputSink interface {
 	io.Closer
 	Receive(ctx context.Context, bytes []byte, unstructured *unstructured.Unstructured) error
@@ -213,8 +217,10 @@ func New(tfProvider *schema.Provider, outputParam string, resourceFormat Resourc
 	switch resourceFormat {
 	case KRMResourceFormat:
 		return newKRM(tfProvider, outputParam)
-	case HCLResourceFormat:
+	case HCLResourceFormat, HCLImportResourceFormat:
 		return newHCL(tfProvider, outputParam)
+	case KustomizeResourceFormat:
+		return newKustomize(outputParam)
 	default:
 		return nil, fmt.Errorf("unknown resource format '%v'", resourceFormat)
 	}
@@ -228,6 +234,21 @@ func newHCL(tfProvider *schema.Provider, outputParam string) (OutputSink, error)
 	return newSink(tfProvider, outputParam, NewHCLDirectory)
 }
 
+// newKustomize returns a KustomizeDirectorySink, which requires the output parameter to be a directory.
+func newKustomize(outputParam string) (OutputSink, error) {
+	if outputParam == "" {
+		return nil, fmt.Errorf("the '%v' resource format requires an output directory", KustomizeResourceFormat)
+	}
+	fi, ok := getFileInfo(outputParam)
+	if ok && !fi.IsDir() {
+		return nil, fmt.Errorf("cannot use output parameter '%v': the '%v' resource format requires an output directory", outputParam, KustomizeResourceFormat)
+	}
+	if err := os.MkdirAll(outputParam, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("error creating directory '%v': %v", outputParam, err)
+	}
+	return NewKustomizeDirectory(outputParam), nil
+}
+
 func newSink(tfProvider *schema.Provider, outputParam string, newDirectoryFunc func(*schema.Provider, string) OutputSink) (OutputSink, error) {
 	if outputParam == "" {
 		return NewWriter(os.Stdout), nil
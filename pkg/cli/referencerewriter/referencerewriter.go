@@ -0,0 +1,334 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referencerewriter
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/extension"
+	dclmetadata "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/schema/dclschemaloader"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/krmtotf"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/servicemapping/servicemappingloader"
+
+	"github.com/nasa9084/go-openapi"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Rewriter rewrites the 'external' resource references in exported resources into 'name' references when the
+// referenced resource is part of the same export. Exported resources only ever contain 'external' references, which
+// makes them hard to use as the starting point for a package of KCC resources that reference each other.
+//
+// All the resources of an export must be added with Add() before any resource is rewritten with Rewrite().
+type Rewriter struct {
+	smLoader          *servicemappingloader.ServiceMappingLoader
+	dclMetadataLoader dclmetadata.ServiceMetadataLoader
+	dclSchemaLoader   dclschemaloader.DCLSchemaLoader
+	resourcesByKind   map[string][]indexedResource
+	// the kinds of resources that can be referenced by each reference field, keyed by GroupVersionKind and then by
+	// the name of the reference field (e.g. 'topicRef')
+	refKindsByGVK map[schema.GroupVersionKind]map[string][]string
+}
+
+// indexedResource holds the identifiers of a resource as they were when the resource was added, as rewriting the
+// resource's own references can remove them from the resource (e.g. the project in 'spec.projectRef.external').
+// Only the identifiers are kept so that callers can let go of the resource once it has been added.
+type indexedResource struct {
+	name       string
+	namespace  string
+	resourceID string
+	projectID  string
+	location   string
+}
+
+// New returns a Rewriter which finds the reference fields of TF-based resources with the given ServiceMappings and
+// of DCL-based resources with the given DCL metadata and schemas.
+func New(smLoader *servicemappingloader.ServiceMappingLoader, dclMetadataLoader dclmetadata.ServiceMetadataLoader,
+	dclSchemaLoader dclschemaloader.DCLSchemaLoader) *Rewriter {
+	return &Rewriter{
+		smLoader:          smLoader,
+		dclMetadataLoader: dclMetadataLoader,
+		dclSchemaLoader:   dclSchemaLoader,
+		resourcesByKind:   make(map[string][]indexedResource),
+		refKindsByGVK:     make(map[schema.GroupVersionKind]map[string][]string),
+	}
+}
+
+// Add indexes the given resource so that references to it can be rewritten.
+func (r *Rewriter) Add(u *unstructured.Unstructured) {
+	location, _, _ := unstructured.NestedString(u.Object, "spec", "location")
+	r.resourcesByKind[u.GetKind()] = append(r.resourcesByKind[u.GetKind()], indexedResource{
+		name:       u.GetName(),
+		namespace:  u.GetNamespace(),
+		resourceID: getResourceID(u),
+		projectID:  getProjectID(u),
+		location:   location,
+	})
+}
+
+// Rewrite replaces each 'external' reference in the spec of the given resource with a 'name' reference if exactly one
+// of the indexed resources matches the reference. References which do not match, or which match more than one
+// indexed resource, are left untouched. It returns true if any reference was rewritten.
+func (r *Rewriter) Rewrite(u *unstructured.Unstructured) bool {
+	spec, ok := u.Object["spec"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	refKinds := r.getReferenceKindsByField(u.GroupVersionKind())
+	return r.rewriteReferences(u, spec, refKinds)
+}
+
+// getReferenceKindsByField returns the kinds of resources that can be referenced by each of the reference fields of
+// the given kind, keyed by the name of the reference field (e.g. 'topicRef').
+//
+// Kinds that are neither TF-based nor DCL-based (e.g. IAM kinds) have no known reference fields, but can still have
+// their multi-kind references, which specify the kind, rewritten.
+func (r *Rewriter) getReferenceKindsByField(gvk schema.GroupVersionKind) map[string][]string {
+	if refKinds, ok := r.refKindsByGVK[gvk]; ok {
+		return refKinds
+	}
+	refKinds := make(map[string][]string)
+	if _, ok := r.dclMetadataLoader.GetResourceWithGVK(gvk); ok {
+		if err := r.addDCLReferenceKinds(gvk, refKinds); err != nil {
+			// fall back to only rewriting multi-kind references
+			refKinds = make(map[string][]string)
+		}
+	} else {
+		r.addTFReferenceKinds(gvk, refKinds)
+	}
+	r.refKindsByGVK[gvk] = refKinds
+	return refKinds
+}
+
+func (r *Rewriter) addTFReferenceKinds(gvk schema.GroupVersionKind, refKinds map[string][]string) {
+	rcs, err := r.smLoader.GetResourceConfigs(gvk)
+	if err != nil {
+		return
+	}
+	for _, rc := range rcs {
+		for _, refConfig := range rc.ResourceReferences {
+			if len(refConfig.Types) == 0 {
+				key := krmtotf.GetKeyForReferenceField(&refConfig)
+				refKinds[key] = append(refKinds[key], refConfig.GVK.Kind)
+				continue
+			}
+			for _, typeConfig := range refConfig.Types {
+				if typeConfig.JSONSchemaType != "" {
+					// not a reference, but an explicit value
+					continue
+				}
+				refKinds[typeConfig.Key] = append(refKinds[typeConfig.Key], typeConfig.GVK.Kind)
+			}
+		}
+	}
+}
+
+func (r *Rewriter) addDCLReferenceKinds(gvk schema.GroupVersionKind, refKinds map[string][]string) error {
+	dclSchema, err := dclschemaloader.GetDCLSchemaForGVK(gvk, r.dclMetadataLoader, r.dclSchemaLoader)
+	if err != nil {
+		return err
+	}
+	// the DCL 'parent' field is split into multiple hierarchical references, e.g. 'projectRef' and 'folderRef'
+	if dcl.SupportsMultipleParentTypes(dclSchema) {
+		tcs, err := dcl.GetReferenceTypeConfigs(dclSchema.Properties["parent"], r.dclMetadataLoader)
+		if err != nil {
+			return fmt.Errorf("error getting reference type configs for DCL field 'parent': %w", err)
+		}
+		for _, tc := range tcs {
+			refKinds[tc.Key] = append(refKinds[tc.Key], tc.GVK.Kind)
+		}
+	}
+	return r.addDCLReferenceKindsForProperties(nil, dclSchema, refKinds)
+}
+
+func (r *Rewriter) addDCLReferenceKindsForProperties(path []string, s *openapi.Schema, refKinds map[string][]string) error {
+	for field, fieldSchema := range s.Properties {
+		fieldPath := append(append([]string{}, path...), field)
+		if dcl.IsMultiTypeParentReferenceField(fieldPath) {
+			continue
+		}
+		if extension.IsReferenceField(fieldSchema) {
+			refField, err := extension.GetReferenceFieldName(fieldPath, fieldSchema)
+			if err != nil {
+				return err
+			}
+			refSchema := fieldSchema
+			if fieldSchema.Type == "array" {
+				refSchema = fieldSchema.Items
+			}
+			tcs, err := dcl.GetReferenceTypeConfigs(refSchema, r.dclMetadataLoader)
+			if err != nil {
+				return fmt.Errorf("error getting reference type configs for DCL field '%v': %w", strings.Join(fieldPath, "."), err)
+			}
+			for _, tc := range tcs {
+				refKinds[refField] = append(refKinds[refField], tc.GVK.Kind)
+			}
+			continue
+		}
+		switch fieldSchema.Type {
+		case "object":
+			if err := r.addDCLReferenceKindsForProperties(fieldPath, fieldSchema, refKinds); err != nil {
+				return err
+			}
+		case "array":
+			if fieldSchema.Items != nil && fieldSchema.Items.Type == "object" {
+				if err := r.addDCLReferenceKindsForProperties(fieldPath, fieldSchema.Items, refKinds); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Rewriter) rewriteReferences(u *unstructured.Unstructured, obj map[string]interface{}, refKinds map[string][]string) bool {
+	rewritten := false
+	for field, val := range obj {
+		switch valAsType := val.(type) {
+		case map[string]interface{}:
+			if r.rewriteReference(u, field, valAsType, refKinds) {
+				rewritten = true
+				continue
+			}
+			if r.rewriteReferences(u, valAsType, refKinds) {
+				rewritten = true
+			}
+		case []interface{}:
+			for _, item := range valAsType {
+				itemAsMap, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if r.rewriteReference(u, field, itemAsMap, refKinds) {
+					rewritten = true
+					continue
+				}
+				if r.rewriteReferences(u, itemAsMap, refKinds) {
+					rewritten = true
+				}
+			}
+		}
+	}
+	return rewritten
+}
+
+func (r *Rewriter) rewriteReference(u *unstructured.Unstructured, field string, ref map[string]interface{}, refKinds map[string][]string) bool {
+	external, ok := ref["external"].(string)
+	if !ok || external == "" {
+		return false
+	}
+	if _, ok := ref["name"]; ok {
+		return false
+	}
+	kinds := refKinds[field]
+	if kind, ok := ref["kind"].(string); ok {
+		kinds = []string{kind}
+	}
+	if len(kinds) == 0 {
+		return false
+	}
+	referenced, ok := r.findReferencedResource(external, kinds)
+	if !ok {
+		return false
+	}
+	delete(ref, "external")
+	ref["name"] = referenced.name
+	if ns := referenced.namespace; ns != "" && ns != u.GetNamespace() {
+		ref["namespace"] = ns
+	}
+	return true
+}
+
+// findReferencedResource returns the single indexed resource of one of the given kinds that is identified by the
+// given external reference value.
+func (r *Rewriter) findReferencedResource(external string, kinds []string) (*indexedResource, bool) {
+	segments := strings.Split(trimExternalValue(external), "/")
+	var match *indexedResource
+	for _, kind := range kinds {
+		resources := r.resourcesByKind[kind]
+		for i := range resources {
+			candidate := &resources[i]
+			if !candidate.isIdentifiedBy(segments) {
+				continue
+			}
+			if match != nil && match != candidate {
+				// ambiguous reference
+				return nil, false
+			}
+			match = candidate
+		}
+	}
+	return match, match != nil
+}
+
+// trimExternalValue converts a self link (e.g. 'https://www.googleapis.com/compute/v1/projects/p/global/networks/n')
+// or a full resource name (e.g. '//pubsub.googleapis.com/projects/p/topics/t') into a relative resource name
+// (e.g. 'projects/p/global/networks/n').
+func trimExternalValue(external string) string {
+	if u, err := url.Parse(external); err == nil && u.Host != "" {
+		external = u.Path
+		for _, qualifier := range []string{"/projects/", "/folders/", "/organizations/"} {
+			if idx := strings.Index(external, qualifier); idx >= 0 {
+				external = external[idx:]
+				break
+			}
+		}
+	}
+	return strings.Trim(external, "/")
+}
+
+// isIdentifiedBy returns true if the resource is the one identified by the given segments of a relative resource
+// name. The last segment is compared to the resource's ID; the project and location in the resource name, if any,
+// must match those of the resource when the resource specifies them.
+func (r *indexedResource) isIdentifiedBy(segments []string) bool {
+	if r.resourceID != segments[len(segments)-1] {
+		return false
+	}
+	for i := 0; i+1 < len(segments)-1; i++ {
+		switch segments[i] {
+		case "projects":
+			if r.projectID != "" && r.projectID != segments[i+1] {
+				return false
+			}
+		case "locations", "regions", "zones":
+			if r.location != "" && r.location != segments[i+1] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func getResourceID(u *unstructured.Unstructured) string {
+	if id, ok, _ := unstructured.NestedString(u.Object, strings.Split(k8s.ResourceIDFieldPath, ".")...); ok && id != "" {
+		return id
+	}
+	return u.GetName()
+}
+
+func getProjectID(u *unstructured.Unstructured) string {
+	if project, ok := k8s.GetAnnotation(k8s.ProjectIDAnnotation, u); ok {
+		return project
+	}
+	if project, ok, _ := unstructured.NestedString(u.Object, "spec", "projectRef", "external"); ok {
+		return strings.TrimPrefix(project, "projects/")
+	}
+	return ""
+}
@@ -0,0 +1,214 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referencerewriter_test
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/referencerewriter"
+	dclmetadata "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/schema/dclschemaloader"
+	testservicemappingloader "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/test/servicemappingloader"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRewrite(t *testing.T) {
+	testCases := []struct {
+		name              string
+		resources         []*unstructured.Unstructured
+		resource          *unstructured.Unstructured
+		expectedSpec      map[string]interface{}
+		expectedRewritten bool
+	}{
+		{
+			name: "reference to a resource in the export is rewritten",
+			resources: []*unstructured.Unstructured{
+				newPubSubTopic("my-project", "my-topic"),
+			},
+			resource: newPubSubSubscription("my-project", "projects/my-project/topics/my-topic"),
+			expectedSpec: map[string]interface{}{
+				"topicRef": map[string]interface{}{
+					"name": "my-topic",
+				},
+			},
+			expectedRewritten: true,
+		},
+		{
+			name: "full resource name reference is rewritten",
+			resources: []*unstructured.Unstructured{
+				newPubSubTopic("my-project", "my-topic"),
+			},
+			resource: newPubSubSubscription("my-project", "//pubsub.googleapis.com/projects/my-project/topics/my-topic"),
+			expectedSpec: map[string]interface{}{
+				"topicRef": map[string]interface{}{
+					"name": "my-topic",
+				},
+			},
+			expectedRewritten: true,
+		},
+		{
+			name: "reference to a resource in another project is not rewritten",
+			resources: []*unstructured.Unstructured{
+				newPubSubTopic("other-project", "my-topic"),
+			},
+			resource: newPubSubSubscription("my-project", "projects/my-project/topics/my-topic"),
+			expectedSpec: map[string]interface{}{
+				"topicRef": map[string]interface{}{
+					"external": "projects/my-project/topics/my-topic",
+				},
+			},
+		},
+		{
+			name: "reference to a resource of a different kind is not rewritten",
+			resources: []*unstructured.Unstructured{
+				newUnstructured("storage.cnrm.cloud.google.com/v1beta1", "StorageBucket", "my-project", "my-topic"),
+			},
+			resource: newPubSubSubscription("my-project", "projects/my-project/topics/my-topic"),
+			expectedSpec: map[string]interface{}{
+				"topicRef": map[string]interface{}{
+					"external": "projects/my-project/topics/my-topic",
+				},
+			},
+		},
+		{
+			name: "ambiguous reference is not rewritten",
+			resources: []*unstructured.Unstructured{
+				newPubSubTopic("", "my-topic"),
+				newPubSubTopic("", "my-topic"),
+			},
+			resource: newPubSubSubscription("my-project", "my-topic"),
+			expectedSpec: map[string]interface{}{
+				"topicRef": map[string]interface{}{
+					"external": "my-topic",
+				},
+			},
+		},
+		{
+			name: "reference of a DCL-based resource is rewritten",
+			resources: []*unstructured.Unstructured{
+				newUnstructured("compute.cnrm.cloud.google.com/v1beta1", "ComputeFirewallPolicy", "", "123456789"),
+			},
+			resource: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "compute.cnrm.cloud.google.com/v1beta1",
+					"kind":       "ComputeFirewallPolicyAssociation",
+					"metadata": map[string]interface{}{
+						"name": "my-association",
+					},
+					"spec": map[string]interface{}{
+						"firewallPolicyRef": map[string]interface{}{
+							"external": "locations/global/firewallPolicies/123456789",
+						},
+					},
+				},
+			},
+			expectedSpec: map[string]interface{}{
+				"firewallPolicyRef": map[string]interface{}{
+					"name": "123456789",
+				},
+			},
+			expectedRewritten: true,
+		},
+		{
+			name: "multi-kind reference with kind is rewritten",
+			resources: []*unstructured.Unstructured{
+				newPubSubTopic("my-project", "my-topic"),
+			},
+			resource: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "iam.cnrm.cloud.google.com/v1beta1",
+					"kind":       "IAMPolicyMember",
+					"metadata": map[string]interface{}{
+						"name": "my-policy-member",
+					},
+					"spec": map[string]interface{}{
+						"member": "user:someone@example.com",
+						"role":   "roles/pubsub.viewer",
+						"resourceRef": map[string]interface{}{
+							"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1",
+							"kind":       "PubSubTopic",
+							"external":   "projects/my-project/topics/my-topic",
+						},
+					},
+				},
+			},
+			expectedSpec: map[string]interface{}{
+				"member": "user:someone@example.com",
+				"role":   "roles/pubsub.viewer",
+				"resourceRef": map[string]interface{}{
+					"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1",
+					"kind":       "PubSubTopic",
+					"name":       "my-topic",
+				},
+			},
+			expectedRewritten: true,
+		},
+	}
+	smLoader := testservicemappingloader.New(t)
+	dclSchemaLoader, err := dclschemaloader.New()
+	if err != nil {
+		t.Fatalf("error creating DCL schema loader: %v", err)
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			rewriter := referencerewriter.New(smLoader, dclmetadata.New(), dclSchemaLoader)
+			for _, r := range tc.resources {
+				rewriter.Add(r)
+			}
+			rewriter.Add(tc.resource)
+			rewritten := rewriter.Rewrite(tc.resource)
+			if rewritten != tc.expectedRewritten {
+				t.Errorf("got rewritten '%v', want '%v'", rewritten, tc.expectedRewritten)
+			}
+			spec, _, _ := unstructured.NestedMap(tc.resource.Object, "spec")
+			if diff := cmp.Diff(tc.expectedSpec, spec); diff != "" {
+				t.Errorf("unexpected spec diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func newPubSubTopic(project, name string) *unstructured.Unstructured {
+	return newUnstructured("pubsub.cnrm.cloud.google.com/v1beta1", "PubSubTopic", project, name)
+}
+
+func newPubSubSubscription(project, topicExternal string) *unstructured.Unstructured {
+	u := newUnstructured("pubsub.cnrm.cloud.google.com/v1beta1", "PubSubSubscription", project, "my-subscription")
+	u.Object["spec"] = map[string]interface{}{
+		"topicRef": map[string]interface{}{
+			"external": topicExternal,
+		},
+	}
+	return u
+}
+
+func newUnstructured(apiVersion, kind, project, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+		},
+	}
+	if project != "" {
+		u.SetAnnotations(map[string]string{"cnrm.cloud.google.com/project-id": project})
+	}
+	return u
+}
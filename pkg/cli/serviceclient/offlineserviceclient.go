@@ -0,0 +1,88 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceclient
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+
+	resourcemanager "google.golang.org/api/cloudresourcemanager/v1"
+)
+
+const projectAssetType = "cloudresourcemanager.googleapis.com/Project"
+
+var projectNumberRegex = regexp.MustCompile(`^[0-9]+$`)
+
+// OfflineServiceClient is a ServiceClient which does not make any requests to GCP. Projects are looked up by number
+// in the project IDs it was created with and in the Project assets recorded with RecordProjectAsset(...).
+type OfflineServiceClient struct {
+	mu sync.RWMutex
+	// projectIDs are the IDs of the projects keyed by their number.
+	projectIDs map[string]string
+}
+
+// NewOfflineServiceClient returns an OfflineServiceClient which knows the IDs of the given projects, keyed by number.
+func NewOfflineServiceClient(projectIDs map[string]string) *OfflineServiceClient {
+	o := &OfflineServiceClient{
+		projectIDs: make(map[string]string, len(projectIDs)),
+	}
+	for number, id := range projectIDs {
+		o.projectIDs[number] = id
+	}
+	return o
+}
+
+// RecordProjectAsset records the ID of the project of the given asset, as found in its resource data, if it is a
+// Project asset. Other assets are ignored.
+func (o *OfflineServiceClient) RecordProjectAsset(a *asset.Asset) {
+	if a.AssetType != projectAssetType || a.Resource == nil {
+		return
+	}
+	id, ok := a.Resource.Data["projectId"].(string)
+	if !ok || id == "" {
+		return
+	}
+	number := a.Name[strings.LastIndex(a.Name, "/")+1:]
+	if !projectNumberRegex.MatchString(number) {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.projectIDs[number] = id
+}
+
+// GetProjectFromProjectIDOrNumber returns the project with the given ID or number. A project number can only be
+// resolved to the ID of a known project.
+func (o *OfflineServiceClient) GetProjectFromProjectIDOrNumber(projectIDOrNumber string) (*resourcemanager.Project, error) {
+	if !projectNumberRegex.MatchString(projectIDOrNumber) {
+		return &resourcemanager.Project{
+			ProjectId: projectIDOrNumber,
+		}, nil
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	id, ok := o.projectIDs[projectIDOrNumber]
+	if !ok {
+		return nil, fmt.Errorf("unable to look up the ID of project with number '%v' offline: the project must be "+
+			"part of the asset inventory, before the resources it contains, or its ID must be supplied", projectIDOrNumber)
+	}
+	return &resourcemanager.Project{
+		ProjectId: id,
+	}, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceclient_test
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/serviceclient"
+)
+
+func TestOfflineServiceClientGetProjectFromProjectIDOrNumber(t *testing.T) {
+	client := serviceclient.NewOfflineServiceClient(map[string]string{"1111111111": "supplied-project"})
+	client.RecordProjectAsset(&asset.Asset{
+		Name:      "//cloudresourcemanager.googleapis.com/projects/2222222222",
+		AssetType: "cloudresourcemanager.googleapis.com/Project",
+		Resource: &asset.Resource{
+			Data: map[string]interface{}{
+				"projectId":     "recorded-project",
+				"projectNumber": "2222222222",
+			},
+		},
+	})
+	client.RecordProjectAsset(&asset.Asset{
+		Name:      "//pubsub.googleapis.com/projects/3333333333/topics/my-topic",
+		AssetType: "pubsub.googleapis.com/Topic",
+		Resource: &asset.Resource{
+			Data: map[string]interface{}{
+				"projectId": "not-a-project",
+			},
+		},
+	})
+	tests := []struct {
+		name              string
+		projectIDOrNumber string
+		expectedID        string
+		shouldErr         bool
+	}{
+		{
+			name:              "project ID",
+			projectIDOrNumber: "my-project",
+			expectedID:        "my-project",
+		},
+		{
+			name:              "number of a supplied project",
+			projectIDOrNumber: "1111111111",
+			expectedID:        "supplied-project",
+		},
+		{
+			name:              "number of a recorded project",
+			projectIDOrNumber: "2222222222",
+			expectedID:        "recorded-project",
+		},
+		{
+			name:              "number of an unknown project",
+			projectIDOrNumber: "3333333333",
+			shouldErr:         true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			project, err := client.GetProjectFromProjectIDOrNumber(tc.projectIDOrNumber)
+			if tc.shouldErr {
+				if err == nil {
+					t.Fatalf("got project '%v', want an error", project.ProjectId)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if project.ProjectId != tc.expectedID {
+				t.Errorf("got project ID '%v', want '%v'", project.ProjectId, tc.expectedID)
+			}
+		})
+	}
+}
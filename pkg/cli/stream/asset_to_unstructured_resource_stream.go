@@ -16,11 +16,16 @@ package stream
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"sync"
 
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/gcpclient"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/offline"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/serviceclient"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/execution"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/resourceskeleton"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/servicemapping/servicemappingloader"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -33,17 +38,48 @@ type AssetToUnstructuredResourceStream struct {
 	serviceClient serviceclient.ServiceClient
 	smLoader      *servicemappingloader.ServiceMappingLoader
 	tfProvider    *schema.Provider
+	offline       bool
+	parallelism   int
+	results       chan chan assetResult
+	done          chan struct{}
+	cancel        context.CancelFunc
+	workers       sync.WaitGroup
+	closeOnce     sync.Once
+	assetTracker  *AssetTracker
+}
+
+type assetResult struct {
+	asset        *asset.Asset
+	unstructured *unstructured.Unstructured
+	err          error
 }
 
 // NewUnstructuredResourceStreamFromAssetStream returns an unstructured stream. The stream converts each asset in the 'assetStream' to
 // a KCC resource and does a GET request to GCP finally returning the current value of the resource in KCC format
 // as an unstructured
-func NewUnstructuredResourceStreamFromAssetStream(assetStream AssetStream, client gcpclient.Client, tfProvider *schema.Provider, serviceClient serviceclient.ServiceClient) (*AssetToUnstructuredResourceStream, error) {
+//
+// When 'parallelism' is greater than one, up to that many assets are fetched from GCP concurrently. The resources are
+// still returned in the order of the assets in 'assetStream'.
+func NewUnstructuredResourceStreamFromAssetStream(assetStream AssetStream, client gcpclient.Client, tfProvider *schema.Provider, serviceClient serviceclient.ServiceClient, parallelism int) (*AssetToUnstructuredResourceStream, error) {
 	stream, err := newUnstructuredResourceStreamFromAssetStream(assetStream, tfProvider, serviceClient)
 	if err != nil {
 		return nil, err
 	}
 	stream.gcpClient = client
+	stream.parallelism = parallelism
+	return stream, nil
+}
+
+// NewOfflineUnstructuredResourceStreamFromAssetStream returns an unstructured stream which, unlike the stream returned by
+// NewUnstructuredResourceStreamFromAssetStream(...), does not GET the resources from GCP but converts the resource data
+// contained in the assets instead, see offline.NewUnstructuredFromAsset(...).
+func NewOfflineUnstructuredResourceStreamFromAssetStream(assetStream AssetStream, tfProvider *schema.Provider, serviceClient serviceclient.ServiceClient, parallelism int) (*AssetToUnstructuredResourceStream, error) {
+	stream, err := newUnstructuredResourceStreamFromAssetStream(assetStream, tfProvider, serviceClient)
+	if err != nil {
+		return nil, err
+	}
+	stream.offline = true
+	stream.parallelism = parallelism
 	return stream, nil
 }
 
@@ -57,25 +93,167 @@ func newUnstructuredResourceStreamFromAssetStream(assetStream AssetStream, tfPro
 		serviceClient: serviceClient,
 		smLoader:      smLoader,
 		tfProvider:    tfProvider,
+		done:          make(chan struct{}),
 	}
 	return &stream, nil
 }
 
+// SetAssetTracker sets the tracker in which each returned resource is recorded along with the asset it was created
+// from.
+func (s *AssetToUnstructuredResourceStream) SetAssetTracker(assetTracker *AssetTracker) {
+	s.assetTracker = assetTracker
+}
+
 func (s *AssetToUnstructuredResourceStream) Next(ctx context.Context) (*unstructured.Unstructured, error) {
-	asset, err := s.assetStream.Next()
+	var result assetResult
+	if s.parallelism > 1 {
+		result = s.nextParallel(ctx)
+	} else {
+		result = s.nextSerial(ctx)
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+	if s.assetTracker != nil {
+		s.assetTracker.Track(result.unstructured, result.asset)
+	}
+	return result.unstructured, nil
+}
+
+func (s *AssetToUnstructuredResourceStream) nextSerial(ctx context.Context) assetResult {
+	a, err := s.assetStream.Next()
 	if err != nil {
 		if err != io.EOF {
 			err = fmt.Errorf("error getting next asset: %v", err)
 		}
-		return nil, err
+		return assetResult{err: err}
 	}
+	u, err := s.getUnstructured(ctx, a)
+	return assetResult{asset: a, unstructured: u, err: err}
+}
+
+// nextParallel returns the result for the next asset in the stream, which may have been fetched concurrently with
+// those of the assets after it.
+func (s *AssetToUnstructuredResourceStream) nextParallel(ctx context.Context) assetResult {
+	if s.results == nil {
+		select {
+		case <-s.done:
+			return assetResult{err: errStreamClosed}
+		default:
+		}
+		s.startWorkers(ctx)
+	}
+	result, ok := <-s.results
+	if !ok {
+		return assetResult{err: io.EOF}
+	}
+	return <-result
+}
+
+// startWorkers reads the asset stream in the background, converting up to 'parallelism' assets at once. A channel for
+// the result of each asset is queued, in the order the assets are read, on s.results so that Next() returns the
+// results in a deterministic order regardless of the order in which the conversions finish. The background reads and
+// conversions stop once Close() is called.
+func (s *AssetToUnstructuredResourceStream) startWorkers(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.results = make(chan chan assetResult, s.parallelism)
+	s.workers.Add(1)
+	go func() {
+		defer s.workers.Done()
+		defer close(s.results)
+		workers := make(chan struct{}, s.parallelism)
+		for {
+			select {
+			case <-s.done:
+				return
+			default:
+			}
+			a, err := s.assetStream.Next()
+			if err == io.EOF {
+				return
+			}
+			result := make(chan assetResult, 1)
+			select {
+			case s.results <- result:
+			case <-s.done:
+				return
+			}
+			if err != nil {
+				result <- assetResult{err: fmt.Errorf("error getting next asset: %v", err)}
+				continue
+			}
+			select {
+			case workers <- struct{}{}:
+			case <-s.done:
+				result <- assetResult{asset: a, err: errStreamClosed}
+				return
+			}
+			s.workers.Add(1)
+			go func() {
+				defer s.workers.Done()
+				defer func() { <-workers }()
+				result <- s.convert(ctx, a)
+			}()
+		}
+	}()
+}
+
+var errStreamClosed = errors.New("the stream is closed")
+
+// Close stops reading and converting assets in the background and waits for the in-flight conversions to return. It
+// does not close the underlying asset stream, which must only be closed once Close() returns.
+func (s *AssetToUnstructuredResourceStream) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		if s.cancel != nil {
+			s.cancel()
+		}
+	})
+	s.workers.Wait()
+	return nil
+}
+
+// convert recovers from panics as, unlike in the serial case, they happen outside of the goroutine calling Next()
+// and so cannot be recovered by the consumer of the stream.
+func (s *AssetToUnstructuredResourceStream) convert(ctx context.Context, a *asset.Asset) (result assetResult) {
+	result.asset = a
+	defer execution.RecoverWithGenericError(&result.err)
+	result.unstructured, result.err = s.getUnstructured(ctx, a)
+	return result
+}
+
+func (s *AssetToUnstructuredResourceStream) getUnstructured(ctx context.Context, asset *asset.Asset) (*unstructured.Unstructured, error) {
 	skel, err := resourceskeleton.NewFromAsset(asset, s.smLoader, s.tfProvider, s.serviceClient)
 	if err != nil {
-		return nil, fmt.Errorf("error converting asset '%v' with kind '%v' to skeleton: %v", asset.Name, asset.AssetType, err)
+		return nil, &ExportError{
+			Stage: SkeletonStage,
+			Asset: asset,
+			Kind:  s.getKind(asset),
+			Err:   fmt.Errorf("error converting asset '%v' with kind '%v' to skeleton: %v", asset.Name, asset.AssetType, err),
+		}
+	}
+	var u *unstructured.Unstructured
+	if s.offline {
+		u, err = offline.NewUnstructuredFromAsset(ctx, asset, skel, s.smLoader, s.tfProvider)
+	} else {
+		u, err = s.gcpClient.Get(ctx, skel)
 	}
-	u, err := s.gcpClient.Get(ctx, skel)
 	if err != nil {
-		return nil, fmt.Errorf("error getting '%v': %v", asset.Name, err)
+		return nil, &ExportError{
+			Stage: GetStage,
+			Asset: asset,
+			Kind:  skel.GetKind(),
+			Err:   fmt.Errorf("error getting '%v': %v", asset.Name, err),
+		}
 	}
 	return u, nil
 }
+
+// getKind returns the KRM kind of the asset, or an empty string if it cannot be resolved.
+func (s *AssetToUnstructuredResourceStream) getKind(a *asset.Asset) string {
+	_, rc, err := asset.GetServiceMappingAndResourceConfig(s.smLoader, a)
+	if err != nil {
+		return ""
+	}
+	return rc.Kind
+}
@@ -16,9 +16,14 @@ package stream_test
 
 import (
 	"context"
+	"errors"
 	"io"
+	"math/rand"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/gcpclient"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/serviceclient"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/stream"
@@ -26,6 +31,7 @@ import (
 	testyaml "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/test/yaml"
 	tfprovider "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/tf/provider"
 
+	"github.com/google/go-cmp/cmp"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -40,11 +46,126 @@ func TestAssetToUnstructuredStream(t *testing.T) {
 	testyaml.AssertFileContentsMatchValue(t, assetToUnstructuredResourceStreamYAMLFile, unstructs)
 }
 
+func TestAssetToUnstructuredStreamWithParallelism(t *testing.T) {
+	serialStream := newTestUnstructuredResourceStreamFromAsset(t, newTestAssetStream(t))
+	expected := unstructuredStreamToSlice(t, serialStream)
+	// the mock client sleeps for a random amount of time so that the requests finish out of order
+	mockClient := &mockGCPClient{t: t, maxDelay: 10 * time.Millisecond}
+	parallelStream := newTestUnstructuredResourceStreamFromAssetWithClient(t, newTestAssetStream(t), mockClient, 4)
+	actual := unstructuredStreamToSlice(t, parallelStream)
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Fatalf("unexpected diff between serial and parallel streams (-want +got):\n%v", diff)
+	}
+}
+
+func TestAssetToUnstructuredStreamTracksAssets(t *testing.T) {
+	ctx := context.TODO()
+	var expectedAssetNames []string
+	assetStream := newTestAssetStream(t)
+	for a, err := assetStream.Next(); err != io.EOF; a, err = assetStream.Next() {
+		if err != nil {
+			t.Fatalf("error reading asset: %v", err)
+		}
+		expectedAssetNames = append(expectedAssetNames, a.Name)
+	}
+	for _, parallelism := range []int{1, 4} {
+		unstructuredStream := newTestUnstructuredResourceStreamFromAssetWithClient(t, newTestAssetStream(t), newMockGCPClient(t), parallelism)
+		assetTracker := stream.NewAssetTracker()
+		unstructuredStream.SetAssetTracker(assetTracker)
+		var assetNames []string
+		for u, err := unstructuredStream.Next(ctx); err != io.EOF; u, err = unstructuredStream.Next(ctx) {
+			if err != nil {
+				t.Fatalf("error reading asset: %v", err)
+			}
+			a, ok := assetTracker.Pop(u)
+			if !ok {
+				t.Fatalf("resource '%v' is not tracked", u.GetName())
+			}
+			assetNames = append(assetNames, a.Name)
+			if _, ok := assetTracker.Pop(u); ok {
+				t.Fatalf("resource '%v' is still tracked after being popped", u.GetName())
+			}
+		}
+		if diff := cmp.Diff(expectedAssetNames, assetNames); diff != "" {
+			t.Fatalf("unexpected asset names with parallelism %v (-want +got):\n%v", parallelism, diff)
+		}
+	}
+}
+
+func TestAssetToUnstructuredStreamGetError(t *testing.T) {
+	mockClient := &mockGCPClient{t: t, err: errors.New("permission denied")}
+	unstructuredStream := newTestUnstructuredResourceStreamFromAssetWithClient(t, newTestAssetStream(t), mockClient, 1)
+	_, err := unstructuredStream.Next(context.TODO())
+	var exportErr *stream.ExportError
+	if !errors.As(err, &exportErr) {
+		t.Fatalf("got error '%v', want an export error", err)
+	}
+	if exportErr.Stage != stream.GetStage || exportErr.Asset == nil || exportErr.Kind == "" {
+		t.Fatalf("got export error with stage '%v', asset '%v' and kind '%v', want the GET stage, the asset and its kind",
+			exportErr.Stage, exportErr.Asset, exportErr.Kind)
+	}
+}
+
+func TestAssetToUnstructuredStreamCloseStopsWorkers(t *testing.T) {
+	assetStream := &closeCheckingAssetStream{t: t, AssetStream: newTestAssetStream(t)}
+	mockClient := &mockGCPClient{t: t, maxDelay: 10 * time.Millisecond}
+	unstructuredStream := newTestUnstructuredResourceStreamFromAssetWithClient(t, assetStream, mockClient, 2)
+	if _, err := unstructuredStream.Next(context.TODO()); err != nil {
+		t.Fatalf("error reading resource: %v", err)
+	}
+	if err := unstructuredStream.Close(); err != nil {
+		t.Fatalf("error closing stream: %v", err)
+	}
+	// the workers are stopped so the asset stream can be closed while there are still unread assets
+	if err := assetStream.Close(); err != nil {
+		t.Fatalf("error closing asset stream: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+}
+
+func TestAssetToUnstructuredStreamCloseBeforeNext(t *testing.T) {
+	unstructuredStream := newTestUnstructuredResourceStreamFromAssetWithClient(t, newTestAssetStream(t), newMockGCPClient(t), 2)
+	if err := unstructuredStream.Close(); err != nil {
+		t.Fatalf("error closing stream: %v", err)
+	}
+	if _, err := unstructuredStream.Next(context.TODO()); err == nil || err == io.EOF {
+		t.Fatalf("got error '%v' reading a closed stream, want an error", err)
+	}
+}
+
+// closeCheckingAssetStream fails the test if the stream is read once it is closed.
+type closeCheckingAssetStream struct {
+	stream.AssetStream
+	t      *testing.T
+	mu     sync.Mutex
+	closed bool
+}
+
+func (s *closeCheckingAssetStream) Next() (*asset.Asset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		s.t.Errorf("asset stream read after being closed")
+		return nil, io.EOF
+	}
+	return s.AssetStream.Next()
+}
+
+func (s *closeCheckingAssetStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return s.AssetStream.Close()
+}
+
 func newTestUnstructuredResourceStreamFromAsset(t *testing.T, assetStream stream.AssetStream) *stream.AssetToUnstructuredResourceStream {
-	mockClient := newMockGCPClient(t)
+	return newTestUnstructuredResourceStreamFromAssetWithClient(t, assetStream, newMockGCPClient(t), 1)
+}
+
+func newTestUnstructuredResourceStreamFromAssetWithClient(t *testing.T, assetStream stream.AssetStream, mockClient gcpclient.Client, parallelism int) *stream.AssetToUnstructuredResourceStream {
 	serviceClient := serviceclient.NewMockServiceClient(t)
 	tfProvider := tfprovider.NewOrLogFatal(tfprovider.NewConfig())
-	unstructuredStream, err := stream.NewUnstructuredResourceStreamFromAssetStream(assetStream, mockClient, tfProvider, &serviceClient)
+	unstructuredStream, err := stream.NewUnstructuredResourceStreamFromAssetStream(assetStream, mockClient, tfProvider, &serviceClient, parallelism)
 	if err != nil {
 		t.Fatalf("error creating unstructured stream: %v", err)
 	}
@@ -65,7 +186,9 @@ func unstructuredStreamToSlice(t *testing.T, stream stream.UnstructuredStream) [
 }
 
 type mockGCPClient struct {
-	t *testing.T
+	t        *testing.T
+	maxDelay time.Duration
+	err      error
 }
 
 func newMockGCPClient(t *testing.T) gcpclient.Client {
@@ -75,6 +198,12 @@ func newMockGCPClient(t *testing.T) gcpclient.Client {
 }
 
 func (m *mockGCPClient) Get(ctx context.Context, u *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if m.maxDelay > 0 {
+		time.Sleep(time.Duration(rand.Int63n(int64(m.maxDelay))))
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
 	newUnstruct := unstructured.Unstructured{
 		Object: deepcopy.DeepCopy(u.Object).(map[string]interface{}),
 	}
@@ -0,0 +1,47 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// AssetTracker records the asset from which each resource returned by an AssetToUnstructuredResourceStream was
+// created. As the streams further down the chain edit the resources in place, the asset of a resource can be looked
+// up when it reaches the end of the chain, e.g. to record that the asset was exported.
+//
+// An AssetTracker is not safe for concurrent use; it is only used by the goroutine consuming the streams.
+type AssetTracker struct {
+	assets map[*unstructured.Unstructured]*asset.Asset
+}
+
+func NewAssetTracker() *AssetTracker {
+	return &AssetTracker{
+		assets: make(map[*unstructured.Unstructured]*asset.Asset),
+	}
+}
+
+func (t *AssetTracker) Track(u *unstructured.Unstructured, a *asset.Asset) {
+	t.assets[u] = a
+}
+
+// Pop returns the asset of the given resource, if the resource is tracked, and stops tracking it.
+func (t *AssetTracker) Pop(u *unstructured.Unstructured) (*asset.Asset, bool) {
+	a, ok := t.assets[u]
+	delete(t.assets, u)
+	return a, ok
+}
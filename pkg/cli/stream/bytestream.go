@@ -31,10 +31,12 @@ type ByteStream interface {
 
 func NewByteStream(resourceFormat outputsink.ResourceFormat, uStream UnstructuredStream, smLoader *servicemappingloader.ServiceMappingLoader, tfProvider *schema.Provider) (ByteStream, error) {
 	switch resourceFormat {
-	case outputsink.KRMResourceFormat:
+	case outputsink.KRMResourceFormat, outputsink.KustomizeResourceFormat:
 		return NewYAMLStream(uStream), nil
 	case outputsink.HCLResourceFormat:
 		return NewHCLStream(uStream, smLoader, tfProvider), nil
+	case outputsink.HCLImportResourceFormat:
+		return NewHCLImportStream(uStream, smLoader, tfProvider), nil
 	default:
 		return nil, fmt.Errorf("unhandled resource format '%v'", resourceFormat)
 	}
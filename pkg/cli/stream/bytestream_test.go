@@ -26,10 +26,18 @@ func TestHCLResourceFormat(t *testing.T) {
 	testNewByteStream(t, "hcl", stream.HCLStream{})
 }
 
+func TestHCLImportResourceFormat(t *testing.T) {
+	testNewByteStream(t, "hcl-import", stream.HCLStream{})
+}
+
 func TestKRMResourceFormat(t *testing.T) {
 	testNewByteStream(t, "krm", stream.YAMLStream{})
 }
 
+func TestKustomizeResourceFormat(t *testing.T) {
+	testNewByteStream(t, "kustomize", stream.YAMLStream{})
+}
+
 func testNewByteStream(t *testing.T, resourceFormat outputsink.ResourceFormat, instanceOfExpectedType interface{}) {
 	byteStream, err := stream.NewByteStream(resourceFormat, nil, nil, nil)
 	if err != nil {
@@ -0,0 +1,58 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type ExportStage string
+
+const (
+	// SkeletonStage is the conversion of an asset to the skeleton of its resource
+	SkeletonStage ExportStage = "skeleton"
+	// GetStage is the GET of a resource from GCP, or its conversion from the asset's resource data when offline
+	GetStage ExportStage = "GET"
+	// IAMFetchStage is the GET of the IAM policy of a resource from GCP
+	IAMFetchStage ExportStage = "IAM fetch"
+	// SerializationStage is the conversion of a resource to the output format, e.g. YAML
+	SerializationStage ExportStage = "serialization"
+	// OutputStage is the writing of a serialized resource to the output
+	OutputStage ExportStage = "output"
+)
+
+// ExportError is returned by the streams when exporting the resource of an asset fails. The error message is that of
+// the underlying error.
+type ExportError struct {
+	Stage ExportStage
+	// Asset is the asset being exported, it is nil if the stage that failed does not know the asset, in which case
+	// it can be looked up from Resource with an AssetTracker
+	Asset *asset.Asset
+	// Kind is the KRM kind of the resource, if it was resolved
+	Kind string
+	// Resource is the resource being exported, if it was fetched
+	Resource *unstructured.Unstructured
+	Err      error
+}
+
+func (e *ExportError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExportError) Unwrap() error {
+	return e.Err
+}
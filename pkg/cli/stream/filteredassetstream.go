@@ -27,12 +27,23 @@ type AssetStream interface {
 
 type FilteredAssetStream struct {
 	assetStream   AssetStream
-	shouldInclude func(*asset.Asset) bool
+	shouldInclude func(*asset.Asset) (bool, error)
 }
 
 // Constructs a new asset stream with a filter function which determines if a given asset is included or not
 // This is useful for filtering out asset types and resource types that we do not yet support
 func NewFilteredAssetStream(stream AssetStream, shouldInclude func(*asset.Asset) bool) AssetStream {
+	if shouldInclude == nil {
+		return NewFallibleFilteredAssetStream(stream, nil)
+	}
+	return NewFallibleFilteredAssetStream(stream, func(a *asset.Asset) (bool, error) {
+		return shouldInclude(a), nil
+	})
+}
+
+// Constructs a new asset stream with a filter function which can fail, e.g. when an asset lacks the data needed to
+// decide if it is included or not. The error of the filter function is returned by Next().
+func NewFallibleFilteredAssetStream(stream AssetStream, shouldInclude func(*asset.Asset) (bool, error)) AssetStream {
 	filteredStream := FilteredAssetStream{
 		assetStream:   stream,
 		shouldInclude: shouldInclude,
@@ -52,7 +63,11 @@ func (f *FilteredAssetStream) Next() (*asset.Asset, error) {
 		if err != nil {
 			return nil, err
 		}
-		if f.shouldInclude(asset) {
+		include, err := f.shouldInclude(asset)
+		if err != nil {
+			return nil, err
+		}
+		if include {
 			return asset, nil
 		}
 	}
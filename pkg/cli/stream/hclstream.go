@@ -30,6 +30,7 @@ type HCLStream struct {
 	unstructuredStream UnstructuredStream
 	smLoader           *servicemappingloader.ServiceMappingLoader
 	tfProvider         *schema.Provider
+	withImportBlocks   bool
 }
 
 func NewHCLStream(unstructuredStream UnstructuredStream, smLoader *servicemappingloader.ServiceMappingLoader, tfProvider *schema.Provider) *HCLStream {
@@ -41,6 +42,14 @@ func NewHCLStream(unstructuredStream UnstructuredStream, smLoader *servicemappin
 	return &hclStream
 }
 
+// NewHCLImportStream returns an HCLStream which follows each resource block with a Terraform 'import' block
+// so that the resulting configuration can be adopted by Terraform without recreating the resources.
+func NewHCLImportStream(unstructuredStream UnstructuredStream, smLoader *servicemappingloader.ServiceMappingLoader, tfProvider *schema.Provider) *HCLStream {
+	hclStream := NewHCLStream(unstructuredStream, smLoader, tfProvider)
+	hclStream.withImportBlocks = true
+	return hclStream
+}
+
 func (h *HCLStream) Next(ctx context.Context) ([]byte, *unstructured.Unstructured, error) {
 	unstructured, err := h.unstructuredStream.Next(ctx)
 	if err != nil {
@@ -49,9 +58,18 @@ func (h *HCLStream) Next(ctx context.Context) ([]byte, *unstructured.Unstructure
 		}
 		return nil, unstructured, err
 	}
-	hcl, err := krmtohcl.UnstructuredToHCL(ctx, unstructured, h.smLoader, h.tfProvider)
+	convert := krmtohcl.UnstructuredToHCL
+	if h.withImportBlocks {
+		convert = krmtohcl.UnstructuredToHCLWithImportBlock
+	}
+	hcl, err := convert(ctx, unstructured, h.smLoader, h.tfProvider)
 	if err != nil {
-		return nil, unstructured, fmt.Errorf("error converting krm to hcl: %w", err)
+		return nil, unstructured, &ExportError{
+			Stage:    SerializationStage,
+			Kind:     unstructured.GetKind(),
+			Resource: unstructured,
+			Err:      fmt.Errorf("error converting krm to hcl: %w", err),
+		}
 	}
 	return []byte(hcl), unstructured, nil
 }
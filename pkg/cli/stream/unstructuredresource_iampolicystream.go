@@ -72,14 +72,19 @@ func (s *UnstructuredResourceAndIAMPolicyStream) Next(ctx context.Context) (*uns
 	resourceUnstruct, err := s.unstructStream.Next(ctx)
 	if err != nil {
 		if err != io.EOF {
-			err = fmt.Errorf("error getting next unstruct: %v", err)
+			err = fmt.Errorf("error getting next unstruct: %w", err)
 		}
 		return nil, err
 	}
 	// if any error occurs in this function then return error and 'drop' the resourceUnstruct: resources are incomplete
 	// without their associated IAMPolicy.
 	if err := s.fillNextIAMPolicyIfSupportedAndIfNonEmpty(resourceUnstruct); err != nil {
-		return nil, err
+		return nil, &ExportError{
+			Stage:    IAMFetchStage,
+			Kind:     resourceUnstruct.GetKind(),
+			Resource: resourceUnstruct,
+			Err:      err,
+		}
 	}
 	return resourceUnstruct, nil
 }
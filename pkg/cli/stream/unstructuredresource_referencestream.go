@@ -0,0 +1,96 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"io"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/referencerewriter"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/execution"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// UnstructuredResourceReferenceStream rewrites the 'external' references of the resources in the underlying stream
+// into 'name' references when the referenced resource is also in the stream, e.g. a PubSubSubscription's
+// 'spec.topicRef.external' is replaced with a 'spec.topicRef.name' pointing at the exported PubSubTopic.
+//
+// As a reference can point at a resource that comes later in the stream, the whole underlying stream is read, and
+// held in memory, on the first call to Next(). The underlying stream is read until the first error, e.g. malformed
+// input that the underlying stream can't decode past, which is returned after the resources that preceded it.
+type UnstructuredResourceReferenceStream struct {
+	unstructStream UnstructuredStream
+	rewriter       *referencerewriter.Rewriter
+	results        []unstructuredResult
+	filled         bool
+}
+
+type unstructuredResult struct {
+	unstructured *unstructured.Unstructured
+	err          error
+}
+
+func NewUnstructuredResourceReferenceStream(unstructuredStream UnstructuredStream, rewriter *referencerewriter.Rewriter) *UnstructuredResourceReferenceStream {
+	return &UnstructuredResourceReferenceStream{
+		unstructStream: unstructuredStream,
+		rewriter:       rewriter,
+	}
+}
+
+func (s *UnstructuredResourceReferenceStream) Next(ctx context.Context) (*unstructured.Unstructured, error) {
+	if !s.filled {
+		s.fill(ctx)
+	}
+	if len(s.results) == 0 {
+		return nil, io.EOF
+	}
+	result := s.results[0]
+	s.results = s.results[1:]
+	if result.err != nil {
+		return nil, result.err
+	}
+	s.rewriter.Rewrite(result.unstructured)
+	return result.unstructured, nil
+}
+
+func (s *UnstructuredResourceReferenceStream) fill(ctx context.Context) {
+	s.filled = true
+	for {
+		select {
+		case <-ctx.Done():
+			s.results = append(s.results, unstructuredResult{err: ctx.Err()})
+			return
+		default:
+		}
+		u, err := s.next(ctx)
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			s.results = append(s.results, unstructuredResult{err: err})
+			return
+		}
+		s.rewriter.Add(u)
+		s.results = append(s.results, unstructuredResult{unstructured: u})
+	}
+}
+
+// next recovers from panics in the underlying stream so that a single bad resource does not cause all the resources
+// read so far to be lost.
+func (s *UnstructuredResourceReferenceStream) next(ctx context.Context) (u *unstructured.Unstructured, err error) {
+	defer execution.RecoverWithGenericError(&err)
+	return s.unstructStream.Next(ctx)
+}
@@ -0,0 +1,147 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/referencerewriter"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/stream"
+	dclmetadata "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/schema/dclschemaloader"
+	testservicemappingloader "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/test/servicemappingloader"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestUnstructuredResourceReferenceStream(t *testing.T) {
+	ctx := context.TODO()
+	subscription := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1",
+			"kind":       "PubSubSubscription",
+			"metadata": map[string]interface{}{
+				"name": "my-subscription",
+				"annotations": map[string]interface{}{
+					"cnrm.cloud.google.com/project-id": "my-project",
+				},
+			},
+			"spec": map[string]interface{}{
+				"topicRef": map[string]interface{}{
+					"external": "projects/my-project/topics/my-topic",
+				},
+			},
+		},
+	}
+	// the referenced topic comes after the subscription in the stream
+	topic := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1",
+			"kind":       "PubSubTopic",
+			"metadata": map[string]interface{}{
+				"name": "my-topic",
+				"annotations": map[string]interface{}{
+					"cnrm.cloud.google.com/project-id": "my-project",
+				},
+			},
+		},
+	}
+	// the stream ends at the first error, so the resource after it is never returned
+	unread := topic.DeepCopy()
+	unread.SetName("unread-topic")
+	streamErr := errors.New("error getting resource")
+	results := []NextUnstructuredResult{
+		{Unstructured: subscription},
+		{Unstructured: topic},
+		{Err: streamErr},
+		{Unstructured: unread},
+	}
+	referenceStream := stream.NewUnstructuredResourceReferenceStream(newMockUnstructuredStream(results), newTestReferenceRewriter(t))
+
+	u, err := referenceStream.Next(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	topicRef, _, _ := unstructured.NestedMap(u.Object, "spec", "topicRef")
+	if name := topicRef["name"]; name != "my-topic" || len(topicRef) != 1 {
+		t.Errorf("got topicRef '%v', want a name reference to 'my-topic'", topicRef)
+	}
+	if u, err := referenceStream.Next(ctx); err != nil || u.GetName() != "my-topic" {
+		t.Errorf("got '%v', '%v', want the topic and a nil error", u, err)
+	}
+	if _, err := referenceStream.Next(ctx); err != streamErr {
+		t.Errorf("got error '%v', want '%v'", err, streamErr)
+	}
+	if _, err := referenceStream.Next(ctx); err != io.EOF {
+		t.Errorf("got error '%v', want '%v'", err, io.EOF)
+	}
+}
+
+func TestUnstructuredResourceReferenceStreamMalformedInput(t *testing.T) {
+	ctx := context.TODO()
+	input := `{"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1", "kind": "PubSubTopic", "metadata": {"name": "my-topic"}}
+{"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1", "kind": `
+	referenceStream := stream.NewUnstructuredResourceReferenceStream(newJSONUnstructuredStream(input), newTestReferenceRewriter(t))
+	if u, err := referenceStream.Next(ctx); err != nil || u.GetName() != "my-topic" {
+		t.Fatalf("got '%v', '%v', want the topic and a nil error", u, err)
+	}
+	if _, err := referenceStream.Next(ctx); err == nil || err == io.EOF {
+		t.Errorf("got error '%v', want a decoding error", err)
+	}
+	if _, err := referenceStream.Next(ctx); err != io.EOF {
+		t.Errorf("got error '%v', want '%v'", err, io.EOF)
+	}
+}
+
+func TestUnstructuredResourceReferenceStreamCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	// the input would never end if the context were not checked while reading it
+	referenceStream := stream.NewUnstructuredResourceReferenceStream(newJSONUnstructuredStream(`{"kind": `), newTestReferenceRewriter(t))
+	if _, err := referenceStream.Next(ctx); err != context.Canceled {
+		t.Errorf("got error '%v', want '%v'", err, context.Canceled)
+	}
+}
+
+func newTestReferenceRewriter(t *testing.T) *referencerewriter.Rewriter {
+	dclSchemaLoader, err := dclschemaloader.New()
+	if err != nil {
+		t.Fatalf("error creating DCL schema loader: %v", err)
+	}
+	return referencerewriter.New(testservicemappingloader.New(t), dclmetadata.New(), dclSchemaLoader)
+}
+
+// jsonUnstructuredStream decodes resources from a JSON stream. Like the asset stream, it returns the same error on
+// every call once it has failed to decode its input.
+type jsonUnstructuredStream struct {
+	decoder *json.Decoder
+}
+
+func newJSONUnstructuredStream(input string) *jsonUnstructuredStream {
+	return &jsonUnstructuredStream{decoder: json.NewDecoder(strings.NewReader(input))}
+}
+
+func (s *jsonUnstructuredStream) Next(_ context.Context) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
+	if err := s.decoder.Decode(&u.Object); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
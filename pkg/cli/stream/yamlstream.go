@@ -89,7 +89,7 @@ func (y *YAMLStream) getNext(ctx context.Context) ([]byte, *unstructured.Unstruc
 	unstructured, err := y.unstructuredStream.Next(ctx)
 	if err != nil {
 		if err != io.EOF {
-			err = fmt.Errorf("error getting unstructured: %v", err)
+			err = fmt.Errorf("error getting unstructured: %w", err)
 		}
 		return nil, unstructured, err
 	}
@@ -97,7 +97,12 @@ func (y *YAMLStream) getNext(ctx context.Context) ([]byte, *unstructured.Unstruc
 	delete(unstructured.Object, "status")
 	bytes, err := yaml.Marshal(unstructured.Object)
 	if err != nil {
-		return nil, unstructured, fmt.Errorf("error marshalling unstructured to YAML: %v", err)
+		return nil, unstructured, &ExportError{
+			Stage:    SerializationStage,
+			Kind:     unstructured.GetKind(),
+			Resource: unstructured,
+			Err:      fmt.Errorf("error marshalling unstructured to YAML: %v", err),
+		}
 	}
 	return bytes, unstructured, nil
 }
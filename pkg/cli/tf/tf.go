@@ -34,3 +34,13 @@ func NewProvider(oauth2Token string) (*schema.Provider, error) {
 	}
 	return p, nil
 }
+
+// offlineAccessToken is a placeholder access token, which is never valid, used to configure the provider without
+// looking up credentials.
+const offlineAccessToken = "offline"
+
+// NewOfflineProvider returns a provider which can be used without access to GCP, e.g. for its schemas or to parse
+// import IDs. Any request to GCP made through it fails.
+func NewOfflineProvider() (*schema.Provider, error) {
+	return NewProvider(offlineAccessToken)
+}
@@ -48,6 +48,9 @@ type AccessContextManagerAccessPolicyStatus struct {
 	/* Conditions represent the latest available observations of the
 	   AccessContextManagerAccessPolicy's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   AccessContextManagerAccessPolicy, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Time the AccessPolicy was created in UTC. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* Resource name of the AccessPolicy. Format: {policy_id}. */
@@ -330,6 +330,9 @@ type AccessContextManagerServicePerimeterStatus struct {
 	/* Conditions represent the latest available observations of the
 	   AccessContextManagerServicePerimeter's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   AccessContextManagerServicePerimeter, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Time the AccessPolicy was created in UTC. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller. If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource. */
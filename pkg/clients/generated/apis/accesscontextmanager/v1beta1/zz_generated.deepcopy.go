@@ -135,6 +135,11 @@ func (in *AccessContextManagerAccessLevelStatus) DeepCopyInto(out *AccessContext
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -238,6 +243,11 @@ func (in *AccessContextManagerAccessPolicyStatus) DeepCopyInto(out *AccessContex
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -367,6 +377,11 @@ func (in *AccessContextManagerServicePerimeterStatus) DeepCopyInto(out *AccessCo
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
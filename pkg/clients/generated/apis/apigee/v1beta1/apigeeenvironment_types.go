@@ -60,6 +60,9 @@ type ApigeeEnvironmentStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ApigeeEnvironment's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ApigeeEnvironment, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. Creation time of this environment as milliseconds since epoch. */
 	CreatedAt int `json:"createdAt,omitempty"`
 	/* Output only. Last modification time of this environment as milliseconds since epoch. */
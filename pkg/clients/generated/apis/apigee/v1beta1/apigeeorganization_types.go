@@ -100,6 +100,9 @@ type ApigeeOrganizationStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ApigeeOrganization's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ApigeeOrganization, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. Billing type of the Apigee organization. See (https://cloud.google.com/apigee/pricing). Possible values: BILLING_TYPE_UNSPECIFIED, SUBSCRIPTION, EVALUATION */
 	BillingType string `json:"billingType,omitempty"`
 	/* Output only. Base64-encoded public certificate for the root CA of the Apigee organization. Valid only when (#RuntimeType) is `CLOUD`. */
@@ -137,6 +137,11 @@ func (in *ApigeeEnvironmentStatus) DeepCopyInto(out *ApigeeEnvironmentStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -273,6 +278,11 @@ func (in *ApigeeOrganizationStatus) DeepCopyInto(out *ApigeeOrganizationStatus)
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	if in.Environments != nil {
 		in, out := &in.Environments, &out.Environments
 		*out = make([]string, len(*in))
@@ -80,6 +80,9 @@ type ArtifactRegistryRepositoryStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ArtifactRegistryRepository's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ArtifactRegistryRepository, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The time when the repository was created. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* The name of the repository, for example:
@@ -134,6 +134,11 @@ func (in *ArtifactRegistryRepositoryStatus) DeepCopyInto(out *ArtifactRegistryRe
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
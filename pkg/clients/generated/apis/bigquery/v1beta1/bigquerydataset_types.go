@@ -200,6 +200,9 @@ type BigQueryDatasetStatus struct {
 	/* Conditions represent the latest available observations of the
 	   BigQueryDataset's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   BigQueryDataset, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The time when this dataset was created, in milliseconds since the
 	epoch. */
 	CreationTime int `json:"creationTime,omitempty"`
@@ -260,6 +260,9 @@ type BigQueryTableStatus struct {
 	/* Conditions represent the latest available observations of the
 	   BigQueryTable's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   BigQueryTable, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The time when this table was created, in milliseconds since the epoch. */
 	CreationTime int `json:"creationTime,omitempty"`
 	/* A hash of the resource. */
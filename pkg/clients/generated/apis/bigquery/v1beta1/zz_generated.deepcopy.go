@@ -166,6 +166,11 @@ func (in *BigQueryDatasetStatus) DeepCopyInto(out *BigQueryDatasetStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -299,6 +304,11 @@ func (in *BigQueryJobStatus) DeepCopyInto(out *BigQueryJobStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = make([]JobStatusStatus, len(*in))
@@ -465,6 +475,11 @@ func (in *BigQueryTableStatus) DeepCopyInto(out *BigQueryTableStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -165,6 +165,11 @@ func (in *BigtableAppProfileStatus) DeepCopyInto(out *BigtableAppProfileStatus)
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -287,6 +292,11 @@ func (in *BigtableGCPolicyStatus) DeepCopyInto(out *BigtableGCPolicyStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -412,6 +422,11 @@ func (in *BigtableInstanceStatus) DeepCopyInto(out *BigtableInstanceStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -526,6 +541,11 @@ func (in *BigtableTableStatus) DeepCopyInto(out *BigtableTableStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -232,6 +232,11 @@ func (in *BinaryAuthorizationAttestorStatus) DeepCopyInto(out *BinaryAuthorizati
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	out.UserOwnedDrydockNote = in.UserOwnedDrydockNote
 	return
 }
@@ -378,6 +383,11 @@ func (in *BinaryAuthorizationPolicyStatus) DeepCopyInto(out *BinaryAuthorization
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -192,6 +192,11 @@ func (in *CloudFunctionsFunctionStatus) DeepCopyInto(out *CloudFunctionsFunction
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	out.HttpsTrigger = in.HttpsTrigger
 	out.SourceRepository = in.SourceRepository
 	return
@@ -103,6 +103,9 @@ type CloudIdentityGroupStatus struct {
 	/* Conditions represent the latest available observations of the
 	   CloudIdentityGroup's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   CloudIdentityGroup, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The time when the Group was created. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* Resource name of the Group in the format: groups/{group_id}, where group_id
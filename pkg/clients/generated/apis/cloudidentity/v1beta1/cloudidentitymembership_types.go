@@ -119,6 +119,9 @@ type CloudIdentityMembershipStatus struct {
 	/* Conditions represent the latest available observations of the
 	   CloudIdentityMembership's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   CloudIdentityMembership, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. The time when the `Membership` was created. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* Output only. Delivery setting associated with the membership. Possible values: DELIVERY_SETTING_UNSPECIFIED, ALL_MAIL, DIGEST, DAILY, NONE, DISABLED */
@@ -142,6 +142,11 @@ func (in *CloudIdentityGroupStatus) DeepCopyInto(out *CloudIdentityGroupStatus)
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -259,6 +264,11 @@ func (in *CloudIdentityMembershipStatus) DeepCopyInto(out *CloudIdentityMembersh
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	out.DisplayName = in.DisplayName
 	return
 }
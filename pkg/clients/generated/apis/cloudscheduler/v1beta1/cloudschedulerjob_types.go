@@ -225,6 +225,9 @@ type CloudSchedulerJobStatus struct {
 	/* Conditions represent the latest available observations of the
 	   CloudSchedulerJob's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   CloudSchedulerJob, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/*  */
 	AppEngineHttpTarget JobAppEngineHttpTargetStatus `json:"appEngineHttpTarget,omitempty"`
 	/* Output only. The time the last job attempt started. */
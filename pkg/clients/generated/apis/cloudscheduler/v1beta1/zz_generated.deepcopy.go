@@ -159,6 +159,11 @@ func (in *CloudSchedulerJobStatus) DeepCopyInto(out *CloudSchedulerJobStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	out.AppEngineHttpTarget = in.AppEngineHttpTarget
 	in.Status.DeepCopyInto(&out.Status)
 	return
@@ -113,6 +113,9 @@ type ComputeAddressStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeAddress's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeAddress, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Creation timestamp in RFC3339 text format. */
 	CreationTimestamp string `json:"creationTimestamp,omitempty"`
 	/* The fingerprint used for optimistic locking of this resource.  Used
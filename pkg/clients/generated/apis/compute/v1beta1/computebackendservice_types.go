@@ -793,6 +793,9 @@ type ComputeBackendServiceStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeBackendService's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeBackendService, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Creation timestamp in RFC3339 text format. */
 	CreationTimestamp string `json:"creationTimestamp,omitempty"`
 	/* Fingerprint of this resource. A hash of the contents stored in this
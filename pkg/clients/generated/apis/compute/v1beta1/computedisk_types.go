@@ -236,6 +236,9 @@ type ComputeDiskStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeDisk's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeDisk, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Creation timestamp in RFC3339 text format. */
 	CreationTimestamp string `json:"creationTimestamp,omitempty"`
 	/* The fingerprint used for optimistic locking of this resource.  Used
@@ -60,6 +60,9 @@ type ComputeFirewallPolicyStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeFirewallPolicy's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeFirewallPolicy, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Creation timestamp in RFC3339 text format. */
 	CreationTimestamp string `json:"creationTimestamp,omitempty"`
 	/* Fingerprint of the resource. This field is used internally during updates of this resource. */
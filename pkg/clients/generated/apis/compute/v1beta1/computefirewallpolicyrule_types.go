@@ -98,6 +98,9 @@ type ComputeFirewallPolicyRuleStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeFirewallPolicyRule's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeFirewallPolicyRule, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Type of the resource. Always `compute#firewallPolicyRule` for firewall policy rules */
 	Kind string `json:"kind,omitempty"`
 	/* ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller. If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource. */
@@ -254,6 +254,9 @@ type ComputeForwardingRuleStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeForwardingRule's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeForwardingRule, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* [Output Only] Creation timestamp in [RFC3339](https://www.ietf.org/rfc/rfc3339.txt) text format. */
 	CreationTimestamp string `json:"creationTimestamp,omitempty"`
 	/* Used internally during label updates. */
@@ -113,6 +113,9 @@ type ComputeImageStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeImage's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeImage, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Size of the image tar.gz archive stored in Google Cloud Storage (in
 	bytes). */
 	ArchiveSizeBytes int `json:"archiveSizeBytes,omitempty"`
@@ -463,6 +463,9 @@ type ComputeInstanceStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeInstance's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeInstance, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The CPU platform used by this instance. */
 	CpuPlatform string `json:"cpuPlatform,omitempty"`
 	/* Current status of the instance. */
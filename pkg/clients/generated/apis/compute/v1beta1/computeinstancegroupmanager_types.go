@@ -341,6 +341,9 @@ type ComputeInstanceGroupManagerStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeInstanceGroupManager's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeInstanceGroupManager, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The creation timestamp for this managed instance group in \[RFC3339\](https://www.ietf.org/rfc/rfc3339.txt) text format. */
 	CreationTimestamp string `json:"creationTimestamp,omitempty"`
 	/* [Output Only] The list of instance actions and the number of instances in this managed instance group that are scheduled for each of those actions. */
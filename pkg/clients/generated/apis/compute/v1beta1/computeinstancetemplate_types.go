@@ -399,6 +399,9 @@ type ComputeInstanceTemplateStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeInstanceTemplate's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeInstanceTemplate, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The unique fingerprint of the metadata. */
 	MetadataFingerprint string `json:"metadataFingerprint,omitempty"`
 	/* ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller. If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource. */
@@ -139,6 +139,9 @@ type ComputeInterconnectAttachmentStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeInterconnectAttachment's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeInterconnectAttachment, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* IPv4 address + prefix length to be configured on Cloud Router
 	Interface for this interconnect attachment. */
 	CloudRouterIpAddress string `json:"cloudRouterIpAddress,omitempty"`
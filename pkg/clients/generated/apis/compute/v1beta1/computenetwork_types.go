@@ -90,6 +90,9 @@ type ComputeNetworkStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeNetwork's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeNetwork, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The gateway address for default routing out of the network. This value
 	is selected by GCP. */
 	GatewayIpv4 string `json:"gatewayIpv4,omitempty"`
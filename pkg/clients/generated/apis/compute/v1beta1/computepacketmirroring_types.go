@@ -144,6 +144,9 @@ type ComputePacketMirroringStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputePacketMirroring's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputePacketMirroring, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/*  */
 	CollectorIlb PacketmirroringCollectorIlbStatus `json:"collectorIlb,omitempty"`
 	/* Output only. The unique identifier for the resource. This identifier is defined by the server. */
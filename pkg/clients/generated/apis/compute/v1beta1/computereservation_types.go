@@ -116,6 +116,9 @@ type ComputeReservationStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeReservation's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeReservation, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Full or partial URL to a parent commitment. This field displays for
 	reservations that are tied to a commitment. */
 	Commitment string `json:"commitment,omitempty"`
@@ -99,6 +99,9 @@ type ComputeRouteStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeRoute's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeRoute, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* URL to a Network that should handle matching packets. */
 	NextHopNetwork string `json:"nextHopNetwork,omitempty"`
 	/* ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller. If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource. */
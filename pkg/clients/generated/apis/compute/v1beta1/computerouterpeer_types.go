@@ -160,6 +160,9 @@ type ComputeRouterPeerStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeRouterPeer's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeRouterPeer, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The resource that configures and manages this BGP peer.
 
 	* 'MANAGED_BY_USER' is the default value and can be managed by
@@ -216,6 +216,9 @@ type ComputeSecurityPolicyStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeSecurityPolicy's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeSecurityPolicy, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Fingerprint of this resource. */
 	Fingerprint string `json:"fingerprint,omitempty"`
 	/* ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller. If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource. */
@@ -104,6 +104,9 @@ type ComputeServiceAttachmentStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeServiceAttachment's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeServiceAttachment, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* An array of connections for all the consumers connected to this service attachment. */
 	ConnectedEndpoints []ServiceattachmentConnectedEndpointsStatus `json:"connectedEndpoints,omitempty"`
 	/* Fingerprint of this resource. This field is used internally during updates of this resource. */
@@ -129,6 +129,9 @@ type ComputeSnapshotStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeSnapshot's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeSnapshot, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Creation timestamp in RFC3339 text format. */
 	CreationTimestamp string `json:"creationTimestamp,omitempty"`
 	/* Size of the snapshot, specified in GB. */
@@ -86,6 +86,9 @@ type ComputeSSLCertificateStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeSSLCertificate's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeSSLCertificate, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The unique identifier for the resource. */
 	CertificateId int `json:"certificateId,omitempty"`
 	/* Creation timestamp in RFC3339 text format. */
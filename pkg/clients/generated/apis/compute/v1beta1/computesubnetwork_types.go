@@ -158,6 +158,9 @@ type ComputeSubnetworkStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeSubnetwork's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeSubnetwork, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Creation timestamp in RFC3339 text format. */
 	CreationTimestamp string `json:"creationTimestamp,omitempty"`
 	/* The range of external IPv6 addresses that are owned by this subnetwork. */
@@ -67,6 +67,9 @@ type ComputeTargetGRPCProxyStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeTargetGRPCProxy's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeTargetGRPCProxy, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Creation timestamp in RFC3339 text format. */
 	CreationTimestamp string `json:"creationTimestamp,omitempty"`
 	/* Fingerprint of this resource. A hash of the contents stored in
@@ -55,6 +55,9 @@ type ComputeTargetVPNGatewayStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeTargetVPNGateway's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeTargetVPNGateway, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Creation timestamp in RFC3339 text format. */
 	CreationTimestamp string `json:"creationTimestamp,omitempty"`
 	/* The unique identifier for the resource. */
@@ -925,6 +925,9 @@ type ComputeURLMapStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeURLMap's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeURLMap, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Creation timestamp in RFC3339 text format. */
 	CreationTimestamp string `json:"creationTimestamp,omitempty"`
 	/* Fingerprint of this resource. This field is used internally during
@@ -130,6 +130,9 @@ type ComputeVPNTunnelStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ComputeVPNTunnel's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ComputeVPNTunnel, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Creation timestamp in RFC3339 text format. */
 	CreationTimestamp string `json:"creationTimestamp,omitempty"`
 	/* Detailed status message for the VPN tunnel. */
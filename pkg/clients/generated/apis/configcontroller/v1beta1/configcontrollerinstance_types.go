@@ -127,6 +127,9 @@ type ConfigControllerInstanceStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ConfigControllerInstance's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ConfigControllerInstance, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. KrmApiHost GCP self link used for identifying the underlying endpoint (GKE cluster currently). */
 	GkeResourceLink string `json:"gkeResourceLink,omitempty"`
 	/* ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller. If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource. */
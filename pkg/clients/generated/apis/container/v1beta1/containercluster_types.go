@@ -1002,6 +1002,9 @@ type ContainerClusterStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ContainerCluster's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ContainerCluster, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The IP address of this cluster's Kubernetes master. */
 	Endpoint string `json:"endpoint,omitempty"`
 	/* The fingerprint of the set of labels for this cluster. */
@@ -371,6 +371,9 @@ type ContainerNodePoolStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ContainerNodePool's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ContainerNodePool, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The resource URLs of the managed instance groups associated with this node pool. */
 	InstanceGroupUrls []string `json:"instanceGroupUrls,omitempty"`
 	/* List of instance group URLs which have been assigned to this node pool. */
@@ -418,6 +418,9 @@ type ContainerAnalysisNoteStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ContainerAnalysisNote's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ContainerAnalysisNote, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. The time this note was created. This field can be used as a filter in list requests. */
 	CreateTime string `json:"createTime,omitempty"`
 	/*  */
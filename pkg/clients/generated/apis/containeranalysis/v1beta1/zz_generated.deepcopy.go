@@ -181,6 +181,11 @@ func (in *ContainerAnalysisNoteStatus) DeepCopyInto(out *ContainerAnalysisNoteSt
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	out.Image = in.Image
 	return
 }
@@ -64,6 +64,9 @@ type DataCatalogPolicyTagStatus struct {
 	/* Conditions represent the latest available observations of the
 	   DataCatalogPolicyTag's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   DataCatalogPolicyTag, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Resource names of child policy tags of this policy tag. */
 	ChildPolicyTags []string `json:"childPolicyTags,omitempty"`
 	/* Resource name of this policy tag, whose format is:
@@ -69,6 +69,9 @@ type DataCatalogTaxonomyStatus struct {
 	/* Conditions represent the latest available observations of the
 	   DataCatalogTaxonomy's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   DataCatalogTaxonomy, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Resource name of this taxonomy, whose format is:
 	"projects/{project}/locations/{region}/taxonomies/{taxonomy}". */
 	Name string `json:"name,omitempty"`
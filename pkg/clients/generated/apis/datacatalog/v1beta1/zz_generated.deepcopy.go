@@ -130,6 +130,11 @@ func (in *DataCatalogPolicyTagStatus) DeepCopyInto(out *DataCatalogPolicyTagStat
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	if in.ChildPolicyTags != nil {
 		in, out := &in.ChildPolicyTags, &out.ChildPolicyTags
 		*out = make([]string, len(*in))
@@ -254,6 +259,11 @@ func (in *DataCatalogTaxonomyStatus) DeepCopyInto(out *DataCatalogTaxonomyStatus
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -55,6 +55,9 @@ type DataflowFlexTemplateJobStatus struct {
 	/* Conditions represent the latest available observations of the
 	   DataflowFlexTemplateJob's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   DataflowFlexTemplateJob, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/*  */
 	JobId string `json:"jobId,omitempty"`
 	/* ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller. If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource. */
@@ -112,6 +112,9 @@ type DataFusionInstanceStatus struct {
 	/* Conditions represent the latest available observations of the
 	   DataFusionInstance's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   DataFusionInstance, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. Endpoint on which the REST APIs is accessible. */
 	ApiEndpoint string `json:"apiEndpoint,omitempty"`
 	/* Available versions that the instance can be upgraded to. */
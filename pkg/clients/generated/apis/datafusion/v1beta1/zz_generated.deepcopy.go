@@ -171,6 +171,11 @@ func (in *DataFusionInstanceStatus) DeepCopyInto(out *DataFusionInstanceStatus)
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	if in.AvailableVersion != nil {
 		in, out := &in.AvailableVersion, &out.AvailableVersion
 		*out = make([]InstanceAvailableVersionStatus, len(*in))
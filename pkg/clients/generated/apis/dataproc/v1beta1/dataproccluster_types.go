@@ -691,6 +691,9 @@ type DataprocClusterStatus struct {
 	/* Conditions represent the latest available observations of the
 	   DataprocCluster's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   DataprocCluster, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. A cluster UUID (Unique Universal Identifier). Dataproc generates this value when it creates the cluster. */
 	ClusterUuid string `json:"clusterUuid,omitempty"`
 	/*  */
@@ -1551,6 +1551,11 @@ func (in *DataprocAutoscalingPolicyStatus) DeepCopyInto(out *DataprocAutoscaling
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1669,6 +1674,11 @@ func (in *DataprocClusterStatus) DeepCopyInto(out *DataprocClusterStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	in.Config.DeepCopyInto(&out.Config)
 	in.Metrics.DeepCopyInto(&out.Metrics)
 	out.Status = in.Status
@@ -1805,6 +1815,11 @@ func (in *DataprocWorkflowTemplateStatus) DeepCopyInto(out *DataprocWorkflowTemp
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	in.Placement.DeepCopyInto(&out.Placement)
 	return
 }
@@ -689,6 +689,9 @@ type DLPDeidentifyTemplateStatus struct {
 	/* Conditions represent the latest available observations of the
 	   DLPDeidentifyTemplate's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   DLPDeidentifyTemplate, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. The creation timestamp of an inspectTemplate. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* Output only. The geographic location where this resource is stored. */
@@ -655,6 +655,9 @@ type DLPJobTriggerStatus struct {
 	/* Conditions represent the latest available observations of the
 	   DLPJobTrigger's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   DLPJobTrigger, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. The creation timestamp of a triggeredJob. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* Output only. A stream of errors encountered when the trigger was activated. Repeated errors may result in the JobTrigger automatically being paused. Will return the last 100 errors. Whenever the JobTrigger is modified this list will be cleared. */
@@ -149,6 +149,11 @@ func (in *DLPDeidentifyTemplateStatus) DeepCopyInto(out *DLPDeidentifyTemplateSt
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -282,6 +287,11 @@ func (in *DLPInspectTemplateStatus) DeepCopyInto(out *DLPInspectTemplateStatus)
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -409,6 +419,11 @@ func (in *DLPJobTriggerStatus) DeepCopyInto(out *DLPJobTriggerStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	if in.Errors != nil {
 		in, out := &in.Errors, &out.Errors
 		*out = make([]JobtriggerErrorsStatus, len(*in))
@@ -559,6 +574,11 @@ func (in *DLPStoredInfoTypeStatus) DeepCopyInto(out *DLPStoredInfoTypeStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -193,6 +193,9 @@ type DNSManagedZoneStatus struct {
 	/* Conditions represent the latest available observations of the
 	   DNSManagedZone's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   DNSManagedZone, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The time that this resource was created on the server.
 	This is in RFC3339 text format. */
 	CreationTime string `json:"creationTime,omitempty"`
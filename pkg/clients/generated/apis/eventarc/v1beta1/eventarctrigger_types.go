@@ -151,6 +151,9 @@ type EventarcTriggerStatus struct {
 	/* Conditions represent the latest available observations of the
 	   EventarcTrigger's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   EventarcTrigger, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. The creation time. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* Output only. This checksum is computed by the server based on the value of other fields, and may be sent only on create requests to ensure the client has an up-to-date value before proceeding. */
@@ -143,6 +143,11 @@ func (in *EventarcTriggerStatus) DeepCopyInto(out *EventarcTriggerStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	if in.ResourceConditions != nil {
 		in, out := &in.ResourceConditions, &out.ResourceConditions
 		*out = make(map[string]string, len(*in))
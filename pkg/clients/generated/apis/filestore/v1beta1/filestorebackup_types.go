@@ -61,6 +61,9 @@ type FilestoreBackupStatus struct {
 	/* Conditions represent the latest available observations of the
 	   FilestoreBackup's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   FilestoreBackup, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. Capacity of the source file share when the backup was created. */
 	CapacityGb int `json:"capacityGb,omitempty"`
 	/* Output only. The time when the backup was created. */
@@ -125,6 +125,9 @@ type FilestoreInstanceStatus struct {
 	/* Conditions represent the latest available observations of the
 	   FilestoreInstance's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   FilestoreInstance, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. The time when the instance was created. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* Server-specified ETag for the instance resource to prevent simultaneous updates from overwriting each other. */
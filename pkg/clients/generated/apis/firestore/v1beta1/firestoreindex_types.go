@@ -76,6 +76,9 @@ type FirestoreIndexStatus struct {
 	/* Conditions represent the latest available observations of the
 	   FirestoreIndex's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   FirestoreIndex, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* A server defined name for this index. Format:
 	'projects/{{project}}/databases/{{database}}/collectionGroups/{{collection}}/indexes/{{server_generated_id}}'. */
 	Name string `json:"name,omitempty"`
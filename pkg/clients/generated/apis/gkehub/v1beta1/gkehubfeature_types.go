@@ -85,6 +85,9 @@ type GKEHubFeatureStatus struct {
 	/* Conditions represent the latest available observations of the
 	   GKEHubFeature's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   GKEHubFeature, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. When the Feature resource was created. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* Output only. When the Feature resource was deleted. */
@@ -175,6 +175,9 @@ type GKEHubMembershipStatus struct {
 	/* Conditions represent the latest available observations of the
 	   GKEHubMembership's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   GKEHubMembership, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/*  */
 	Authority MembershipAuthorityStatus `json:"authority,omitempty"`
 	/* Output only. When the Membership was created. */
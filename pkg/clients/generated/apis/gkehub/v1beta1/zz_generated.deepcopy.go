@@ -536,6 +536,11 @@ func (in *GKEHubFeatureMembershipStatus) DeepCopyInto(out *GKEHubFeatureMembersh
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -584,6 +589,11 @@ func (in *GKEHubFeatureStatus) DeepCopyInto(out *GKEHubFeatureStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	out.ResourceState = in.ResourceState
 	out.State = in.State
 	return
@@ -714,6 +724,11 @@ func (in *GKEHubMembershipStatus) DeepCopyInto(out *GKEHubMembershipStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	out.Authority = in.Authority
 	in.Endpoint.DeepCopyInto(&out.Endpoint)
 	out.State = in.State
@@ -59,6 +59,9 @@ type IAMCustomRoleStatus struct {
 	/* Conditions represent the latest available observations of the
 	   IAMCustomRole's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   IAMCustomRole, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The current deleted state of the role. */
 	Deleted bool `json:"deleted,omitempty"`
 	/* The full name of the role. */
@@ -67,6 +67,9 @@ type IAMWorkforcePoolStatus struct {
 	/* Conditions represent the latest available observations of the
 	   IAMWorkforcePool's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   IAMWorkforcePool, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller. If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource. */
 	ObservedGeneration int `json:"observedGeneration,omitempty"`
 	/* Output only. The resource name of the pool. Format: `locations/{location}/workforcePools/{workforce_pool_id}` */
@@ -517,6 +517,9 @@ type IdentityPlatformConfigStatus struct {
 	/* Conditions represent the latest available observations of the
 	   IdentityPlatformConfig's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   IdentityPlatformConfig, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/*  */
 	Client ConfigClientStatus `json:"client,omitempty"`
 	/*  */
@@ -102,6 +102,9 @@ type IdentityPlatformTenantOAuthIDPConfigStatus struct {
 	/* Conditions represent the latest available observations of the
 	   IdentityPlatformTenantOAuthIDPConfig's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   IdentityPlatformTenantOAuthIDPConfig, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller. If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource. */
 	ObservedGeneration int `json:"observedGeneration,omitempty"`
 }
@@ -1133,6 +1133,11 @@ func (in *IdentityPlatformConfigStatus) DeepCopyInto(out *IdentityPlatformConfig
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	out.Client = in.Client
 	out.Notification = in.Notification
 	out.SignIn = in.SignIn
@@ -1269,6 +1274,11 @@ func (in *IdentityPlatformOAuthIDPConfigStatus) DeepCopyInto(out *IdentityPlatfo
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1464,6 +1474,11 @@ func (in *IdentityPlatformTenantOAuthIDPConfigStatus) DeepCopyInto(out *Identity
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1543,6 +1558,11 @@ func (in *IdentityPlatformTenantStatus) DeepCopyInto(out *IdentityPlatformTenant
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -56,6 +56,22 @@ type Condition struct {
 	Type string `json:"type,omitempty"`
 }
 
+type ReconcileHistoryEntry struct {
+	// Time at which the reconcile finished.
+	Timestamp string `json:"timestamp,omitempty"`
+
+	// Unique, one-word, CamelCase reason for the outcome of the reconcile
+	// (e.g. UpToDate, UpdateFailed, DependencyNotReady).
+	Reason string `json:"reason,omitempty"`
+
+	// Duration of the reconcile (e.g. "1.5s").
+	Duration string `json:"duration,omitempty"`
+
+	// GCPMutated is true if the reconcile successfully created, updated or
+	// deleted the underlying GCP resource.
+	GCPMutated bool `json:"gcpMutated,omitempty"`
+}
+
 type ResourceRef struct {
 	/* The external name of the referenced resource */
 	External string `json:"external,omitempty"`
@@ -49,6 +49,9 @@ type KMSKeyRingStatus struct {
 	/* Conditions represent the latest available observations of the
 	   KMSKeyRing's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   KMSKeyRing, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller. If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource. */
 	ObservedGeneration int `json:"observedGeneration,omitempty"`
 	/* The self link of the created KeyRing in the format projects/{project}/locations/{location}/keyRings/{name}. */
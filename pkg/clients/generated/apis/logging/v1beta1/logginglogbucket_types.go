@@ -76,6 +76,9 @@ type LoggingLogBucketStatus struct {
 	/* Conditions represent the latest available observations of the
 	   LoggingLogBucket's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   LoggingLogBucket, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. The creation timestamp of the bucket. This is not set for any of the default buckets. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* Output only. The bucket lifecycle state. Possible values: LIFECYCLE_STATE_UNSPECIFIED, ACTIVE, DELETE_REQUESTED */
@@ -72,6 +72,9 @@ type LoggingLogExclusionStatus struct {
 	/* Conditions represent the latest available observations of the
 	   LoggingLogExclusion's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   LoggingLogExclusion, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. The creation timestamp of the exclusion. This field may not be present for older exclusions. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller. If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource. */
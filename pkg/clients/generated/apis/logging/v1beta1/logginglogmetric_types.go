@@ -191,6 +191,9 @@ type LoggingLogMetricStatus struct {
 	/* Conditions represent the latest available observations of the
 	   LoggingLogMetric's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   LoggingLogMetric, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. The creation timestamp of the metric. This field may not be present for older metrics. */
 	CreateTime string `json:"createTime,omitempty"`
 	/*  */
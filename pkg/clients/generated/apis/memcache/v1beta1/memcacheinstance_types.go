@@ -200,6 +200,9 @@ type MemcacheInstanceStatus struct {
 	/* Conditions represent the latest available observations of the
 	   MemcacheInstance's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   MemcacheInstance, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Creation timestamp in RFC3339 text format. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* Endpoint for Discovery API. */
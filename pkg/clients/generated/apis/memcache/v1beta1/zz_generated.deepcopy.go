@@ -317,6 +317,11 @@ func (in *MemcacheInstanceStatus) DeepCopyInto(out *MemcacheInstanceStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	if in.MaintenanceSchedule != nil {
 		in, out := &in.MaintenanceSchedule, &out.MaintenanceSchedule
 		*out = make([]InstanceMaintenanceScheduleStatus, len(*in))
@@ -563,6 +563,9 @@ type MonitoringAlertPolicyStatus struct {
 	/* Conditions represent the latest available observations of the
 	   MonitoringAlertPolicy's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   MonitoringAlertPolicy, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* A read-only record of the creation of the alerting policy.
 	If provided in a call to create or update, this field will
 	be ignored. */
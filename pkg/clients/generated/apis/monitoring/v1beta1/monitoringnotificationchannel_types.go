@@ -128,6 +128,9 @@ type MonitoringNotificationChannelStatus struct {
 	/* Conditions represent the latest available observations of the
 	   MonitoringNotificationChannel's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   MonitoringNotificationChannel, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The full REST resource name for this channel. The syntax is:
 	projects/[PROJECT_ID]/notificationChannels/[CHANNEL_ID]
 	The [CHANNEL_ID] is automatically assigned by the server on creation. */
@@ -280,6 +280,9 @@ type MonitoringServiceLevelObjectiveStatus struct {
 	/* Conditions represent the latest available observations of the
 	   MonitoringServiceLevelObjective's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   MonitoringServiceLevelObjective, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Time stamp of the `Create` or most recent `Update` command on this `Slo`. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* Time stamp of the `Update` or `Delete` command that made this no longer a current `Slo`. This field is not populated in `ServiceLevelObjective`s returned from calls to `GetServiceLevelObjective` and `ListServiceLevelObjectives`, because it is always empty in the current version. It is populated in `ServiceLevelObjective`s representing previous versions in the output of `ListServiceLevelObjectiveVersions`. Because all old configuration versions are stored, `Update` operations mark the obsoleted version as deleted. */
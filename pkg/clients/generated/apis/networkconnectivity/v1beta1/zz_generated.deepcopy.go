@@ -141,6 +141,11 @@ func (in *NetworkConnectivityHubStatus) DeepCopyInto(out *NetworkConnectivityHub
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	if in.RoutingVpcs != nil {
 		in, out := &in.RoutingVpcs, &out.RoutingVpcs
 		*out = make([]HubRoutingVpcsStatus, len(*in))
@@ -271,6 +276,11 @@ func (in *NetworkConnectivitySpokeStatus) DeepCopyInto(out *NetworkConnectivityS
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
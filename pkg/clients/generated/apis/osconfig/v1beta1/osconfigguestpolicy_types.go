@@ -417,6 +417,9 @@ type OSConfigGuestPolicyStatus struct {
 	/* Conditions represent the latest available observations of the
 	   OSConfigGuestPolicy's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   OSConfigGuestPolicy, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. Time this GuestPolicy was created. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* The etag for this GuestPolicy. If this is provided on update, it must match the server's etag. */
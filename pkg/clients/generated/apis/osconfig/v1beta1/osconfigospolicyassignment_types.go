@@ -428,6 +428,9 @@ type OSConfigOSPolicyAssignmentStatus struct {
 	/* Conditions represent the latest available observations of the
 	   OSConfigOSPolicyAssignment's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   OSConfigOSPolicyAssignment, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. Indicates that this revision has been successfully rolled out in this zone and new VMs will be assigned OS policies from this revision. For a given OS policy assignment, there is only one revision with a value of `true` for this field. */
 	Baseline bool `json:"baseline,omitempty"`
 	/* Output only. Indicates that this revision deletes the OS policy assignment. */
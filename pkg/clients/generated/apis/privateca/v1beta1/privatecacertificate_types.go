@@ -545,6 +545,9 @@ type PrivateCACertificateStatus struct {
 	/* Conditions represent the latest available observations of the
 	   PrivateCACertificate's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   PrivateCACertificate, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. A structured description of the issued X.509 certificate. */
 	CertificateDescription CertificateCertificateDescriptionStatus `json:"certificateDescription,omitempty"`
 	/* Output only. The time at which this Certificate was created. */
@@ -566,6 +566,9 @@ type PrivateCACertificateAuthorityStatus struct {
 	/* Conditions represent the latest available observations of the
 	   PrivateCACertificateAuthority's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   PrivateCACertificateAuthority, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. URLs for accessing content published by this CA, such as the CA certificate and CRLs. */
 	AccessUrls CertificateauthorityAccessUrlsStatus `json:"accessUrls,omitempty"`
 	/* Output only. A structured description of this CertificateAuthority's CA certificate and its issuers. Ordered as self-to-root. */
@@ -2875,6 +2875,11 @@ func (in *PrivateCACAPoolStatus) DeepCopyInto(out *PrivateCACAPoolStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -3015,6 +3020,11 @@ func (in *PrivateCACertificateAuthorityStatus) DeepCopyInto(out *PrivateCACertif
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	in.AccessUrls.DeepCopyInto(&out.AccessUrls)
 	if in.CaCertificateDescriptions != nil {
 		in, out := &in.CaCertificateDescriptions, &out.CaCertificateDescriptions
@@ -3132,6 +3142,11 @@ func (in *PrivateCACertificateStatus) DeepCopyInto(out *PrivateCACertificateStat
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	in.CertificateDescription.DeepCopyInto(&out.CertificateDescription)
 	if in.PemCertificateChain != nil {
 		in, out := &in.PemCertificateChain, &out.PemCertificateChain
@@ -3263,6 +3278,11 @@ func (in *PrivateCACertificateTemplateStatus) DeepCopyInto(out *PrivateCACertifi
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -118,6 +118,9 @@ type RecaptchaEnterpriseKeyStatus struct {
 	/* Conditions represent the latest available observations of the
 	   RecaptchaEnterpriseKey's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   RecaptchaEnterpriseKey, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The timestamp corresponding to the creation of this Key. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller. If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource. */
@@ -254,6 +254,11 @@ func (in *RecaptchaEnterpriseKeyStatus) DeepCopyInto(out *RecaptchaEnterpriseKey
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
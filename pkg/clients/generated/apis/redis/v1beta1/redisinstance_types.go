@@ -313,6 +313,9 @@ type RedisInstanceStatus struct {
 	/* Conditions represent the latest available observations of the
 	   RedisInstance's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   RedisInstance, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The time the instance was created in RFC3339 UTC "Zulu" format,
 	accurate to nanoseconds. */
 	CreateTime string `json:"createTime,omitempty"`
@@ -61,6 +61,9 @@ type FolderStatus struct {
 	/* Conditions represent the latest available observations of the
 	   Folder's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   Folder, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Timestamp when the Folder was created. Assigned by the server. A timestamp in RFC3339 UTC "Zulu" format, accurate to nanoseconds. Example: "2014-10-02T15:01:23.045123456Z". */
 	CreateTime string `json:"createTime,omitempty"`
 	/* The folder id from the name "folders/{folder_id}". */
@@ -70,6 +70,9 @@ type ResourceManagerLienStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ResourceManagerLien's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ResourceManagerLien, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Time of creation. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* A system-generated unique identifier for this Lien. */
@@ -348,6 +348,9 @@ type RunServiceStatus struct {
 	/* Conditions represent the latest available observations of the
 	   RunService's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   RunService, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. The creation time. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* Output only. Email address of the authenticated creator. */
@@ -115,6 +115,9 @@ type SecretManagerSecretStatus struct {
 	/* Conditions represent the latest available observations of the
 	   SecretManagerSecret's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   SecretManagerSecret, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The time at which the Secret was created. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* The resource name of the Secret. Format:
@@ -71,6 +71,9 @@ type SecretManagerSecretVersionStatus struct {
 	/* Conditions represent the latest available observations of the
 	   SecretManagerSecretVersion's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   SecretManagerSecretVersion, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The time at which the Secret was created. */
 	CreateTime string `json:"createTime,omitempty"`
 	/* The time at which the Secret was destroyed. Only present if state is DESTROYED. */
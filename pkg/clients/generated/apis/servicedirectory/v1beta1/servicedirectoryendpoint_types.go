@@ -68,6 +68,9 @@ type ServiceDirectoryEndpointStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ServiceDirectoryEndpoint's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ServiceDirectoryEndpoint, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The resource name for the endpoint in the format
 	'projects/* /locations/* /namespaces/* /services/* /endpoints/*'. */
 	Name string `json:"name,omitempty"`
@@ -53,6 +53,9 @@ type ServiceDirectoryNamespaceStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ServiceDirectoryNamespace's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ServiceDirectoryNamespace, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The resource name for the namespace
 	in the format 'projects/* /locations/* /namespaces/*'. */
 	Name string `json:"name,omitempty"`
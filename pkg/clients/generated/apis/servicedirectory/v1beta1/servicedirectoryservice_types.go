@@ -48,6 +48,9 @@ type ServiceDirectoryServiceStatus struct {
 	/* Conditions represent the latest available observations of the
 	   ServiceDirectoryService's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   ServiceDirectoryService, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The resource name for the service in the
 	format 'projects/* /locations/* /namespaces/* /services/*'. */
 	Name string `json:"name,omitempty"`
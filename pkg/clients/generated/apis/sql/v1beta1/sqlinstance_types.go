@@ -444,6 +444,9 @@ type SQLInstanceStatus struct {
 	/* Conditions represent the latest available observations of the
 	   SQLInstance's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   SQLInstance, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Available Maintenance versions. */
 	AvailableMaintenanceVersions []string `json:"availableMaintenanceVersions,omitempty"`
 	/* The connection name of the instance to be used in connection strings. For example, when connecting with Cloud SQL Proxy. */
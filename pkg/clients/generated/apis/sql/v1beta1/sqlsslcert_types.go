@@ -51,6 +51,9 @@ type SQLSSLCertStatus struct {
 	/* Conditions represent the latest available observations of the
 	   SQLSSLCert's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   SQLSSLCert, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The actual certificate data for this client certificate. */
 	Cert string `json:"cert,omitempty"`
 	/* The serial number extracted from the certificate data. */
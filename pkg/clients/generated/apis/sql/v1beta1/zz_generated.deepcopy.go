@@ -766,6 +766,11 @@ func (in *SQLDatabaseStatus) DeepCopyInto(out *SQLDatabaseStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -905,6 +910,11 @@ func (in *SQLInstanceStatus) DeepCopyInto(out *SQLInstanceStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	if in.AvailableMaintenanceVersions != nil {
 		in, out := &in.AvailableMaintenanceVersions, &out.AvailableMaintenanceVersions
 		*out = make([]string, len(*in))
@@ -1020,6 +1030,11 @@ func (in *SQLSSLCertStatus) DeepCopyInto(out *SQLSSLCertStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1144,6 +1159,11 @@ func (in *SQLUserStatus) DeepCopyInto(out *SQLUserStatus) {
 		*out = make([]v1alpha1.Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReconcileHistory != nil {
+		in, out := &in.ReconcileHistory, &out.ReconcileHistory
+		*out = make([]v1alpha1.ReconcileHistoryEntry, len(*in))
+		copy(*out, *in)
+	}
 	if in.SqlServerUserDetails != nil {
 		in, out := &in.SqlServerUserDetails, &out.SqlServerUserDetails
 		*out = make([]UserSqlServerUserDetailsStatus, len(*in))
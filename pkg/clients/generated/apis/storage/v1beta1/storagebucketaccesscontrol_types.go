@@ -65,6 +65,9 @@ type StorageBucketAccessControlStatus struct {
 	/* Conditions represent the latest available observations of the
 	   StorageBucketAccessControl's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   StorageBucketAccessControl, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* The domain associated with the entity. */
 	Domain string `json:"domain,omitempty"`
 	/* The email address associated with the entity. */
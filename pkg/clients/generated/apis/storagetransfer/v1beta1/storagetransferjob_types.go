@@ -312,6 +312,9 @@ type StorageTransferJobStatus struct {
 	/* Conditions represent the latest available observations of the
 	   StorageTransferJob's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   StorageTransferJob, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* When the Transfer Job was created. */
 	CreationTime string `json:"creationTime,omitempty"`
 	/* When the Transfer Job was deleted. */
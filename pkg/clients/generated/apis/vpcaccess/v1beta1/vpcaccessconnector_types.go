@@ -93,6 +93,9 @@ type VPCAccessConnectorStatus struct {
 	/* Conditions represent the latest available observations of the
 	   VPCAccessConnector's current state. */
 	Conditions []v1alpha1.Condition `json:"conditions,omitempty"`
+	/* ReconcileHistory lists the outcomes of the most recent reconciles of the
+	   VPCAccessConnector, oldest first. */
+	ReconcileHistory []v1alpha1.ReconcileHistoryEntry `json:"reconcileHistory,omitempty"`
 	/* Output only. List of projects using the connector. */
 	ConnectedProjects []string `json:"connectedProjects,omitempty"`
 	/* ObservedGeneration is the generation of the resource that was most recently observed by the Config Connector controller. If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource. */
@@ -164,6 +164,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (res
 	r.RecordReconcileWorkers(ctx, r.gvk)
 	defer r.AfterReconcile()
 	defer r.RecordReconcileMetrics(ctx, r.gvk, req.Namespace, req.Name, startTime, &err)
+	ctx = lifecyclehandler.WithReconcileTracker(ctx)
 
 	u := &unstructured.Unstructured{}
 	u.SetGroupVersionKind(r.gvk)
@@ -258,6 +259,9 @@ func (r *Reconciler) sync(ctx context.Context, resource *dcl.Resource) (requeue
 		}
 		return false, r.HandleUpdateFailed(ctx, &resource.Resource, fmt.Errorf("error converting the desired state to a KCC lite resource: %w", err))
 	}
+	if err := r.validateResourceAllowedInNamespace(ctx, lite); err != nil {
+		return false, r.HandleUpdateFailed(ctx, &resource.Resource, err)
+	}
 	// KCC Lite to DCL resource
 	dclResource, err := r.converter.KRMObjectToDCLObject(lite)
 	if err != nil {
@@ -296,6 +300,7 @@ func (r *Reconciler) sync(ctx context.Context, resource *dcl.Resource) (requeue
 		r.logger.Error(err, "error applying desired state", "resource", resource.GetNamespacedName())
 		return false, r.HandleUpdateFailed(ctx, &resource.Resource, fmt.Errorf("error applying desired state: %w", err))
 	}
+	lifecyclehandler.MarkGCPMutated(ctx)
 	// update k8s api server with the new state
 	newLite, err := r.converter.DCLObjectToKRMObject(newState)
 	if err != nil {
@@ -424,6 +429,28 @@ func (r *Reconciler) applyChangesForBackwardsCompatibility(ctx context.Context,
 	return nil
 }
 
+// validateResourceAllowedInNamespace checks that the resource doesn't target a
+// project, folder or location that its namespace doesn't allow. The webhooks
+// can't resolve references to Project and Folder resources, so the check is
+// redone here against the KCC lite resource, whose references are resolved.
+func (r *Reconciler) validateResourceAllowedInNamespace(ctx context.Context, lite *unstructured.Unstructured) error {
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: lite.GetNamespace()}, ns); err != nil {
+		return fmt.Errorf("error getting namespace %v: %v", lite.GetNamespace(), err)
+	}
+	gvk := lite.GroupVersionKind()
+	containers, err := dclcontainer.GetContainersForGVK(gvk, r.converter.MetadataLoader, r.converter.SchemaLoader)
+	if err != nil {
+		return fmt.Errorf("error getting containers supported by GroupVersionKind %v: %v", gvk, err)
+	}
+	hierarchicalRefs, err := dcl.GetHierarchicalReferencesForGVK(gvk, r.converter.MetadataLoader, r.converter.SchemaLoader)
+	if err != nil {
+		return fmt.Errorf("error getting hierarchical references supported by GroupVersionKind %v: %v", gvk, err)
+	}
+	spec, _, _ := unstructured.NestedMap(lite.Object, "spec")
+	return k8s.ValidateResourceAllowedInNamespace(lite, spec, ns, hierarchicalRefs, containers)
+}
+
 func (r *Reconciler) handleDeleted(ctx context.Context, resource *dcl.Resource) error {
 	if err := resourceoverrides.Handler.PostActuationTransform(resource.Original, &resource.Resource); err != nil {
 		return r.HandlePostActuationTransformFailed(ctx, &resource.Resource, fmt.Errorf("error applying post-actuation transformation to resource '%v': %w", resource.GetNamespacedName(), err))
@@ -558,6 +585,7 @@ func (r *Reconciler) finalizeResourceDeletion(ctx context.Context, resource *dcl
 		}
 		return false, r.HandleDeleteFailed(ctx, &resource.Resource, fmt.Errorf("error deleting the resource %v: %w", resource.GetNamespacedName(), err))
 	}
+	lifecyclehandler.MarkGCPMutated(ctx)
 	return false, r.handleDeleted(ctx, resource)
 }
 
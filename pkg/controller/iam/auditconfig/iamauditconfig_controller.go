@@ -190,6 +190,9 @@ func (r *reconcileContext) doReconcile(auditConfig *v1beta1.IAMAuditConfig) (req
 			return false, r.handleUpdateFailed(auditConfig, err)
 		}
 	}
+	if err := r.Reconciler.iamClient.ValidateResourceReferenceAllowedInNamespace(r.Ctx, auditConfig.Namespace, auditConfig.Spec.ResourceReference); err != nil {
+		return false, r.handleUpdateFailed(auditConfig, err)
+	}
 	if !k8s.EnsureFinalizers(auditConfig, k8s.ControllerFinalizerName, k8s.DeletionDefenderFinalizerName) {
 		if err := r.update(auditConfig); err != nil {
 			return false, r.handleUpdateFailed(auditConfig, err)
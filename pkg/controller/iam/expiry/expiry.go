@@ -0,0 +1,94 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package expiry implements time-bound IAM bindings. The expiresAt field of an IAMPolicyMember or
+// of an IAMPartialPolicy binding is enforced by GCP through an IAM condition comparing the request
+// time with the expiry time, and the binding is removed from the IAM policy by its controller once
+// it has expired so that IAM policies don't accumulate bindings that can no longer apply.
+package expiry
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	conditionTitleTmpl       = "Expires at %v"
+	conditionDescriptionTmpl = "Managed by Config Connector: the binding expires at %v."
+	expressionTmpl           = `request.time < timestamp("%v")`
+)
+
+// Condition returns the IAM condition under which a binding with the given condition and expiry
+// time applies. If the binding has no expiry time, the given condition is returned as is. If it
+// has no condition, a condition only checking the expiry time is returned. Otherwise, the
+// expression of the given condition is combined with the expiry time check.
+func Condition(condition *v1beta1.IAMCondition, expiresAt *metav1.Time) *v1beta1.IAMCondition {
+	if expiresAt == nil {
+		return condition
+	}
+	timestamp := formatTimestamp(expiresAt)
+	expression := fmt.Sprintf(expressionTmpl, timestamp)
+	if condition == nil {
+		return &v1beta1.IAMCondition{
+			Title:       fmt.Sprintf(conditionTitleTmpl, timestamp),
+			Description: fmt.Sprintf(conditionDescriptionTmpl, timestamp),
+			Expression:  expression,
+		}
+	}
+	return &v1beta1.IAMCondition{
+		Title:       condition.Title,
+		Description: condition.Description,
+		Expression:  fmt.Sprintf("(%v) && %v", condition.Expression, expression),
+	}
+}
+
+// HasExpired returns true if the given expiry time is set and is not after the given time.
+func HasExpired(expiresAt *metav1.Time, now time.Time) bool {
+	return expiresAt != nil && !now.Before(expiresAt.Time)
+}
+
+// RequeueAfter returns the period after which a resource reconciled at the given time and that
+// would otherwise be reconciled again after the given period must be reconciled again so that its
+// bindings expiring at the given times are removed on time.
+func RequeueAfter(period time.Duration, now time.Time, expiryTimes ...*metav1.Time) time.Duration {
+	for _, expiresAt := range expiryTimes {
+		if expiresAt == nil || HasExpired(expiresAt, now) {
+			continue
+		}
+		if untilExpiry := expiresAt.Sub(now); untilExpiry < period {
+			period = untilExpiry
+		}
+	}
+	return period
+}
+
+// PolicyMemberWithExpiryCondition returns a copy of the given IAMPolicyMember whose condition
+// enforces its expiry time, if any, and whose expiry time is cleared.
+func PolicyMemberWithExpiryCondition(policyMember *v1beta1.IAMPolicyMember) *v1beta1.IAMPolicyMember {
+	if policyMember.Spec.ExpiresAt == nil {
+		return policyMember
+	}
+	res := policyMember.DeepCopy()
+	res.Spec.Condition = Condition(policyMember.Spec.Condition, policyMember.Spec.ExpiresAt)
+	res.Spec.ExpiresAt = nil
+	return res
+}
+
+func formatTimestamp(t *metav1.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,141 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expiry_test
+
+import (
+	"testing"
+	"time"
+
+	iamv1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/expiry"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCondition(t *testing.T) {
+	expiresAt := metav1.NewTime(time.Date(2030, time.June, 1, 12, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)))
+	condition := &iamv1beta1.IAMCondition{
+		Title:       "only-foo-bucket",
+		Description: "Only the foo bucket",
+		Expression:  `resource.name == "projects/_/buckets/foo"`,
+	}
+	tests := []struct {
+		name      string
+		condition *iamv1beta1.IAMCondition
+		expiresAt *metav1.Time
+		expected  *iamv1beta1.IAMCondition
+	}{
+		{
+			name: "no condition and no expiry time",
+		},
+		{
+			name:      "condition and no expiry time",
+			condition: condition,
+			expected:  condition,
+		},
+		{
+			name:      "expiry time and no condition",
+			expiresAt: &expiresAt,
+			expected: &iamv1beta1.IAMCondition{
+				Title:       "Expires at 2030-06-01T10:00:00Z",
+				Description: "Managed by Config Connector: the binding expires at 2030-06-01T10:00:00Z.",
+				Expression:  `request.time < timestamp("2030-06-01T10:00:00Z")`,
+			},
+		},
+		{
+			name:      "condition and expiry time",
+			condition: condition,
+			expiresAt: &expiresAt,
+			expected: &iamv1beta1.IAMCondition{
+				Title:       "only-foo-bucket",
+				Description: "Only the foo bucket",
+				Expression:  `(resource.name == "projects/_/buckets/foo") && request.time < timestamp("2030-06-01T10:00:00Z")`,
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			res := expiry.Condition(tc.condition, tc.expiresAt)
+			if diff := cmp.Diff(tc.expected, res); diff != "" {
+				t.Fatalf("unexpected condition diff (-want +got): \n%v", diff)
+			}
+		})
+	}
+}
+
+func TestRequeueAfter(t *testing.T) {
+	now := time.Date(2030, time.June, 1, 12, 0, 0, 0, time.UTC)
+	expired := metav1.NewTime(now.Add(-time.Minute))
+	expiresSoon := metav1.NewTime(now.Add(time.Minute))
+	expiresLater := metav1.NewTime(now.Add(time.Hour))
+	tests := []struct {
+		name        string
+		expiryTimes []*metav1.Time
+		expected    time.Duration
+	}{
+		{
+			name:     "no expiry time",
+			expected: 10 * time.Minute,
+		},
+		{
+			name:        "expiry time after the period",
+			expiryTimes: []*metav1.Time{&expiresLater},
+			expected:    10 * time.Minute,
+		},
+		{
+			name:        "expiry time before the end of the period",
+			expiryTimes: []*metav1.Time{nil, &expiresLater, &expiresSoon},
+			expected:    time.Minute,
+		},
+		{
+			name:        "expired",
+			expiryTimes: []*metav1.Time{&expired},
+			expected:    10 * time.Minute,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if res := expiry.RequeueAfter(10*time.Minute, now, tc.expiryTimes...); res != tc.expected {
+				t.Fatalf("got %v, want %v", res, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPolicyMemberWithExpiryCondition(t *testing.T) {
+	expiresAt := metav1.NewTime(time.Date(2030, time.June, 1, 12, 0, 0, 0, time.UTC))
+	policyMember := &iamv1beta1.IAMPolicyMember{
+		Spec: iamv1beta1.IAMPolicyMemberSpec{
+			Member:    "user:foo@example.com",
+			Role:      "roles/owner",
+			ExpiresAt: &expiresAt,
+		},
+	}
+	res := expiry.PolicyMemberWithExpiryCondition(policyMember)
+	if res.Spec.ExpiresAt != nil {
+		t.Errorf("expected the expiry time to be cleared, got %v", res.Spec.ExpiresAt)
+	}
+	if res.Spec.Condition == nil || res.Spec.Condition.Expression != `request.time < timestamp("2030-06-01T12:00:00Z")` {
+		t.Errorf("unexpected condition %+v", res.Spec.Condition)
+	}
+	if policyMember.Spec.ExpiresAt == nil || policyMember.Spec.Condition != nil {
+		t.Errorf("expected the original IAMPolicyMember to be left unchanged, got %+v", policyMember.Spec)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ValidateResourceReferenceAllowedInNamespace returns an error if the resource
+// referenced by an IAM resource in the given namespace belongs to a project,
+// folder or location that the namespace doesn't allow. Unlike the webhooks,
+// this also validates references by name, which are resolved to the ID of
+// the referenced resource.
+func (c *IAMClient) ValidateResourceReferenceAllowedInNamespace(ctx context.Context, namespace string, resourceRef v1beta1.ResourceReference) error {
+	if !c.isDCLBasedResource(resourceRef.GroupVersionKind()) {
+		return c.TFIAMClient.ValidateResourceReferenceAllowedInNamespace(ctx, namespace, resourceRef)
+	}
+	return validateResourceReferenceAllowedInNamespace(ctx, c.DCLIAMClient.kubeClient, namespace, resourceRef, func() (string, error) {
+		if resourceRef.External != "" {
+			return resourceRef.External, nil
+		}
+		return c.DCLIAMClient.resolveResourceContainerPath(ctx, resourceRef, namespace)
+	})
+}
+
+// ValidateResourceReferenceAllowedInNamespace returns an error if the resource
+// referenced by an IAM resource in the given namespace belongs to a project,
+// folder or location that the namespace doesn't allow.
+func (t *TFIAMClient) ValidateResourceReferenceAllowedInNamespace(ctx context.Context, namespace string, resourceRef v1beta1.ResourceReference) error {
+	return validateResourceReferenceAllowedInNamespace(ctx, t.kubeClient, namespace, resourceRef, func() (string, error) {
+		id, err := t.getResourceID(ctx, resourceRef, namespace)
+		if err != nil {
+			return "", fmt.Errorf("couldn't get resource id for resource reference: %w", err)
+		}
+		return id, nil
+	})
+}
+
+// validateResourceReferenceAllowedInNamespace only resolves the ID of the
+// referenced resource if the namespace restricts the targets of its resources.
+func validateResourceReferenceAllowedInNamespace(ctx context.Context, kubeClient client.Client, namespace string,
+	resourceRef v1beta1.ResourceReference, resolveID func() (string, error)) error {
+	ns := &corev1.Namespace{}
+	if err := kubeClient.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return fmt.Errorf("error getting Namespace %v: %w", namespace, err)
+	}
+	if !k8s.HasAllowedTargetsAnnotation(ns) {
+		return nil
+	}
+	id, err := resolveID()
+	if err != nil {
+		return err
+	}
+	return k8s.ValidateResourceIDAllowedInNamespace(ns, resourceRef.Kind, id)
+}
+
+// dclContainerFields are the fields of DCL resources that hold their
+// container or location, and the collections of the values of these fields.
+var dclContainerFields = []struct {
+	field      string
+	collection string
+}{
+	{"project", "projects"},
+	{"folder", "folders"},
+	{"organization", "organizations"},
+	{"location", "locations"},
+}
+
+func (d *DCLIAMClient) resolveResourceContainerPath(ctx context.Context, resourceRef v1beta1.ResourceReference, namespace string) (string, error) {
+	dclSchema, err := d.getSchemaFromResourceReference(resourceRef)
+	if err != nil {
+		return "", err
+	}
+	dclResource, err := d.getDCLResource(ctx, resourceRef, dclSchema, namespace)
+	if err != nil {
+		return "", fmt.Errorf("error getting referenced DCL resource: %w", err)
+	}
+	segments := make([]string, 0)
+	for _, f := range dclContainerFields {
+		if val, ok := dclResource.Object[f.field].(string); ok && val != "" {
+			segments = append(segments, f.collection, strings.TrimPrefix(val, f.collection+"/"))
+		}
+	}
+	return strings.Join(segments, "/"), nil
+}
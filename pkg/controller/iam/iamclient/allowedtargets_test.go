@@ -0,0 +1,91 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/test/mocks"
+)
+
+func TestValidateResourceReferenceAllowedInNamespace(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := mocks.Manager{}.GetClient()
+	for _, ns := range []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "restricted-ns",
+				Annotations: map[string]string{
+					k8s.AllowedProjectsAnnotation: "allowed-project",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrestricted-ns"},
+		},
+	} {
+		if err := kubeClient.Create(ctx, ns); err != nil {
+			t.Fatalf("error creating namespace: %v", err)
+		}
+	}
+	tfIAMClient := &TFIAMClient{kubeClient: kubeClient}
+	tests := []struct {
+		name        string
+		namespace   string
+		resourceRef v1beta1.ResourceReference
+		hasError    bool
+	}{
+		{
+			name:        "allowed project",
+			namespace:   "restricted-ns",
+			resourceRef: v1beta1.ResourceReference{Kind: "Project", External: "projects/allowed-project"},
+		},
+		{
+			name:        "project not allowed",
+			namespace:   "restricted-ns",
+			resourceRef: v1beta1.ResourceReference{Kind: "Project", External: "forbidden-project"},
+			hasError:    true,
+		},
+		{
+			name:        "resource in a project not allowed",
+			namespace:   "restricted-ns",
+			resourceRef: v1beta1.ResourceReference{Kind: "PubSubTopic", External: "projects/forbidden-project/topics/topic"},
+			hasError:    true,
+		},
+		{
+			name:        "namespace without restrictions",
+			namespace:   "unrestricted-ns",
+			resourceRef: v1beta1.ResourceReference{Kind: "Project", External: "forbidden-project"},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := tfIAMClient.ValidateResourceReferenceAllowedInNamespace(ctx, tc.namespace, tc.resourceRef)
+			if tc.hasError && err == nil {
+				t.Fatalf("expected an error, got none")
+			}
+			if !tc.hasError && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
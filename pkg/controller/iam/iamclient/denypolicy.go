@@ -0,0 +1,219 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/krmtotf"
+	tfresource "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/tf/resource"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/util"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	denyPolicyTFResourceName = "google_iam_deny_policy"
+	denyPolicyIDTemplate     = "{{parent}}/{{name}}"
+
+	// denyPolicyAttachmentPointPrefix is the prefix of the full resource names
+	// of the resources that deny policies can be attached to.
+	denyPolicyAttachmentPointPrefix = "cloudresourcemanager.googleapis.com/"
+)
+
+// denyPolicyAttachmentPointCollections are the collections, keyed by kind, of
+// the resources that deny policies can be attached to.
+var denyPolicyAttachmentPointCollections = map[string]string{
+	"Project":      "projects",
+	"Folder":       "folders",
+	"Organization": "organizations",
+}
+
+// IsDenyPolicyAttachmentPointKind returns true if IAM deny policies can be
+// attached to resources of the given kind.
+func IsDenyPolicyAttachmentPointKind(kind string) bool {
+	_, ok := denyPolicyAttachmentPointCollections[kind]
+	return ok
+}
+
+// DenyPolicyAttachmentPoint returns the full resource name of the resource of
+// the given kind and ID that a deny policy is attached to, e.g.
+// 'cloudresourcemanager.googleapis.com/projects/my-project'. The ID may or
+// may not be prefixed by the collection of the resource (e.g. 'projects/').
+func DenyPolicyAttachmentPoint(kind, id string) (string, error) {
+	collection, ok := denyPolicyAttachmentPointCollections[kind]
+	if !ok {
+		return "", fmt.Errorf("invalid resource reference: IAM deny policies cannot be attached to kind %v, "+
+			"only Projects, Folders and Organizations are supported", kind)
+	}
+	id = strings.TrimPrefix(id, collection+"/")
+	if id == "" || strings.Contains(id, "/") {
+		return "", fmt.Errorf("invalid %v ID '%v'", kind, id)
+	}
+	return fmt.Sprintf("%v%v/%v", denyPolicyAttachmentPointPrefix, collection, id), nil
+}
+
+func (t *TFIAMClient) SetDenyPolicy(ctx context.Context, denyPolicy *v1beta1.IAMDenyPolicy) (*v1beta1.IAMDenyPolicy, error) {
+	resource, err := t.newDenyPolicyResource(ctx, denyPolicy)
+	if err != nil {
+		return nil, err
+	}
+	liveState, err := krmtotf.FetchLiveState(ctx, resource, t.provider, t.kubeClient, t.smLoader)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching live state for resource: %w", err)
+	}
+	cfg, _, err := krmtotf.KRMResourceToTFResourceConfig(resource, t.kubeClient, t.smLoader)
+	if err != nil {
+		return nil, fmt.Errorf("error creating resource config: %w", err)
+	}
+	diff, err := resource.TFResource.Diff(ctx, liveState, cfg, t.provider.Meta())
+	if err != nil {
+		return nil, fmt.Errorf("error calculating diff: %w", err)
+	}
+	if !liveState.Empty() && diff.RequiresNew() {
+		return nil, k8s.NewImmutableFieldsMutationError(tfresource.ImmutableFieldsFromDiff(diff))
+	}
+	if diff.Empty() {
+		logger.Info("underlying resource is already up to date", "resource", k8s.GetNamespacedName(denyPolicy))
+		return newIAMDenyPolicyFromTFState(resource, liveState, denyPolicy)
+	}
+	newState, diagnostics := resource.TFResource.Apply(ctx, liveState, diff, t.provider.Meta())
+	if err := krmtotf.NewErrorFromDiagnostics(diagnostics); err != nil {
+		return nil, fmt.Errorf("error applying changes: %w", err)
+	}
+	return newIAMDenyPolicyFromTFState(resource, newState, denyPolicy)
+}
+
+func (t *TFIAMClient) GetDenyPolicy(ctx context.Context, denyPolicy *v1beta1.IAMDenyPolicy) (*v1beta1.IAMDenyPolicy, error) {
+	resource, err := t.newDenyPolicyResource(ctx, denyPolicy)
+	if err != nil {
+		return nil, err
+	}
+	liveState, err := krmtotf.FetchLiveState(ctx, resource, t.provider, t.kubeClient, t.smLoader)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching live state for resource: %w", err)
+	}
+	if liveState.Empty() {
+		return nil, NotFoundError
+	}
+	return newIAMDenyPolicyFromTFState(resource, liveState, denyPolicy)
+}
+
+func (t *TFIAMClient) DeleteDenyPolicy(ctx context.Context, denyPolicy *v1beta1.IAMDenyPolicy) error {
+	resource, err := t.newDenyPolicyResource(ctx, denyPolicy)
+	if err != nil {
+		return err
+	}
+	liveState, err := krmtotf.FetchLiveState(ctx, resource, t.provider, t.kubeClient, t.smLoader)
+	if err != nil {
+		return fmt.Errorf("error fetching live state for resource: %w", err)
+	}
+	if liveState.Empty() {
+		return NotFoundError
+	}
+	_, diagnostics := resource.TFResource.Apply(ctx, liveState, &terraform.InstanceDiff{Destroy: true}, t.provider.Meta())
+	if err := krmtotf.NewErrorFromDiagnostics(diagnostics); err != nil {
+		return fmt.Errorf("error deleting IAMDenyPolicy: %w", err)
+	}
+	return nil
+}
+
+// resolveDenyPolicyAttachmentPoint returns the full resource name of the
+// resource referenced by the given IAMDenyPolicy.
+func (t *TFIAMClient) resolveDenyPolicyAttachmentPoint(ctx context.Context, denyPolicy *v1beta1.IAMDenyPolicy) (string, error) {
+	resourceRef := denyPolicy.Spec.ResourceReference
+	if !IsDenyPolicyAttachmentPointKind(resourceRef.Kind) {
+		return DenyPolicyAttachmentPoint(resourceRef.Kind, "")
+	}
+	id, err := t.getResourceID(ctx, resourceRef, denyPolicy.Namespace)
+	if err != nil {
+		return "", fmt.Errorf("couldn't get resource id for resource reference: %w", err)
+	}
+	return DenyPolicyAttachmentPoint(resourceRef.Kind, id)
+}
+
+// newDenyPolicyResource creates a Resource which represents the given
+// IAMDenyPolicy as a google_iam_deny_policy Terraform resource.
+func (t *TFIAMClient) newDenyPolicyResource(ctx context.Context, denyPolicy *v1beta1.IAMDenyPolicy) (*krmtotf.Resource, error) {
+	SetGVK(denyPolicy)
+	attachmentPoint, err := t.resolveDenyPolicyAttachmentPoint(ctx, denyPolicy)
+	if err != nil {
+		return nil, err
+	}
+	var rules []interface{}
+	if err := util.Marshal(denyPolicy.Spec.Rules, &rules); err != nil {
+		return nil, fmt.Errorf("unable to marshal deny policy rules to slice: %w", err)
+	}
+	spec := map[string]interface{}{
+		// The parent of a deny policy is the URL-encoded full resource name
+		// of its attachment point.
+		"parent": url.QueryEscape(attachmentPoint),
+		"name":   denyPolicyID(denyPolicy),
+		"rules":  rules,
+	}
+	if denyPolicy.Spec.DisplayName != "" {
+		spec["displayName"] = denyPolicy.Spec.DisplayName
+	}
+	u := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": v1beta1.IAMDenyPolicyGVK.GroupVersion().String(),
+			"kind":       v1beta1.IAMDenyPolicyGVK.Kind,
+			"metadata": map[string]interface{}{
+				"name":      denyPolicy.Name,
+				"namespace": denyPolicy.Namespace,
+			},
+			"spec": spec,
+		},
+	}
+	sm := newServiceMappingForGVKAndTFResourceName(v1beta1.IAMDenyPolicyGVK, denyPolicyTFResourceName)
+	// Deny policies are not attached to the IAM policy of their attachment
+	// point, so they are read by importing their own ID.
+	sm.Spec.Resources[0].SkipImport = false
+	sm.Spec.Resources[0].IDTemplate = denyPolicyIDTemplate
+	return krmtotf.NewResource(u, sm, t.provider)
+}
+
+func denyPolicyID(denyPolicy *v1beta1.IAMDenyPolicy) string {
+	if denyPolicy.Spec.ResourceID != nil && *denyPolicy.Spec.ResourceID != "" {
+		return *denyPolicy.Spec.ResourceID
+	}
+	return denyPolicy.Name
+}
+
+func newIAMDenyPolicyFromTFState(resource *krmtotf.Resource, state *terraform.InstanceState, origDenyPolicy *v1beta1.IAMDenyPolicy) (*v1beta1.IAMDenyPolicy, error) {
+	resource.Spec, resource.Status = krmtotf.GetSpecAndStatusFromState(resource, state)
+	u, err := resource.MarshalAsUnstructured()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling resource to unstructured: %w", err)
+	}
+	iamDenyPolicy := v1beta1.IAMDenyPolicy{}
+	if err := util.Marshal(u, &iamDenyPolicy); err != nil {
+		return nil, fmt.Errorf("error marshalling unstructured to IAMDenyPolicy: %w", err)
+	}
+	iamDenyPolicy.Spec.ResourceReference = origDenyPolicy.Spec.ResourceReference
+	iamDenyPolicy.Spec.ResourceID = origDenyPolicy.Spec.ResourceID
+	iamDenyPolicy.ObjectMeta = origDenyPolicy.ObjectMeta
+	iamDenyPolicy.Status = *origDenyPolicy.Status.DeepCopy()
+	if etag, ok := resource.Status["etag"].(string); ok {
+		iamDenyPolicy.Status.Etag = etag
+	}
+	return &iamDenyPolicy, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/test/mocks"
+)
+
+func TestDenyPolicyAttachmentPoint(t *testing.T) {
+	tests := []struct {
+		kind     string
+		id       string
+		expected string
+		hasError bool
+	}{
+		{
+			kind:     "Project",
+			id:       "projects/foo-project",
+			expected: "cloudresourcemanager.googleapis.com/projects/foo-project",
+		},
+		{
+			kind:     "Project",
+			id:       "foo-project",
+			expected: "cloudresourcemanager.googleapis.com/projects/foo-project",
+		},
+		{
+			kind:     "Folder",
+			id:       "folders/123456789",
+			expected: "cloudresourcemanager.googleapis.com/folders/123456789",
+		},
+		{
+			kind:     "Organization",
+			id:       "123456789",
+			expected: "cloudresourcemanager.googleapis.com/organizations/123456789",
+		},
+		{
+			kind:     "Folder",
+			id:       "projects/foo-project",
+			hasError: true,
+		},
+		{
+			kind:     "StorageBucket",
+			id:       "foo-bucket",
+			hasError: true,
+		},
+	}
+	for _, tc := range tests {
+		attachmentPoint, err := DenyPolicyAttachmentPoint(tc.kind, tc.id)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("%v '%v': expected an error, got attachment point '%v'", tc.kind, tc.id, attachmentPoint)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v '%v': unexpected error: %v", tc.kind, tc.id, err)
+			continue
+		}
+		if attachmentPoint != tc.expected {
+			t.Errorf("%v '%v': got '%v', want '%v'", tc.kind, tc.id, attachmentPoint, tc.expected)
+		}
+	}
+}
+
+func TestNewDenyPolicyResource(t *testing.T) {
+	tfIAMClient := &TFIAMClient{
+		kubeClient: mocks.Manager{}.GetClient(),
+		provider:   google.Provider(),
+	}
+	resourceID := "foo-policy-id"
+	denyPolicy := &v1beta1.IAMDenyPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo-ns", Name: "foo-policy"},
+		Spec: v1beta1.IAMDenyPolicySpec{
+			ResourceReference: v1beta1.ResourceReference{Kind: "Organization", External: "organizations/123456789"},
+			ResourceID:        &resourceID,
+			DisplayName:       "Foo policy",
+			Rules: []v1beta1.IAMDenyPolicyRule{
+				{
+					DenyRule: v1beta1.IAMDenyRule{
+						DeniedPrincipals:  []string{"principalSet://goog/public:all"},
+						DeniedPermissions: []string{"iam.googleapis.com/roles.delete"},
+					},
+				},
+			},
+		},
+	}
+	resource, err := tfIAMClient.newDenyPolicyResource(context.Background(), denyPolicy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := resource.Spec["parent"], "cloudresourcemanager.googleapis.com%2Forganizations%2F123456789"; got != want {
+		t.Errorf("got parent '%v', want '%v'", got, want)
+	}
+	if got, want := resource.Spec["name"], resourceID; got != want {
+		t.Errorf("got name '%v', want '%v'", got, want)
+	}
+	if got, want := resource.ResourceConfig.IDTemplate, "{{parent}}/{{name}}"; got != want {
+		t.Errorf("got ID template '%v', want '%v'", got, want)
+	}
+
+	denyPolicy.Spec.ResourceReference = v1beta1.ResourceReference{Kind: "StorageBucket", External: "foo-bucket"}
+	if _, err := tfIAMClient.newDenyPolicyResource(context.Background(), denyPolicy); err == nil {
+		t.Errorf("expected an error for an unsupported attachment point, got none")
+	}
+}
@@ -38,8 +38,8 @@ func (c *IAMClient) isDCLBasedResource(gvk schema.GroupVersionKind) bool {
 }
 
 // ResolveMemberIdentity checks only one of Member/MemberFrom is provided, and then tries to resolve identity.
-// MemberFrom can only have oneOf a ServiceAccountRef, a LogSinkRef, a SQLInstanceRef, so to resolve these
-// values, it is necessary to call on the TFIAMClient
+// MemberFrom can only have oneOf a ServiceAccountRef, a LogSinkRef, a SQLInstanceRef, a KubernetesServiceAccountRef,
+// a ServiceAgent or a WorkloadIdentityPoolPrincipalSet, so to resolve these values, it is necessary to call on the TFIAMClient
 func ResolveMemberIdentity(ctx context.Context, member v1beta1.Member,
 	memberFrom *v1beta1.MemberSource, namespace string, tfIAMClient *TFIAMClient) (id string, err error) {
 	if member != "" && memberFrom != nil {
@@ -72,11 +72,26 @@ func ResolveMemberIdentity(ctx context.Context, member v1beta1.Member,
 		gvks = append(gvks, SQLInstanceGVK)
 	}
 
-	if len(refs) == 1 {
-		return tfIAMClient.resolveMemberReference(ctx, refs[0], gvks[0], namespace)
-	} else {
-		return id, fmt.Errorf("%v memberFrom refs found. Exactly one Of 'logSinkRef', 'serviceAccountRef', 'sqlInstanceRef' must be used", len(refs))
+	numSources := len(refs)
+	for _, set := range []bool{memberFrom.KubernetesServiceAccountRef != nil, memberFrom.ServiceAgent != nil, memberFrom.WorkloadIdentityPoolPrincipalSet != nil} {
+		if set {
+			numSources++
+		}
+	}
+	if numSources != 1 {
+		return id, fmt.Errorf("%v memberFrom refs found. Exactly one Of 'kubernetesServiceAccountRef', 'logSinkRef', 'serviceAccountRef', "+
+			"'serviceAgent', 'sqlInstanceRef', 'workloadIdentityPoolPrincipalSet' must be used", numSources)
+	}
+
+	switch {
+	case memberFrom.KubernetesServiceAccountRef != nil:
+		return tfIAMClient.resolveKubernetesServiceAccount(ctx, memberFrom.KubernetesServiceAccountRef, namespace)
+	case memberFrom.ServiceAgent != nil:
+		return tfIAMClient.resolveServiceAgent(ctx, memberFrom.ServiceAgent, namespace)
+	case memberFrom.WorkloadIdentityPoolPrincipalSet != nil:
+		return tfIAMClient.resolveWorkloadIdentityPoolPrincipalSet(ctx, memberFrom.WorkloadIdentityPoolPrincipalSet, namespace)
 	}
+	return tfIAMClient.resolveMemberReference(ctx, refs[0], gvks[0], namespace)
 }
 
 func extractNamespaceAndResourceReference(iamInterface interface{}) (string, v1beta1.ResourceReference) {
@@ -87,6 +102,8 @@ func extractNamespaceAndResourceReference(iamInterface interface{}) (string, v1b
 		return iamObject.Namespace, iamObject.Spec.ResourceReference
 	case *v1beta1.IAMAuditConfig:
 		return iamObject.Namespace, iamObject.Spec.ResourceReference
+	case *v1beta1.IAMDenyPolicy:
+		return iamObject.Namespace, iamObject.Spec.ResourceReference
 	}
 	panic(fmt.Errorf("unknown type: %v", reflect.TypeOf(iamInterface).Name()))
 }
@@ -124,6 +141,8 @@ func SetGVK(iamInterface interface{}) {
 		setPolicyMemberGVK(iamObject)
 	case *v1beta1.IAMAuditConfig:
 		setAuditConfigGVK(iamObject)
+	case *v1beta1.IAMDenyPolicy:
+		setDenyPolicyGVK(iamObject)
 	default:
 		panic(fmt.Errorf("unknown type: %v", reflect.TypeOf(iamInterface).Name()))
 	}
@@ -145,6 +164,10 @@ func setAuditConfigGVK(auditConfig *v1beta1.IAMAuditConfig) {
 	auditConfig.SetGroupVersionKind(v1beta1.IAMAuditConfigGVK)
 }
 
+func setDenyPolicyGVK(denyPolicy *v1beta1.IAMDenyPolicy) {
+	denyPolicy.SetGroupVersionKind(v1beta1.IAMDenyPolicyGVK)
+}
+
 func resourceSupportsIAMPolicy(rc *corekccv1alpha1.ResourceConfig) bool {
 	return rc.IAMConfig.PolicyName != ""
 }
@@ -16,10 +16,13 @@ package iamclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/expiry"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/conversion"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/servicemapping/servicemappingloader"
 
@@ -82,6 +85,9 @@ var idTemplateVarsRegex = regexp.MustCompile(`{{[a-z]([a-zA-Z0-9\-_.]*[a-zA-Z0-9
 type IAMClient struct {
 	TFIAMClient  *TFIAMClient
 	DCLIAMClient *DCLIAMClient
+
+	// policyMemberBatcher coalesces the writes of policy members targeting the same resource, if set.
+	policyMemberBatcher *PolicyMemberBatcher
 }
 
 func New(tfProvider *tfschema.Provider,
@@ -89,11 +95,6 @@ func New(tfProvider *tfschema.Provider,
 	kubeClient client.Client,
 	converter *conversion.Converter,
 	dclConfig *mmdcl.Config) *IAMClient {
-	tfIAMClient := TFIAMClient{
-		kubeClient: kubeClient,
-		provider:   tfProvider,
-		smLoader:   smLoader,
-	}
 	dclIAMClient := DCLIAMClient{
 		dclClient: &dcliam.Client{
 			Config: dclConfig,
@@ -103,13 +104,54 @@ func New(tfProvider *tfschema.Provider,
 		kubeClient: kubeClient,
 	}
 	iamClient := IAMClient{
-		TFIAMClient:  &tfIAMClient,
+		TFIAMClient:  NewTFIAMClient(tfProvider, smLoader, kubeClient),
 		DCLIAMClient: &dclIAMClient,
 	}
 	return &iamClient
 }
 
+// NewTFIAMClient returns the client for the IAM of TF-based resources. A provider that is not configured, e.g.
+// google.Provider(), can be used if the client is only used to resolve resource references, see ResolveResourceID.
+func NewTFIAMClient(tfProvider *tfschema.Provider,
+	smLoader *servicemappingloader.ServiceMappingLoader,
+	kubeClient client.Client) *TFIAMClient {
+	return &TFIAMClient{
+		kubeClient: kubeClient,
+		provider:   tfProvider,
+		smLoader:   smLoader,
+	}
+}
+
+// ResolveResourceID returns the ID of the resource referenced by the given reference, see
+// TFIAMClient.ResolveResourceID. Only references to TF-based resources can be resolved.
+func (c *IAMClient) ResolveResourceID(ctx context.Context, resourceRef v1beta1.ResourceReference, namespace string) (string, error) {
+	return c.TFIAMClient.ResolveResourceID(ctx, resourceRef, namespace)
+}
+
+// EnablePolicyMemberBatching makes SetPolicyMember and DeletePolicyMember coalesce the writes of policy members
+// that target the same resource within the given window into a single read-modify-write of its policy.
+// Policy members targeting resources that don't support IAM policies are still written one by one.
+func (c *IAMClient) EnablePolicyMemberBatching(window time.Duration) {
+	c.policyMemberBatcher = NewPolicyMemberBatcher(c, window)
+}
+
 func (c *IAMClient) SetPolicyMember(ctx context.Context, policyMember *v1beta1.IAMPolicyMember) (*v1beta1.IAMPolicyMember, error) {
+	// The expiry time of a policy member is enforced by GCP through the condition of its binding,
+	// which is therefore part of the identity of the binding like any other condition.
+	policyMember = expiry.PolicyMemberWithExpiryCondition(policyMember)
+	if batched, err := c.canBatchPolicyMember(ctx, policyMember); err != nil {
+		return nil, err
+	} else if batched {
+		member, err := ResolveMemberIdentity(ctx, policyMember.Spec.Member, policyMember.Spec.MemberFrom, policyMember.Namespace, c.TFIAMClient)
+		if err != nil {
+			return nil, err
+		}
+		skeleton := newPolicySkeletonForPolicyMember(policyMember)
+		if err := c.policyMemberBatcher.AddMember(ctx, skeleton, policyMember.Spec.Role, policyMember.Spec.Condition, v1beta1.Member(member)); err != nil {
+			return nil, fmt.Errorf("error setting IAMPolicyMember: %w", err)
+		}
+		return policyMember, nil
+	}
 	if c.isDCLBasedIAMResource(policyMember) {
 		return c.DCLIAMClient.SetPolicyMember(ctx, c.TFIAMClient, policyMember)
 	}
@@ -117,6 +159,7 @@ func (c *IAMClient) SetPolicyMember(ctx context.Context, policyMember *v1beta1.I
 }
 
 func (c *IAMClient) GetPolicyMember(ctx context.Context, policyMember *v1beta1.IAMPolicyMember) (*v1beta1.IAMPolicyMember, error) {
+	policyMember = expiry.PolicyMemberWithExpiryCondition(policyMember)
 	if c.isDCLBasedIAMResource(policyMember) {
 		return c.DCLIAMClient.GetPolicyMember(ctx, c.TFIAMClient, policyMember)
 	}
@@ -124,6 +167,23 @@ func (c *IAMClient) GetPolicyMember(ctx context.Context, policyMember *v1beta1.I
 }
 
 func (c *IAMClient) DeletePolicyMember(ctx context.Context, policyMember *v1beta1.IAMPolicyMember) error {
+	policyMember = expiry.PolicyMemberWithExpiryCondition(policyMember)
+	if batched, err := c.canBatchPolicyMember(ctx, policyMember); err != nil {
+		return err
+	} else if batched {
+		member, err := ResolveMemberIdentity(ctx, policyMember.Spec.Member, policyMember.Spec.MemberFrom, policyMember.Namespace, c.TFIAMClient)
+		if err != nil {
+			return err
+		}
+		skeleton := newPolicySkeletonForPolicyMember(policyMember)
+		if err := c.policyMemberBatcher.RemoveMember(ctx, skeleton, policyMember.Spec.Role, policyMember.Spec.Condition, v1beta1.Member(member)); err != nil {
+			if errors.Is(err, NotFoundError) {
+				return err
+			}
+			return fmt.Errorf("error deleting IAMPolicyMember: %w", err)
+		}
+		return nil
+	}
 	if c.isDCLBasedIAMResource(policyMember) {
 		return c.DCLIAMClient.DeletePolicyMember(ctx, c.TFIAMClient, policyMember)
 
@@ -131,6 +191,22 @@ func (c *IAMClient) DeletePolicyMember(ctx context.Context, policyMember *v1beta
 	return c.TFIAMClient.DeletePolicyMember(ctx, policyMember)
 }
 
+// canBatchPolicyMember returns true if policy member batching is enabled and the resource referenced by the
+// given policy member supports IAM policies.
+func (c *IAMClient) canBatchPolicyMember(ctx context.Context, policyMember *v1beta1.IAMPolicyMember) (bool, error) {
+	if c.policyMemberBatcher == nil {
+		return false, nil
+	}
+	if c.isDCLBasedIAMResource(policyMember) {
+		return true, nil
+	}
+	rc, err := c.TFIAMClient.getResourceConfigForReferencedResource(ctx, policyMember)
+	if err != nil {
+		return false, fmt.Errorf("error getting resource config for referenced resource: %w", err)
+	}
+	return resourceSupportsIAMPolicy(rc), nil
+}
+
 func (c *IAMClient) SetPolicy(ctx context.Context, policy *v1beta1.IAMPolicy) (*v1beta1.IAMPolicy, error) {
 	if c.isDCLBasedIAMResource(policy) {
 		return c.DCLIAMClient.SetPolicy(ctx, policy)
@@ -172,3 +248,17 @@ func (c *IAMClient) DeleteAuditConfig(ctx context.Context, auditConfig *v1beta1.
 	}
 	return c.TFIAMClient.DeleteAuditConfig(ctx, auditConfig)
 }
+
+// IAM deny policies can only be attached to Projects, Folders and Organizations, none of which
+// are DCL-based, so they are always managed through the TF-based client.
+func (c *IAMClient) SetDenyPolicy(ctx context.Context, denyPolicy *v1beta1.IAMDenyPolicy) (*v1beta1.IAMDenyPolicy, error) {
+	return c.TFIAMClient.SetDenyPolicy(ctx, denyPolicy)
+}
+
+func (c *IAMClient) GetDenyPolicy(ctx context.Context, denyPolicy *v1beta1.IAMDenyPolicy) (*v1beta1.IAMDenyPolicy, error) {
+	return c.TFIAMClient.GetDenyPolicy(ctx, denyPolicy)
+}
+
+func (c *IAMClient) DeleteDenyPolicy(ctx context.Context, denyPolicy *v1beta1.IAMDenyPolicy) error {
+	return c.TFIAMClient.DeleteDenyPolicy(ctx, denyPolicy)
+}
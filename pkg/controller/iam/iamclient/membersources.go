@@ -0,0 +1,213 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamclient
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/gcp"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	IAMWorkloadIdentityPoolKind = "IAMWorkloadIdentityPool"
+
+	googleAPIsServiceSuffix = ".googleapis.com"
+)
+
+var (
+	IAMWorkloadIdentityPoolGVK = schema.GroupVersionKind{
+		Group:   IAMGroup,
+		Version: IAMVersion,
+		Kind:    IAMWorkloadIdentityPoolKind,
+	}
+
+	projectNumberRegex = regexp.MustCompile(`^[0-9]+$`)
+
+	// serviceAgentDomains are the domains of the service agents of the services whose service agent
+	// is known, keyed by service. The domain of a service agent cannot be derived from the name of
+	// its service, so the service agents of other services can only be bound with 'member'.
+	serviceAgentDomains = map[string]string{
+		"aiplatform.googleapis.com":           "gcp-sa-aiplatform.iam.gserviceaccount.com",
+		"artifactregistry.googleapis.com":     "gcp-sa-artifactregistry.iam.gserviceaccount.com",
+		"bigquerydatatransfer.googleapis.com": "gcp-sa-bigquerydatatransfer.iam.gserviceaccount.com",
+		"binaryauthorization.googleapis.com":  "gcp-sa-binaryauthorization.iam.gserviceaccount.com",
+		"cloudbuild.googleapis.com":           "gcp-sa-cloudbuild.iam.gserviceaccount.com",
+		"cloudfunctions.googleapis.com":       "gcf-admin-robot.iam.gserviceaccount.com",
+		"cloudkms.googleapis.com":             "gcp-sa-cloudkms.iam.gserviceaccount.com",
+		"cloudscheduler.googleapis.com":       "gcp-sa-cloudscheduler.iam.gserviceaccount.com",
+		"cloudtasks.googleapis.com":           "gcp-sa-cloudtasks.iam.gserviceaccount.com",
+		"compute.googleapis.com":              "compute-system.iam.gserviceaccount.com",
+		"container.googleapis.com":            "container-engine-robot.iam.gserviceaccount.com",
+		"containerregistry.googleapis.com":    "containerregistry.iam.gserviceaccount.com",
+		"dataflow.googleapis.com":             "dataflow-service-producer-prod.iam.gserviceaccount.com",
+		"dataproc.googleapis.com":             "dataproc-accounts.iam.gserviceaccount.com",
+		"eventarc.googleapis.com":             "gcp-sa-eventarc.iam.gserviceaccount.com",
+		"gkehub.googleapis.com":               "gcp-sa-gkehub.iam.gserviceaccount.com",
+		"pubsub.googleapis.com":               "gcp-sa-pubsub.iam.gserviceaccount.com",
+		"run.googleapis.com":                  "serverless-robot-prod.iam.gserviceaccount.com",
+		"secretmanager.googleapis.com":        "gcp-sa-secretmanager.iam.gserviceaccount.com",
+		"spanner.googleapis.com":              "gcp-sa-spanner.iam.gserviceaccount.com",
+		"storage.googleapis.com":              "gs-project-accounts.iam.gserviceaccount.com",
+	}
+)
+
+// resolveKubernetesServiceAccount returns the Workload Identity principal of the given Kubernetes
+// ServiceAccount, in the workload identity pool of the project of the ServiceAccount's namespace.
+func (t *TFIAMClient) resolveKubernetesServiceAccount(ctx context.Context, ref *v1beta1.MemberReference, resourceNamespace string) (string, error) {
+	namespace := useIfNonEmptyElseDefaultTo(ref.Namespace, resourceNamespace)
+	projectID, err := k8s.GetProjectIDForNamespace(t.kubeClient, ctx, namespace)
+	if err != nil {
+		return "", err
+	}
+	return WorkloadIdentityMember(projectID, namespace, ref.Name), nil
+}
+
+// WorkloadIdentityMember returns the IAM member of the given Kubernetes ServiceAccount in the
+// workload identity pool of the given project.
+func WorkloadIdentityMember(projectID, namespace, name string) string {
+	return fmt.Sprintf("serviceAccount:%v.svc.id.goog[%v/%v]", projectID, namespace, name)
+}
+
+// resolveServiceAgent returns the IAM member of the Google-managed service agent of the given
+// service in the given project.
+func (t *TFIAMClient) resolveServiceAgent(ctx context.Context, serviceAgent *v1beta1.ServiceAgentSource, resourceNamespace string) (string, error) {
+	if !strings.HasSuffix(serviceAgent.Service, googleAPIsServiceSuffix) {
+		return "", fmt.Errorf("invalid service '%v': must be a service name ending with '%v', e.g. 'pubsub%v'",
+			serviceAgent.Service, googleAPIsServiceSuffix, googleAPIsServiceSuffix)
+	}
+	if _, ok := serviceAgentDomains[serviceAgent.Service]; !ok {
+		return "", fmt.Errorf("the service agent of service '%v' is unknown: use 'member' to bind it instead", serviceAgent.Service)
+	}
+	projectNumber, err := t.resolveProjectNumber(ctx, serviceAgent.ProjectRef, resourceNamespace)
+	if err != nil {
+		return "", err
+	}
+	return ServiceAgentMember(projectNumber, serviceAgent.Service)
+}
+
+// ServiceAgentMember returns the IAM member of the Google-managed service agent of the given service
+// in the project with the given number, or an error if the service agent of the service is unknown.
+func ServiceAgentMember(projectNumber, service string) (string, error) {
+	domain, ok := serviceAgentDomains[service]
+	if !ok {
+		return "", fmt.Errorf("the service agent of service '%v' is unknown", service)
+	}
+	return fmt.Sprintf("serviceAccount:service-%v@%v", projectNumber, domain), nil
+}
+
+// resolveWorkloadIdentityPoolPrincipalSet returns the IAM principal set of the identities selected
+// by the given attribute in the given IAMWorkloadIdentityPool.
+func (t *TFIAMClient) resolveWorkloadIdentityPoolPrincipalSet(ctx context.Context, principalSet *v1beta1.WorkloadIdentityPoolPrincipalSet, resourceNamespace string) (string, error) {
+	nn := types.NamespacedName{
+		Namespace: useIfNonEmptyElseDefaultTo(principalSet.PoolRef.Namespace, resourceNamespace),
+		Name:      principalSet.PoolRef.Name,
+	}
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(IAMWorkloadIdentityPoolGVK)
+	if err := t.kubeClient.Get(ctx, nn, u); err != nil {
+		if errors.IsNotFound(err) {
+			return "", k8s.NewReferenceNotFoundError(IAMWorkloadIdentityPoolGVK, nn)
+		}
+		return "", fmt.Errorf("error retrieving resource '%v' with GroupVersionKind '%v': %w", nn, IAMWorkloadIdentityPoolGVK, err)
+	}
+	pool, err := k8s.NewResource(u)
+	if err != nil {
+		return "", fmt.Errorf("error parsing %v %v: %w", IAMWorkloadIdentityPoolKind, nn, err)
+	}
+	if !k8s.IsResourceReady(pool) {
+		return "", k8s.NewReferenceNotReadyErrorForResource(pool)
+	}
+	poolID, _, err := unstructured.NestedString(pool.Spec, k8s.ResourceIDFieldName)
+	if err != nil {
+		return "", fmt.Errorf("error getting the value of '%v' of %v %v: %w", k8s.ResourceIDFieldPath, IAMWorkloadIdentityPoolKind, nn, err)
+	}
+	if poolID == "" {
+		poolID = pool.GetName()
+	}
+	projectRef, _, err := unstructured.NestedStringMap(pool.Spec, "projectRef")
+	if err != nil {
+		return "", fmt.Errorf("error getting the value of 'spec.projectRef' of %v %v: %w", IAMWorkloadIdentityPoolKind, nn, err)
+	}
+	ref := v1beta1.ProjectReference{
+		Namespace: projectRef["namespace"],
+		Name:      projectRef["name"],
+		External:  projectRef["external"],
+	}
+	projectNumber, err := t.resolveProjectNumber(ctx, ref, pool.GetNamespace())
+	if err != nil {
+		return "", err
+	}
+	return WorkloadIdentityPoolPrincipalSetMember(projectNumber, poolID, principalSet.Attribute), nil
+}
+
+// WorkloadIdentityPoolPrincipalSetMember returns the IAM principal set of the identities selected by
+// the given attribute, or of all the identities if it is empty, in the given workload identity pool.
+func WorkloadIdentityPoolPrincipalSetMember(projectNumber, poolID, attribute string) string {
+	if attribute == "" {
+		attribute = "*"
+	}
+	return fmt.Sprintf("principalSet://iam.googleapis.com/projects/%v/locations/global/workloadIdentityPools/%v/%v", projectNumber, poolID, attribute)
+}
+
+// resolveProjectNumber returns the number of the given project. A project referenced by name is read
+// from its Project resource, and a project referenced by ID is read from GCP.
+func (t *TFIAMClient) resolveProjectNumber(ctx context.Context, ref v1beta1.ProjectReference, resourceNamespace string) (string, error) {
+	if (ref.Name == "") == (ref.External == "") {
+		return "", fmt.Errorf("invalid project reference: exactly one of 'name' or 'external' must be used")
+	}
+	if ref.Name != "" {
+		nn := types.NamespacedName{
+			Namespace: useIfNonEmptyElseDefaultTo(ref.Namespace, resourceNamespace),
+			Name:      ref.Name,
+		}
+		project, err := t.getResource(ctx, ProjectGVK, nn)
+		if err != nil {
+			return "", err
+		}
+		number, _, err := unstructured.NestedString(project.Status, "number")
+		if err != nil {
+			return "", fmt.Errorf("error getting the number of Project %v: %w", nn, err)
+		}
+		if number == "" {
+			return "", k8s.NewReferenceNotReadyErrorForResource(&project.Resource)
+		}
+		return number, nil
+	}
+	projectID := strings.TrimPrefix(ref.External, "projects/")
+	if projectNumberRegex.MatchString(projectID) {
+		return projectID, nil
+	}
+	config, ok := t.provider.Meta().(*google.Config)
+	if !ok {
+		return "", fmt.Errorf("unable to look up the number of project '%v': the provider is not configured", projectID)
+	}
+	project, err := config.NewResourceManagerClient(gcp.KCCUserAgent).Projects.Get(projectID).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("error getting project '%v': %w", projectID, err)
+	}
+	return strconv.FormatInt(project.ProjectNumber, 10), nil
+}
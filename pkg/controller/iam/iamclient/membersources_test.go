@@ -0,0 +1,144 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/test/mocks"
+)
+
+func TestResolveMemberIdentityWithNewMemberSources(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := mocks.Manager{}.GetClient()
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo-ns",
+			Annotations: map[string]string{
+				k8s.ProjectIDAnnotation: "foo-project",
+			},
+		},
+	}
+	if err := kubeClient.Create(ctx, namespace); err != nil {
+		t.Fatalf("error creating namespace: %v", err)
+	}
+	tfIAMClient := &TFIAMClient{kubeClient: kubeClient}
+	tests := []struct {
+		name       string
+		memberFrom *v1beta1.MemberSource
+		expected   string
+		hasError   bool
+	}{
+		{
+			name: "kubernetes service account in the resource's namespace",
+			memberFrom: &v1beta1.MemberSource{
+				KubernetesServiceAccountRef: &v1beta1.MemberReference{Name: "foo-ksa"},
+			},
+			expected: "serviceAccount:foo-project.svc.id.goog[foo-ns/foo-ksa]",
+		},
+		{
+			name: "service agent in the gcp-sa domain of its service",
+			memberFrom: &v1beta1.MemberSource{
+				ServiceAgent: &v1beta1.ServiceAgentSource{
+					ProjectRef: v1beta1.ProjectReference{External: "projects/123456789"},
+					Service:    "pubsub.googleapis.com",
+				},
+			},
+			expected: "serviceAccount:service-123456789@gcp-sa-pubsub.iam.gserviceaccount.com",
+		},
+		{
+			name: "service agent with a custom domain",
+			memberFrom: &v1beta1.MemberSource{
+				ServiceAgent: &v1beta1.ServiceAgentSource{
+					ProjectRef: v1beta1.ProjectReference{External: "123456789"},
+					Service:    "container.googleapis.com",
+				},
+			},
+			expected: "serviceAccount:service-123456789@container-engine-robot.iam.gserviceaccount.com",
+		},
+		{
+			name: "service agent of an unknown service",
+			memberFrom: &v1beta1.MemberSource{
+				ServiceAgent: &v1beta1.ServiceAgentSource{
+					ProjectRef: v1beta1.ProjectReference{External: "123456789"},
+					Service:    "example.googleapis.com",
+				},
+			},
+			hasError: true,
+		},
+		{
+			name: "service agent of an invalid service",
+			memberFrom: &v1beta1.MemberSource{
+				ServiceAgent: &v1beta1.ServiceAgentSource{
+					ProjectRef: v1beta1.ProjectReference{External: "123456789"},
+					Service:    "pubsub",
+				},
+			},
+			hasError: true,
+		},
+		{
+			name: "multiple sources",
+			memberFrom: &v1beta1.MemberSource{
+				KubernetesServiceAccountRef: &v1beta1.MemberReference{Name: "foo-ksa"},
+				ServiceAccountRef:           &v1beta1.MemberReference{Name: "foo-gsa"},
+			},
+			hasError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := ResolveMemberIdentity(ctx, "", tc.memberFrom, "foo-ns", tfIAMClient)
+			if tc.hasError {
+				if err == nil {
+					t.Fatalf("expected an error, got member '%v'", id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id != tc.expected {
+				t.Fatalf("got member '%v', want '%v'", id, tc.expected)
+			}
+		})
+	}
+}
+
+func TestWorkloadIdentityPoolPrincipalSetMember(t *testing.T) {
+	tests := []struct {
+		attribute string
+		expected  string
+	}{
+		{
+			attribute: "",
+			expected:  "principalSet://iam.googleapis.com/projects/123456789/locations/global/workloadIdentityPools/foo-pool/*",
+		},
+		{
+			attribute: "attribute.repository/foo-org/foo-repo",
+			expected:  "principalSet://iam.googleapis.com/projects/123456789/locations/global/workloadIdentityPools/foo-pool/attribute.repository/foo-org/foo-repo",
+		},
+	}
+	for _, tc := range tests {
+		if got := WorkloadIdentityPoolPrincipalSetMember("123456789", "foo-pool", tc.attribute); got != tc.expected {
+			t.Errorf("got '%v', want '%v'", got, tc.expected)
+		}
+	}
+}
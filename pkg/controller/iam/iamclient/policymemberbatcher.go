@@ -0,0 +1,303 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultPolicyMemberBatchWindow is how long the writes of IAMPolicyMembers targeting the same
+	// resource are collected before being applied together.
+	DefaultPolicyMemberBatchWindow = 500 * time.Millisecond
+
+	// maxBatchAttempts is the number of times a batch is re-read and re-applied when the policy
+	// is modified concurrently by another actor.
+	maxBatchAttempts = 5
+
+	// batchFlushTimeout bounds the time spent reading and writing the policy of a batch.
+	batchFlushTimeout = 5 * time.Minute
+)
+
+// policyClient reads and writes whole IAM policies. It is implemented by IAMClient.
+type policyClient interface {
+	GetPolicy(ctx context.Context, policy *v1beta1.IAMPolicy) (*v1beta1.IAMPolicy, error)
+	SetPolicy(ctx context.Context, policy *v1beta1.IAMPolicy) (*v1beta1.IAMPolicy, error)
+}
+
+// PolicyMemberBatcher coalesces the adds and removes of IAM policy members targeting the same resource.
+// Instead of a read-modify-write of the whole policy per member, the operations submitted for a resource
+// within the batch window are applied with a single read and a single write of its policy, and the result
+// is reported back to each caller. This avoids etag conflicts between the reconciliations of many
+// IAMPolicyMembers targeting the same resource, e.g. a project.
+type PolicyMemberBatcher struct {
+	client policyClient
+	window time.Duration
+
+	mu      sync.Mutex
+	batches map[string]*memberBatch
+}
+
+type memberBatch struct {
+	// skeleton is used to read and write the policy of the batch's resource.
+	skeleton *v1beta1.IAMPolicy
+	ops      []*memberOp
+}
+
+type memberOp struct {
+	role      string
+	condition *v1beta1.IAMCondition
+	member    v1beta1.Member
+	remove    bool
+	done      chan error
+}
+
+func NewPolicyMemberBatcher(client policyClient, window time.Duration) *PolicyMemberBatcher {
+	return &PolicyMemberBatcher{
+		client:  client,
+		window:  window,
+		batches: make(map[string]*memberBatch),
+	}
+}
+
+// AddMember adds the given member to the binding with the given role and condition in the policy of the
+// resource referenced by the given policy skeleton, and waits until the batch it belongs to is applied.
+func (b *PolicyMemberBatcher) AddMember(ctx context.Context, skeleton *v1beta1.IAMPolicy, role string, condition *v1beta1.IAMCondition, member v1beta1.Member) error {
+	return b.submit(ctx, skeleton, &memberOp{role: role, condition: condition, member: member})
+}
+
+// RemoveMember removes the given member from the binding with the given role and condition in the policy of
+// the resource referenced by the given policy skeleton, and waits until the batch it belongs to is applied.
+// NotFoundError is returned if the policy doesn't contain the member.
+func (b *PolicyMemberBatcher) RemoveMember(ctx context.Context, skeleton *v1beta1.IAMPolicy, role string, condition *v1beta1.IAMCondition, member v1beta1.Member) error {
+	return b.submit(ctx, skeleton, &memberOp{role: role, condition: condition, member: member, remove: true})
+}
+
+func (b *PolicyMemberBatcher) submit(ctx context.Context, skeleton *v1beta1.IAMPolicy, op *memberOp) error {
+	op.done = make(chan error, 1)
+	key := batchKey(skeleton)
+	b.mu.Lock()
+	batch, ok := b.batches[key]
+	if !ok {
+		batch = &memberBatch{skeleton: skeleton}
+		b.batches[key] = batch
+		time.AfterFunc(b.window, func() { b.flush(key) })
+	}
+	batch.ops = append(batch.ops, op)
+	b.mu.Unlock()
+
+	select {
+	case err := <-op.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *PolicyMemberBatcher) flush(key string) {
+	b.mu.Lock()
+	batch := b.batches[key]
+	// Operations submitted from now on go into a new batch.
+	delete(b.batches, key)
+	b.mu.Unlock()
+	if batch == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), batchFlushTimeout)
+	defer cancel()
+	var results []error
+	var err error
+	for attempt := 1; attempt <= maxBatchAttempts; attempt++ {
+		results, err = b.apply(ctx, batch)
+		if err == nil || !isConcurrentPolicyModificationError(err) {
+			break
+		}
+		logger.Info("IAM policy was modified concurrently; retrying the batch", "resource", key, "attempt", attempt)
+	}
+	for i, op := range batch.ops {
+		if err != nil {
+			op.done <- err
+			continue
+		}
+		op.done <- results[i]
+	}
+}
+
+// apply reads the policy of the batch's resource, applies all the operations of the batch to it and writes
+// it back if it changed. It returns the result of each operation.
+func (b *PolicyMemberBatcher) apply(ctx context.Context, batch *memberBatch) ([]error, error) {
+	livePolicy, err := b.client.GetPolicy(ctx, batch.skeleton.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+	bindings := make([]v1beta1.IAMPolicyBinding, 0, len(livePolicy.Spec.Bindings))
+	for _, binding := range livePolicy.Spec.Bindings {
+		bindings = append(bindings, *binding.DeepCopy())
+	}
+	results := make([]error, len(batch.ops))
+	changed := false
+	for i, op := range batch.ops {
+		var opChanged bool
+		if op.remove {
+			bindings, opChanged = removeMemberFromBindings(bindings, op.role, op.condition, op.member)
+			if !opChanged {
+				results[i] = NotFoundError
+			}
+		} else {
+			bindings, opChanged = addMemberToBindings(bindings, op.role, op.condition, op.member)
+		}
+		changed = changed || opChanged
+	}
+	if !changed {
+		return results, nil
+	}
+	desiredPolicy := batch.skeleton.DeepCopy()
+	desiredPolicy.Spec.Bindings = bindings
+	// Carry the etag from the read so that the write fails if the policy has been modified since.
+	desiredPolicy.Spec.Etag = livePolicy.Spec.Etag
+	desiredPolicy.Spec.AuditConfigs = livePolicy.Spec.AuditConfigs
+	if _, err := b.client.SetPolicy(ctx, desiredPolicy); err != nil {
+		return nil, fmt.Errorf("error setting policy: %w", err)
+	}
+	return results, nil
+}
+
+func addMemberToBindings(bindings []v1beta1.IAMPolicyBinding, role string, condition *v1beta1.IAMCondition, member v1beta1.Member) ([]v1beta1.IAMPolicyBinding, bool) {
+	for i := range bindings {
+		if !bindingMatches(bindings[i], role, condition) {
+			continue
+		}
+		for _, m := range bindings[i].Members {
+			if m == member {
+				return bindings, false
+			}
+		}
+		bindings[i].Members = append(bindings[i].Members, member)
+		return bindings, true
+	}
+	return append(bindings, v1beta1.IAMPolicyBinding{
+		Role:      role,
+		Condition: condition.DeepCopy(),
+		Members:   []v1beta1.Member{member},
+	}), true
+}
+
+func removeMemberFromBindings(bindings []v1beta1.IAMPolicyBinding, role string, condition *v1beta1.IAMCondition, member v1beta1.Member) ([]v1beta1.IAMPolicyBinding, bool) {
+	for i := range bindings {
+		if !bindingMatches(bindings[i], role, condition) {
+			continue
+		}
+		for j, m := range bindings[i].Members {
+			if m != member {
+				continue
+			}
+			bindings[i].Members = append(bindings[i].Members[:j:j], bindings[i].Members[j+1:]...)
+			if len(bindings[i].Members) == 0 {
+				bindings = append(bindings[:i:i], bindings[i+1:]...)
+			}
+			return bindings, true
+		}
+	}
+	return bindings, false
+}
+
+func bindingMatches(binding v1beta1.IAMPolicyBinding, role string, condition *v1beta1.IAMCondition) bool {
+	if binding.Role != role {
+		return false
+	}
+	if binding.Condition == nil || condition == nil {
+		return binding.Condition == nil && condition == nil
+	}
+	return *binding.Condition == *condition
+}
+
+// batchKey identifies the resource whose policy is read and written through the given policy skeleton.
+func batchKey(skeleton *v1beta1.IAMPolicy) string {
+	ref := skeleton.Spec.ResourceReference
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = skeleton.Namespace
+	}
+	return fmt.Sprintf("%v/%v/%v/%v/%v", ref.APIVersion, ref.Kind, namespace, ref.Name, ref.External)
+}
+
+// tfGoogleAPIErrorCodeRegex matches the status code of the googleapi errors rendered in the diagnostics
+// of Terraform operations, e.g. 'Error setting IAM policy for project "foo": googleapi: Error 412: ...'.
+var tfGoogleAPIErrorCodeRegex = regexp.MustCompile(`googleapi: Error (\d{3})`)
+
+// tfGoogleAPIError is an error flattened from Terraform diagnostics that rendered a googleapi error.
+// It unwraps to a *googleapi.Error with the rendered status code so that the status code can be
+// checked like that of the errors returned by the GCP client libraries.
+type tfGoogleAPIError struct {
+	err  error
+	gErr *googleapi.Error
+}
+
+func (e *tfGoogleAPIError) Error() string {
+	return e.err.Error()
+}
+
+func (e *tfGoogleAPIError) Unwrap() error {
+	return e.gErr
+}
+
+// withGoogleAPIErrorCode returns the given error, flattened from Terraform diagnostics, as an error
+// unwrapping to a *googleapi.Error if the diagnostics rendered a googleapi error, and as is otherwise.
+func withGoogleAPIErrorCode(err error) error {
+	m := tfGoogleAPIErrorCodeRegex.FindStringSubmatch(err.Error())
+	if m == nil {
+		return err
+	}
+	code, convErr := strconv.Atoi(m[1])
+	if convErr != nil {
+		return err
+	}
+	return &tfGoogleAPIError{err: err, gErr: &googleapi.Error{Code: code, Message: err.Error()}}
+}
+
+func isConcurrentPolicyModificationError(err error) bool {
+	var gErr *googleapi.Error
+	if !errors.As(err, &gErr) {
+		return false
+	}
+	return gErr.Code == http.StatusConflict || gErr.Code == http.StatusPreconditionFailed
+}
+
+// newPolicySkeletonForPolicyMember returns an IAMPolicy that can be passed to GetPolicy and SetPolicy
+// to read and write the policy of the resource referenced by the given IAMPolicyMember.
+func newPolicySkeletonForPolicyMember(policyMember *v1beta1.IAMPolicyMember) *v1beta1.IAMPolicy {
+	skeleton := &v1beta1.IAMPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1beta1.IAMPolicyGVK.Kind,
+			APIVersion: v1beta1.IAMAPIVersion,
+		},
+	}
+	skeleton.ObjectMeta = *policyMember.ObjectMeta.DeepCopy()
+	skeleton.Spec.ResourceReference = policyMember.Spec.ResourceReference
+	return skeleton
+}
@@ -0,0 +1,172 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testBatchWindow = 50 * time.Millisecond
+
+type fakePolicyClient struct {
+	mu       sync.Mutex
+	bindings []v1beta1.IAMPolicyBinding
+	gets     int
+	sets     int
+	// setErrs are returned, in order, by the first calls to SetPolicy.
+	setErrs []error
+}
+
+func (f *fakePolicyClient) GetPolicy(ctx context.Context, policy *v1beta1.IAMPolicy) (*v1beta1.IAMPolicy, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.gets++
+	policy.Spec.Bindings = append([]v1beta1.IAMPolicyBinding{}, f.bindings...)
+	policy.Spec.Etag = testEtag
+	return policy, nil
+}
+
+func (f *fakePolicyClient) SetPolicy(ctx context.Context, policy *v1beta1.IAMPolicy) (*v1beta1.IAMPolicy, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sets++
+	if len(f.setErrs) > 0 {
+		err := f.setErrs[0]
+		f.setErrs = f.setErrs[1:]
+		return nil, err
+	}
+	if policy.Spec.Etag != testEtag {
+		return nil, &googleapi.Error{Code: http.StatusPreconditionFailed}
+	}
+	f.bindings = policy.Spec.Bindings
+	return policy, nil
+}
+
+func newTestPolicySkeleton() *v1beta1.IAMPolicy {
+	return &v1beta1.IAMPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foo-ns",
+		},
+		Spec: v1beta1.IAMPolicySpec{
+			ResourceReference: v1beta1.ResourceReference{
+				APIVersion: "resourcemanager.cnrm.cloud.google.com/v1beta1",
+				Kind:       "Project",
+				External:   "projects/foo-project",
+			},
+		},
+	}
+}
+
+func TestPolicyMemberBatcherCoalescesConcurrentWrites(t *testing.T) {
+	client := &fakePolicyClient{}
+	batcher := NewPolicyMemberBatcher(client, testBatchWindow)
+	members := []v1beta1.Member{"user:a@example.com", "user:b@example.com", "user:c@example.com"}
+	var wg sync.WaitGroup
+	errs := make([]error, len(members))
+	for i, m := range members {
+		wg.Add(1)
+		go func(i int, m v1beta1.Member) {
+			defer wg.Done()
+			errs[i] = batcher.AddMember(context.Background(), newTestPolicySkeleton(), testRole1, nil, m)
+		}(i, m)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error adding member %v: %v", members[i], err)
+		}
+	}
+	if client.gets != 1 || client.sets != 1 {
+		t.Fatalf("got %v reads and %v writes of the policy, want 1 and 1", client.gets, client.sets)
+	}
+	if len(client.bindings) != 1 || len(client.bindings[0].Members) != len(members) {
+		t.Fatalf("expected a single binding with %v members, got %v", len(members), client.bindings)
+	}
+}
+
+func TestPolicyMemberBatcherAddAndRemove(t *testing.T) {
+	condition := &v1beta1.IAMCondition{
+		Title:      testTitle1,
+		Expression: testExpression1,
+	}
+	client := &fakePolicyClient{
+		bindings: []v1beta1.IAMPolicyBinding{
+			{
+				Role:    testRole1,
+				Members: []v1beta1.Member{"user:a@example.com"},
+			},
+			{
+				Role:      testRole1,
+				Condition: condition,
+				Members:   []v1beta1.Member{"user:a@example.com"},
+			},
+		},
+	}
+	batcher := NewPolicyMemberBatcher(client, testBatchWindow)
+	ctx := context.Background()
+
+	if err := batcher.RemoveMember(ctx, newTestPolicySkeleton(), testRole1, condition, "user:a@example.com"); err != nil {
+		t.Fatalf("unexpected error removing member: %v", err)
+	}
+	expectedBindings := []v1beta1.IAMPolicyBinding{
+		{
+			Role:    testRole1,
+			Members: []v1beta1.Member{"user:a@example.com"},
+		},
+	}
+	if !reflect.DeepEqual(client.bindings, expectedBindings) {
+		t.Fatalf("got bindings %v, want %v", client.bindings, expectedBindings)
+	}
+
+	err := batcher.RemoveMember(ctx, newTestPolicySkeleton(), testRole2, nil, "user:a@example.com")
+	if !errors.Is(err, NotFoundError) {
+		t.Fatalf("got error %v removing a member absent from the policy, want %v", err, NotFoundError)
+	}
+
+	sets := client.sets
+	if err := batcher.AddMember(ctx, newTestPolicySkeleton(), testRole1, nil, "user:a@example.com"); err != nil {
+		t.Fatalf("unexpected error adding member: %v", err)
+	}
+	if client.sets != sets {
+		t.Fatalf("expected the policy not to be written when adding a member it already contains")
+	}
+}
+
+func TestPolicyMemberBatcherRetriesOnConcurrentModification(t *testing.T) {
+	client := &fakePolicyClient{
+		setErrs: []error{&googleapi.Error{Code: http.StatusConflict}},
+	}
+	batcher := NewPolicyMemberBatcher(client, testBatchWindow)
+	if err := batcher.AddMember(context.Background(), newTestPolicySkeleton(), testRole1, nil, "user:a@example.com"); err != nil {
+		t.Fatalf("unexpected error adding member: %v", err)
+	}
+	if client.gets != 2 || client.sets != 2 {
+		t.Fatalf("got %v reads and %v writes of the policy, want 2 and 2", client.gets, client.sets)
+	}
+	if len(client.bindings) != 1 {
+		t.Fatalf("expected the member to be added after retrying, got bindings %v", client.bindings)
+	}
+}
@@ -169,7 +169,9 @@ func (t *TFIAMClient) SetPolicy(ctx context.Context, policy *v1beta1.IAMPolicy)
 	}
 	newState, diagnostics := resource.TFResource.Apply(ctx, liveState, diff, t.provider.Meta())
 	if err := krmtotf.NewErrorFromDiagnostics(diagnostics); err != nil {
-		return nil, fmt.Errorf("error applying changes: %w", err)
+		// Keep the status code of the error, if any, so that the policy member batcher can retry
+		// writes failing because of concurrent modifications of the policy.
+		return nil, fmt.Errorf("error applying changes: %w", withGoogleAPIErrorCode(err))
 	}
 	return newIAMPolicyFromTFState(resource, newState, policy)
 }
@@ -483,6 +485,38 @@ func (t *TFIAMClient) getResourceID(ctx context.Context, resourceRef v1beta1.Res
 	return "", fmt.Errorf("couldn't construct id for referenced resource")
 }
 
+// ResolveResourceID returns the ID of the resource referenced by the given reference, whose namespace defaults to the
+// given one. External references are returned in the form of the ID template of the referenced resource, e.g.
+// 'projects/foo' for 'foo', so that references by name and external references to the same resource have the same ID.
+func (t *TFIAMClient) ResolveResourceID(ctx context.Context, resourceRef v1beta1.ResourceReference, namespace string) (string, error) {
+	id, err := t.getResourceID(ctx, resourceRef, namespace)
+	if err != nil {
+		return "", err
+	}
+	if resourceRef.External == "" {
+		return id, nil
+	}
+	rc, err := t.getResourceConfigForExternalRef(resourceRef)
+	if err != nil {
+		return "", err
+	}
+	return withIDTemplatePrefix(id, rc.IDTemplate), nil
+}
+
+// withIDTemplatePrefix adds the constant prefix of the given ID template, e.g. 'projects/' for
+// 'projects/{{project_id}}', to the given ID if the template only has one field and the ID is the bare value of that
+// field.
+func withIDTemplatePrefix(id, idTemplate string) string {
+	if strings.Count(idTemplate, "{{") != 1 || !strings.HasSuffix(idTemplate, "}}") {
+		return id
+	}
+	prefix := idTemplate[:strings.Index(idTemplate, "{{")]
+	if prefix == "" || strings.HasPrefix(id, prefix) || strings.Contains(id, "/") {
+		return id
+	}
+	return prefix + id
+}
+
 func (t *TFIAMClient) getResourceConfigForReferencedResource(ctx context.Context, iamInterface interface{}) (*corekccv1alpha1.ResourceConfig, error) {
 	namespace, resourceRef := extractNamespaceAndResourceReference(iamInterface)
 	if resourceRef.External != "" {
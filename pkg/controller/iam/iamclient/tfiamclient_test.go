@@ -0,0 +1,136 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	testservicemappingloader "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/test/servicemappingloader"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	tfschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/test/mocks"
+)
+
+// fakeProjectIAMPolicy replaces the google_project_iam_policy Terraform resource. It stores the
+// policy in memory and fails the first writes with the given errors, as the provider would.
+type fakeProjectIAMPolicy struct {
+	mu         sync.Mutex
+	policyData string
+	etag       int
+	writes     int
+	writeErrs  []error
+}
+
+func (f *fakeProjectIAMPolicy) read(_ context.Context, d *tfschema.ResourceData, _ interface{}) diag.Diagnostics {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	d.Set("policy_data", f.policyData)
+	d.Set("etag", fmt.Sprintf("etag-%v", f.etag))
+	return nil
+}
+
+func (f *fakeProjectIAMPolicy) write(ctx context.Context, d *tfschema.ResourceData, meta interface{}) diag.Diagnostics {
+	f.mu.Lock()
+	f.writes++
+	if len(f.writeErrs) > 0 {
+		err := f.writeErrs[0]
+		f.writeErrs = f.writeErrs[1:]
+		f.mu.Unlock()
+		return diag.FromErr(err)
+	}
+	f.policyData = d.Get("policy_data").(string)
+	f.etag++
+	f.mu.Unlock()
+	d.SetId(d.Get("project").(string))
+	return f.read(ctx, d, meta)
+}
+
+func newTestProviderWithFakeProjectIAMPolicy(f *fakeProjectIAMPolicy) *tfschema.Provider {
+	provider := google.Provider()
+	orig := provider.ResourcesMap["google_project_iam_policy"]
+	provider.ResourcesMap["google_project_iam_policy"] = &tfschema.Resource{
+		Schema:        orig.Schema,
+		Importer:      orig.Importer,
+		ReadContext:   f.read,
+		CreateContext: f.write,
+		UpdateContext: f.write,
+		DeleteContext: func(context.Context, *tfschema.ResourceData, interface{}) diag.Diagnostics { return nil },
+	}
+	return provider
+}
+
+func TestPolicyMemberBatcherRetriesConcurrentModificationErrorsOfTFClient(t *testing.T) {
+	fake := &fakeProjectIAMPolicy{
+		policyData: `{"bindings":[{"role":"roles/viewer","members":["user:existing@example.com"]}]}`,
+		writeErrs: []error{
+			fmt.Errorf(`Error setting IAM policy for project "foo-project": googleapi: Error 412: There were concurrent policy changes. ` +
+				`Please retry the whole read-modify-write with exponential backoff., conditionNotMet`),
+		},
+	}
+	tfIAMClient := &TFIAMClient{
+		kubeClient: mocks.Manager{}.GetClient(),
+		provider:   newTestProviderWithFakeProjectIAMPolicy(fake),
+		smLoader:   testservicemappingloader.New(t),
+	}
+	batcher := NewPolicyMemberBatcher(tfIAMClient, testBatchWindow)
+	skeleton := &v1beta1.IAMPolicy{
+		Spec: v1beta1.IAMPolicySpec{
+			ResourceReference: v1beta1.ResourceReference{
+				APIVersion: "resourcemanager.cnrm.cloud.google.com/v1beta1",
+				Kind:       "Project",
+				External:   "projects/foo-project",
+			},
+		},
+	}
+	skeleton.Namespace = "foo-ns"
+	if err := batcher.AddMember(context.Background(), skeleton, testRole1, nil, "user:a@example.com"); err != nil {
+		t.Fatalf("unexpected error adding member: %v", err)
+	}
+	if fake.writes != 2 {
+		t.Fatalf("got %v writes of the policy, want 2", fake.writes)
+	}
+	policy, err := tfIAMClient.GetPolicy(context.Background(), skeleton.DeepCopy())
+	if err != nil {
+		t.Fatalf("error getting policy: %v", err)
+	}
+	if len(policy.Spec.Bindings) != 2 {
+		t.Fatalf("expected the member to be added after retrying, got bindings %v", policy.Spec.Bindings)
+	}
+}
+
+func TestWithIDTemplatePrefix(t *testing.T) {
+	tests := []struct {
+		id         string
+		idTemplate string
+		expected   string
+	}{
+		{id: "foo", idTemplate: "projects/{{project_id}}", expected: "projects/foo"},
+		{id: "projects/foo", idTemplate: "projects/{{project_id}}", expected: "projects/foo"},
+		{id: "foo", idTemplate: "{{project}}/{{name}}", expected: "foo"},
+		{id: "bar/foo", idTemplate: "projects/{{project}}/topics/{{name}}", expected: "bar/foo"},
+		{id: "foo", idTemplate: "", expected: "foo"},
+	}
+	for _, tc := range tests {
+		if got := withIDTemplatePrefix(tc.id, tc.idTemplate); got != tc.expected {
+			t.Errorf("got '%v' for ID '%v' and template '%v', want '%v'", got, tc.id, tc.idTemplate, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package managementconflict detects IAM resources fighting over the IAM policy of the same
+// resource. An IAMPolicy manages the whole IAM policy of the resource it references, so any
+// other IAMPolicy, IAMPartialPolicy or IAMPolicyMember referencing the same resource has its
+// bindings overwritten on every reconciliation of the IAMPolicy, and vice versa.
+package managementconflict
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type iamObject struct {
+	kind        string
+	meta        metav1.Object
+	resourceRef v1beta1.ResourceReference
+}
+
+func (o *iamObject) id() string {
+	return fmt.Sprintf("%v/%v/%v", o.kind, o.meta.GetNamespace(), o.meta.GetName())
+}
+
+// ResourceIDResolver resolves a resource reference, whose namespace defaults to the given one, to
+// the ID of the referenced resource.
+type ResourceIDResolver interface {
+	ResolveResourceID(ctx context.Context, ref v1beta1.ResourceReference, namespace string) (string, error)
+}
+
+// FindConflicts returns the IAM resources, in all the namespaces visible to kubeClient, that
+// manage the IAM policy of the resource referenced by the given IAMPolicy, IAMPartialPolicy or
+// IAMPolicyMember in a way that conflicts with it. Each conflicting resource is identified as
+// '<kind>/<namespace>/<name>', and the result is sorted. Resources being deleted are ignored.
+func FindConflicts(ctx context.Context, kubeClient client.Client, resolver ResourceIDResolver, obj client.Object) ([]string, error) {
+	o, err := toIAMObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := listIAMPolicies(ctx, kubeClient)
+	if err != nil {
+		return nil, err
+	}
+	if o.kind == v1beta1.IAMPolicyGVK.Kind {
+		partialPolicies, err := listIAMPartialPolicies(ctx, kubeClient)
+		if err != nil {
+			return nil, err
+		}
+		policyMembers, err := listIAMPolicyMembers(ctx, kubeClient)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, partialPolicies...)
+		candidates = append(candidates, policyMembers...)
+	}
+	matcher := NewReferenceMatcher(ctx, resolver, o.resourceRef, o.meta.GetNamespace())
+	conflicts := make([]string, 0)
+	for _, c := range candidates {
+		if c.id() == o.id() || !c.meta.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if matcher.Matches(ctx, c.resourceRef, c.meta.GetNamespace()) {
+			conflicts = append(conflicts, c.id())
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts, nil
+}
+
+// NewConflictError returns the error describing the conflicts, as returned by FindConflicts,
+// of the IAM resource of the given kind.
+func NewConflictError(kind string, conflicts []string) error {
+	if kind == v1beta1.IAMPolicyGVK.Kind {
+		return fmt.Errorf("the IAM policy of the referenced resource is also managed by %v; an IAMPolicy "+
+			"manages the whole IAM policy of the resource and cannot be used along with other IAM resources "+
+			"referencing the same resource", strings.Join(conflicts, ", "))
+	}
+	return fmt.Errorf("the IAM policy of the referenced resource is managed authoritatively by %v; %v "+
+		"cannot be used along with an IAMPolicy referencing the same resource", strings.Join(conflicts, ", "), kind)
+}
+
+// ReferenceMatcher matches resource references against the resource referenced by a given
+// reference. References are compared by the IDs of the resources they reference, so that e.g. a
+// reference by name and an external reference to the same resource match. References that cannot
+// be resolved, e.g. because the referenced resource is not ready yet, are compared as written, see
+// ReferencesSameResource.
+type ReferenceMatcher struct {
+	resolver  ResourceIDResolver
+	ref       v1beta1.ResourceReference
+	namespace string
+	// id is the ID of the resource referenced by ref, or empty if ref cannot be resolved.
+	id string
+}
+
+// NewReferenceMatcher returns the matcher of the references to the resource referenced by the
+// given reference, whose namespace defaults to the given one.
+func NewReferenceMatcher(ctx context.Context, resolver ResourceIDResolver, ref v1beta1.ResourceReference, namespace string) *ReferenceMatcher {
+	m := &ReferenceMatcher{
+		resolver:  resolver,
+		ref:       ref,
+		namespace: namespace,
+	}
+	if id, err := resolver.ResolveResourceID(ctx, ref, namespace); err == nil {
+		m.id = id
+	}
+	return m
+}
+
+// Matches returns true if the given reference, whose namespace defaults to the given one, points
+// at the resource of the matcher.
+func (m *ReferenceMatcher) Matches(ctx context.Context, ref v1beta1.ResourceReference, namespace string) bool {
+	if ref.Kind != m.ref.Kind {
+		return false
+	}
+	if ReferencesSameResource(ref, namespace, m.ref, m.namespace) {
+		return true
+	}
+	if m.id == "" {
+		return false
+	}
+	id, err := m.resolver.ResolveResourceID(ctx, ref, namespace)
+	return err == nil && id == m.id
+}
+
+// ReferencesSameResource returns true if the two resource references, whose namespaces default to
+// the given ones, are written to point at the same resource. References by name and by external
+// value are only compared with references of the same form; use a ReferenceMatcher to compare
+// the resources they resolve to.
+func ReferencesSameResource(ref1 v1beta1.ResourceReference, namespace1 string, ref2 v1beta1.ResourceReference, namespace2 string) bool {
+	if ref1.Kind != ref2.Kind {
+		return false
+	}
+	if ref1.External != "" || ref2.External != "" {
+		return ref1.External == ref2.External
+	}
+	if ref1.Namespace != "" {
+		namespace1 = ref1.Namespace
+	}
+	if ref2.Namespace != "" {
+		namespace2 = ref2.Namespace
+	}
+	return ref1.Name == ref2.Name && namespace1 == namespace2
+}
+
+func toIAMObject(obj client.Object) (*iamObject, error) {
+	switch o := obj.(type) {
+	case *v1beta1.IAMPolicy:
+		return &iamObject{kind: v1beta1.IAMPolicyGVK.Kind, meta: o, resourceRef: o.Spec.ResourceReference}, nil
+	case *v1beta1.IAMPartialPolicy:
+		return &iamObject{kind: v1beta1.IAMPartialPolicyGVK.Kind, meta: o, resourceRef: o.Spec.ResourceReference}, nil
+	case *v1beta1.IAMPolicyMember:
+		return &iamObject{kind: v1beta1.IAMPolicyMemberGVK.Kind, meta: o, resourceRef: o.Spec.ResourceReference}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T: only IAMPolicy, IAMPartialPolicy and IAMPolicyMember are supported", obj)
+	}
+}
+
+func listIAMPolicies(ctx context.Context, kubeClient client.Client) ([]*iamObject, error) {
+	list := &v1beta1.IAMPolicyList{}
+	if err := kubeClient.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("error listing IAMPolicies: %w", err)
+	}
+	res := make([]*iamObject, 0, len(list.Items))
+	for i := range list.Items {
+		o, _ := toIAMObject(&list.Items[i])
+		res = append(res, o)
+	}
+	return res, nil
+}
+
+func listIAMPartialPolicies(ctx context.Context, kubeClient client.Client) ([]*iamObject, error) {
+	list := &v1beta1.IAMPartialPolicyList{}
+	if err := kubeClient.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("error listing IAMPartialPolicies: %w", err)
+	}
+	res := make([]*iamObject, 0, len(list.Items))
+	for i := range list.Items {
+		o, _ := toIAMObject(&list.Items[i])
+		res = append(res, o)
+	}
+	return res, nil
+}
+
+func listIAMPolicyMembers(ctx context.Context, kubeClient client.Client) ([]*iamObject, error) {
+	list := &v1beta1.IAMPolicyMemberList{}
+	if err := kubeClient.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("error listing IAMPolicyMembers: %w", err)
+	}
+	res := make([]*iamObject, 0, len(list.Items))
+	for i := range list.Items {
+		o, _ := toIAMObject(&list.Items[i])
+		res = append(res, o)
+	}
+	return res, nil
+}
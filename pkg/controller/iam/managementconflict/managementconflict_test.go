@@ -0,0 +1,266 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managementconflict_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	iamv1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/managementconflict"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/test/mocks"
+)
+
+func TestFindConflicts(t *testing.T) {
+	if err := iamv1beta1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatalf("error adding IAM types to the scheme: %v", err)
+	}
+	ctx := context.Background()
+	kubeClient := mocks.Manager{}.GetClient()
+	project := iamv1beta1.ResourceReference{Kind: "Project", Name: "foo-project"}
+	otherProject := iamv1beta1.ResourceReference{Kind: "Project", Name: "bar-project"}
+	deletionTimestamp := metav1.Now()
+	existing := []client.Object{
+		&iamv1beta1.IAMPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo-ns", Name: "policy"},
+			Spec:       iamv1beta1.IAMPolicySpec{ResourceReference: project},
+		},
+		&iamv1beta1.IAMPartialPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo-ns", Name: "partial"},
+			Spec:       iamv1beta1.IAMPartialPolicySpec{ResourceReference: project},
+		},
+		&iamv1beta1.IAMPolicyMember{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo-ns", Name: "member"},
+			Spec:       iamv1beta1.IAMPolicyMemberSpec{ResourceReference: project},
+		},
+		&iamv1beta1.IAMPolicyMember{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo-ns", Name: "deleted-member", DeletionTimestamp: &deletionTimestamp},
+			Spec:       iamv1beta1.IAMPolicyMemberSpec{ResourceReference: project},
+		},
+		&iamv1beta1.IAMPolicyMember{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo-ns", Name: "other-member"},
+			Spec:       iamv1beta1.IAMPolicyMemberSpec{ResourceReference: otherProject},
+		},
+		&iamv1beta1.IAMPolicyMember{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "bar-ns", Name: "external-member"},
+			Spec: iamv1beta1.IAMPolicyMemberSpec{
+				ResourceReference: iamv1beta1.ResourceReference{Kind: "Project", External: "foo-project-id"},
+			},
+		},
+	}
+	for _, obj := range existing {
+		if err := kubeClient.Create(ctx, obj); err != nil {
+			t.Fatalf("error creating %v: %v", obj.GetName(), err)
+		}
+	}
+	tests := []struct {
+		name     string
+		obj      client.Object
+		expected []string
+	}{
+		{
+			name: "IAMPolicy conflicts with every other IAM resource on the same resource",
+			obj:  existing[0],
+			expected: []string{
+				"IAMPartialPolicy/foo-ns/partial",
+				"IAMPolicyMember/bar-ns/external-member",
+				"IAMPolicyMember/foo-ns/member",
+			},
+		},
+		{
+			name:     "IAMPartialPolicy only conflicts with IAMPolicies",
+			obj:      existing[1],
+			expected: []string{"IAMPolicy/foo-ns/policy"},
+		},
+		{
+			name: "new IAMPolicyMember on the same resource",
+			obj: &iamv1beta1.IAMPolicyMember{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "foo-ns", Name: "new-member"},
+				Spec:       iamv1beta1.IAMPolicyMemberSpec{ResourceReference: project},
+			},
+			expected: []string{"IAMPolicy/foo-ns/policy"},
+		},
+		{
+			name: "new IAMPolicyMember in another namespace referencing the same resource by external value",
+			obj: &iamv1beta1.IAMPolicyMember{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "baz-ns", Name: "new-member"},
+				Spec: iamv1beta1.IAMPolicyMemberSpec{
+					ResourceReference: iamv1beta1.ResourceReference{Kind: "Project", External: "projects/foo-project-id"},
+				},
+			},
+			expected: []string{"IAMPolicy/foo-ns/policy"},
+		},
+		{
+			name: "new IAMPolicy on a resource without other IAM resources",
+			obj: &iamv1beta1.IAMPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "foo-ns", Name: "new-policy"},
+				Spec: iamv1beta1.IAMPolicySpec{
+					ResourceReference: iamv1beta1.ResourceReference{Kind: "Project", Name: "baz-project"},
+				},
+			},
+			expected: []string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conflicts, err := managementconflict.FindConflicts(ctx, kubeClient, newTestResolver(), tc.obj)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, conflicts); diff != "" {
+				t.Errorf("unexpected conflicts diff (-want +got): \n%v", diff)
+			}
+		})
+	}
+}
+
+func TestReferenceMatcher(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		ref1       iamv1beta1.ResourceReference
+		namespace1 string
+		ref2       iamv1beta1.ResourceReference
+		namespace2 string
+		expected   bool
+	}{
+		{
+			name:       "reference by name and external reference to the same resource",
+			ref1:       iamv1beta1.ResourceReference{Kind: "Project", Name: "foo-project"},
+			namespace1: "foo-ns",
+			ref2:       iamv1beta1.ResourceReference{Kind: "Project", External: "foo-project-id"},
+			expected:   true,
+		},
+		{
+			name:     "external references in different forms",
+			ref1:     iamv1beta1.ResourceReference{Kind: "Project", External: "projects/foo-project-id"},
+			ref2:     iamv1beta1.ResourceReference{Kind: "Project", External: "foo-project-id"},
+			expected: true,
+		},
+		{
+			name:       "references to different resources",
+			ref1:       iamv1beta1.ResourceReference{Kind: "Project", Name: "bar-project"},
+			namespace1: "foo-ns",
+			ref2:       iamv1beta1.ResourceReference{Kind: "Project", External: "foo-project-id"},
+			expected:   false,
+		},
+		{
+			name:       "unresolvable references written the same way",
+			ref1:       iamv1beta1.ResourceReference{Kind: "Project", Name: "unknown-project"},
+			namespace1: "foo-ns",
+			ref2:       iamv1beta1.ResourceReference{Kind: "Project", Name: "unknown-project"},
+			namespace2: "foo-ns",
+			expected:   true,
+		},
+		{
+			name:       "unresolvable reference and external reference",
+			ref1:       iamv1beta1.ResourceReference{Kind: "Project", Name: "unknown-project"},
+			namespace1: "foo-ns",
+			ref2:       iamv1beta1.ResourceReference{Kind: "Project", External: "foo-project-id"},
+			expected:   false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher := managementconflict.NewReferenceMatcher(ctx, newTestResolver(), tc.ref1, tc.namespace1)
+			if got := matcher.Matches(ctx, tc.ref2, tc.namespace2); got != tc.expected {
+				t.Errorf("got %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestReferencesSameResource(t *testing.T) {
+	tests := []struct {
+		name       string
+		ref1       iamv1beta1.ResourceReference
+		namespace1 string
+		ref2       iamv1beta1.ResourceReference
+		namespace2 string
+		expected   bool
+	}{
+		{
+			name:       "same name in the default namespace",
+			ref1:       iamv1beta1.ResourceReference{Kind: "Project", Name: "foo"},
+			namespace1: "foo-ns",
+			ref2:       iamv1beta1.ResourceReference{Kind: "Project", Name: "foo", Namespace: "foo-ns"},
+			namespace2: "bar-ns",
+			expected:   true,
+		},
+		{
+			name:       "same name in different namespaces",
+			ref1:       iamv1beta1.ResourceReference{Kind: "Project", Name: "foo"},
+			namespace1: "foo-ns",
+			ref2:       iamv1beta1.ResourceReference{Kind: "Project", Name: "foo"},
+			namespace2: "bar-ns",
+			expected:   false,
+		},
+		{
+			name:     "same external value",
+			ref1:     iamv1beta1.ResourceReference{Kind: "Project", External: "projects/foo"},
+			ref2:     iamv1beta1.ResourceReference{Kind: "Project", External: "projects/foo"},
+			expected: true,
+		},
+		{
+			name:     "different kinds",
+			ref1:     iamv1beta1.ResourceReference{Kind: "Project", External: "foo"},
+			ref2:     iamv1beta1.ResourceReference{Kind: "Folder", External: "foo"},
+			expected: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := managementconflict.ReferencesSameResource(tc.ref1, tc.namespace1, tc.ref2, tc.namespace2); got != tc.expected {
+				t.Errorf("got %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+// testResolver resolves the references to the Projects named 'foo-project' and 'bar-project' in
+// the 'foo-ns' namespace and the external references to their IDs.
+type testResolver map[string]string
+
+func newTestResolver() testResolver {
+	return testResolver{
+		"foo-ns/foo-project":      "projects/foo-project-id",
+		"foo-ns/bar-project":      "projects/bar-project-id",
+		"foo-project-id":          "projects/foo-project-id",
+		"projects/foo-project-id": "projects/foo-project-id",
+		"bar-project-id":          "projects/bar-project-id",
+		"projects/bar-project-id": "projects/bar-project-id",
+	}
+}
+
+func (r testResolver) ResolveResourceID(_ context.Context, ref iamv1beta1.ResourceReference, namespace string) (string, error) {
+	key := ref.External
+	if key == "" {
+		if ref.Namespace != "" {
+			namespace = ref.Namespace
+		}
+		key = namespace + "/" + ref.Name
+	}
+	id, ok := r[key]
+	if !ok {
+		return "", fmt.Errorf("reference '%v' not found", key)
+	}
+	return id, nil
+}
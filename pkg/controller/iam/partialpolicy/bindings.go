@@ -17,8 +17,10 @@ package partialpolicy
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/expiry"
 )
 
 type MemberIdentityResolver interface {
@@ -79,7 +81,13 @@ func ComputePartialPolicyWithRemainingBindings(partialPolicy *v1beta1.IAMPartial
 
 func ConvertIAMPartialBindingsToIAMPolicyBindings(partialPolicy *v1beta1.IAMPartialPolicy, resolver MemberIdentityResolver) (bindings []v1beta1.IAMPolicyBinding, err error) {
 	res := make([]v1beta1.IAMPolicyBinding, 0)
+	now := time.Now()
 	for _, binding := range partialPolicy.Spec.Bindings {
+		// Expired bindings are left out so that they are removed from the IAM policy like any
+		// other binding removed from the IAMPartialPolicy.
+		if expiry.HasExpired(binding.ExpiresAt, now) {
+			continue
+		}
 		convertedBinding, err := toIAMPolicyBinding(binding, resolver, partialPolicy.Namespace)
 		if err != nil {
 			return bindings, fmt.Errorf("error converting IAMPartialPolicy binding to IAMPolicy binding: %w", err)
@@ -101,7 +109,7 @@ func toIAMPolicyBinding(b v1beta1.IAMPartialPolicyBinding, resolver MemberIdenti
 
 	return v1beta1.IAMPolicyBinding{
 		Role:      b.Role,
-		Condition: b.Condition,
+		Condition: expiry.Condition(b.Condition, b.ExpiresAt),
 		Members:   members,
 	}, nil
 }
@@ -233,20 +241,22 @@ func mergeMembers(memberSlice1, memberSlice2 []v1beta1.Member) []v1beta1.Member
 
 func sortBindingSlice(bindings []v1beta1.IAMPolicyBinding) {
 	sort.Slice(bindings, func(i, j int) bool {
-		k1 := getIamBindingKey(bindings[i])
-		k2 := getIamBindingKey(bindings[j])
-		if k1.Role != k2.Role {
-			return k1.Role < k2.Role
-		}
-		if k1.Condition.Title != k2.Condition.Title {
-			return k1.Condition.Title < k2.Condition.Title
-		}
-		if k1.Condition.Description != k2.Condition.Description {
-			return k1.Condition.Description < k2.Condition.Description
-		}
-		if k1.Condition.Expression != k2.Condition.Expression {
-			return k1.Condition.Expression < k2.Condition.Expression
-		}
-		return false
+		return bindingLess(getIamBindingKey(bindings[i]), getIamBindingKey(bindings[j]))
 	})
 }
+
+func bindingLess(k1, k2 iamBindingKey) bool {
+	if k1.Role != k2.Role {
+		return k1.Role < k2.Role
+	}
+	if k1.Condition.Title != k2.Condition.Title {
+		return k1.Condition.Title < k2.Condition.Title
+	}
+	if k1.Condition.Description != k2.Condition.Description {
+		return k1.Condition.Description < k2.Condition.Description
+	}
+	if k1.Condition.Expression != k2.Condition.Expression {
+		return k1.Condition.Expression < k2.Condition.Expression
+	}
+	return false
+}
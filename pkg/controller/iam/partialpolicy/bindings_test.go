@@ -18,11 +18,13 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	iamv1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/partialpolicy"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // mockIdentityResolver helps to resolve referenced member identity
@@ -44,6 +46,13 @@ func (t *mockIdentityResolver) Resolve(member iamv1beta1.Member, memberFrom *iam
 func TestComputePartialPolicyWithMergedBindings(t *testing.T) {
 	condition1 := newIAMCondition("test-iam-condition1")
 	condition2 := newIAMCondition("test-iam-condition2")
+	expiredAt := metav1.NewTime(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC))
+	expiresAt := metav1.NewTime(time.Date(2999, time.January, 1, 0, 0, 0, 0, time.UTC))
+	expiryCondition := &iamv1beta1.IAMCondition{
+		Title:       "Expires at 2999-01-01T00:00:00Z",
+		Description: "Managed by Config Connector: the binding expires at 2999-01-01T00:00:00Z.",
+		Expression:  `request.time < timestamp("2999-01-01T00:00:00Z")`,
+	}
 	tests := []struct {
 		name          string
 		partialPolicy *iamv1beta1.IAMPartialPolicy
@@ -874,6 +883,186 @@ func TestComputePartialPolicyWithMergedBindings(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "bindings with an expiry time",
+			partialPolicy: &iamv1beta1.IAMPartialPolicy{
+				Spec: iamv1beta1.IAMPartialPolicySpec{
+					Bindings: []iamv1beta1.IAMPartialPolicyBinding{
+						{
+							Role:      "roles/owner",
+							ExpiresAt: &expiresAt,
+							Members: []iamv1beta1.IAMPartialPolicyMember{
+								{
+									Member: "user:foo@example.com",
+								},
+							},
+						},
+						{
+							Role:      "roles/editor",
+							Condition: condition1,
+							ExpiresAt: &expiresAt,
+							Members: []iamv1beta1.IAMPartialPolicyMember{
+								{
+									Member: "user:bar@example.com",
+								},
+							},
+						},
+					},
+				},
+			},
+			livePolicy: &iamv1beta1.IAMPolicy{
+				Spec: iamv1beta1.IAMPolicySpec{},
+			},
+			mergedPolicy: &iamv1beta1.IAMPartialPolicy{
+				Spec: iamv1beta1.IAMPartialPolicySpec{
+					Bindings: []iamv1beta1.IAMPartialPolicyBinding{
+						{
+							Role:      "roles/owner",
+							ExpiresAt: &expiresAt,
+							Members: []iamv1beta1.IAMPartialPolicyMember{
+								{
+									Member: "user:foo@example.com",
+								},
+							},
+						},
+						{
+							Role:      "roles/editor",
+							Condition: condition1,
+							ExpiresAt: &expiresAt,
+							Members: []iamv1beta1.IAMPartialPolicyMember{
+								{
+									Member: "user:bar@example.com",
+								},
+							},
+						},
+					},
+				},
+				Status: iamv1beta1.IAMPartialPolicyStatus{
+					LastAppliedBindings: []iamv1beta1.IAMPolicyBinding{
+						{
+							Role: "roles/editor",
+							Condition: &iamv1beta1.IAMCondition{
+								Title:       condition1.Title,
+								Description: condition1.Description,
+								Expression:  fmt.Sprintf("(%v) && %v", condition1.Expression, expiryCondition.Expression),
+							},
+							Members: []iamv1beta1.Member{
+								"user:bar@example.com",
+							},
+						},
+						{
+							Role:      "roles/owner",
+							Condition: expiryCondition,
+							Members: []iamv1beta1.Member{
+								"user:foo@example.com",
+							},
+						},
+					},
+					AllBindings: []iamv1beta1.IAMPolicyBinding{
+						{
+							Role: "roles/editor",
+							Condition: &iamv1beta1.IAMCondition{
+								Title:       condition1.Title,
+								Description: condition1.Description,
+								Expression:  fmt.Sprintf("(%v) && %v", condition1.Expression, expiryCondition.Expression),
+							},
+							Members: []iamv1beta1.Member{
+								"user:bar@example.com",
+							},
+						},
+						{
+							Role:      "roles/owner",
+							Condition: expiryCondition,
+							Members: []iamv1beta1.Member{
+								"user:foo@example.com",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "expired binding is removed from the live policy",
+			partialPolicy: &iamv1beta1.IAMPartialPolicy{
+				Spec: iamv1beta1.IAMPartialPolicySpec{
+					Bindings: []iamv1beta1.IAMPartialPolicyBinding{
+						{
+							Role:      "roles/owner",
+							ExpiresAt: &expiredAt,
+							Members: []iamv1beta1.IAMPartialPolicyMember{
+								{
+									Member: "user:foo@example.com",
+								},
+							},
+						},
+					},
+				},
+				Status: iamv1beta1.IAMPartialPolicyStatus{
+					LastAppliedBindings: []iamv1beta1.IAMPolicyBinding{
+						{
+							Role: "roles/owner",
+							Condition: &iamv1beta1.IAMCondition{
+								Title:       "Expires at 2000-01-01T00:00:00Z",
+								Description: "Managed by Config Connector: the binding expires at 2000-01-01T00:00:00Z.",
+								Expression:  `request.time < timestamp("2000-01-01T00:00:00Z")`,
+							},
+							Members: []iamv1beta1.Member{
+								"user:foo@example.com",
+							},
+						},
+					},
+				},
+			},
+			livePolicy: &iamv1beta1.IAMPolicy{
+				Spec: iamv1beta1.IAMPolicySpec{
+					Bindings: []iamv1beta1.IAMPolicyBinding{
+						{
+							Role: "roles/owner",
+							Condition: &iamv1beta1.IAMCondition{
+								Title:       "Expires at 2000-01-01T00:00:00Z",
+								Description: "Managed by Config Connector: the binding expires at 2000-01-01T00:00:00Z.",
+								Expression:  `request.time < timestamp("2000-01-01T00:00:00Z")`,
+							},
+							Members: []iamv1beta1.Member{
+								"user:foo@example.com",
+							},
+						},
+						{
+							Role: "roles/owner",
+							Members: []iamv1beta1.Member{
+								"user:bar@example.com",
+							},
+						},
+					},
+				},
+			},
+			mergedPolicy: &iamv1beta1.IAMPartialPolicy{
+				Spec: iamv1beta1.IAMPartialPolicySpec{
+					Bindings: []iamv1beta1.IAMPartialPolicyBinding{
+						{
+							Role:      "roles/owner",
+							ExpiresAt: &expiredAt,
+							Members: []iamv1beta1.IAMPartialPolicyMember{
+								{
+									Member: "user:foo@example.com",
+								},
+							},
+						},
+					},
+				},
+				Status: iamv1beta1.IAMPartialPolicyStatus{
+					LastAppliedBindings: []iamv1beta1.IAMPolicyBinding{},
+					AllBindings: []iamv1beta1.IAMPolicyBinding{
+						{
+							Role: "roles/owner",
+							Members: []iamv1beta1.Member{
+								"user:bar@example.com",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -0,0 +1,212 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partialpolicy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/expiry"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/managementconflict"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PolicySource is an IAMPolicy, IAMPartialPolicy or IAMPolicyMember object, along with the
+// IAM bindings it grants on the resource it references.
+type PolicySource struct {
+	Kind      string
+	Namespace string
+	Name      string
+	// Bindings are the bindings granted by the object, with all memberFrom fields resolved.
+	Bindings []v1beta1.IAMPolicyBinding
+	// Err is the error resolving the bindings of the object, if any, in which case it has no
+	// bindings.
+	Err error
+}
+
+// ID returns the identifier of the source object in the form '<kind>/<namespace>/<name>'.
+func (s *PolicySource) ID() string {
+	return fmt.Sprintf("%v/%v/%v", s.Kind, s.Namespace, s.Name)
+}
+
+type iamMemberKey struct {
+	iamBindingKey
+	Member v1beta1.Member
+}
+
+// ListPolicySources returns the IAMPolicy, IAMPartialPolicy and IAMPolicyMember objects in the
+// given namespace, or in all namespaces if it is empty, that reference the given resource. The
+// namespace of the resource reference defaults to refNamespace. The references are compared by the
+// IDs that idResolver resolves them to, see managementconflict.ReferenceMatcher. Objects being
+// deleted are skipped. Objects whose bindings can't be resolved, e.g. because of a memberFrom
+// referencing a missing resource, are returned with the error and no bindings.
+func ListPolicySources(ctx context.Context, kubeClient client.Client, idResolver managementconflict.ResourceIDResolver,
+	resourceRef v1beta1.ResourceReference, refNamespace, namespace string, resolver MemberIdentityResolver) ([]PolicySource, error) {
+	sources := make([]PolicySource, 0)
+	matcher := managementconflict.NewReferenceMatcher(ctx, idResolver, resourceRef, refNamespace)
+
+	policies := &v1beta1.IAMPolicyList{}
+	if err := kubeClient.List(ctx, policies, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("error listing IAMPolicies: %w", err)
+	}
+	for _, p := range policies.Items {
+		if !p.DeletionTimestamp.IsZero() || !matcher.Matches(ctx, p.Spec.ResourceReference, p.Namespace) {
+			continue
+		}
+		sources = append(sources, PolicySource{
+			Kind:      v1beta1.IAMPolicyGVK.Kind,
+			Namespace: p.Namespace,
+			Name:      p.Name,
+			Bindings:  mergeBindingsWithSameRoleAndCondition(p.Spec.Bindings),
+		})
+	}
+
+	partialPolicies := &v1beta1.IAMPartialPolicyList{}
+	if err := kubeClient.List(ctx, partialPolicies, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("error listing IAMPartialPolicies: %w", err)
+	}
+	for i := range partialPolicies.Items {
+		pp := &partialPolicies.Items[i]
+		if !pp.DeletionTimestamp.IsZero() || !matcher.Matches(ctx, pp.Spec.ResourceReference, pp.Namespace) {
+			continue
+		}
+		source := PolicySource{
+			Kind:      v1beta1.IAMPartialPolicyGVK.Kind,
+			Namespace: pp.Namespace,
+			Name:      pp.Name,
+		}
+		bindings, err := ConvertIAMPartialBindingsToIAMPolicyBindings(pp, resolver)
+		if err != nil {
+			source.Err = fmt.Errorf("error computing the bindings: %w", err)
+			sources = append(sources, source)
+			continue
+		}
+		source.Bindings = bindings
+		sources = append(sources, source)
+	}
+
+	policyMembers := &v1beta1.IAMPolicyMemberList{}
+	if err := kubeClient.List(ctx, policyMembers, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("error listing IAMPolicyMembers: %w", err)
+	}
+	for _, pm := range policyMembers.Items {
+		if !pm.DeletionTimestamp.IsZero() || !matcher.Matches(ctx, pm.Spec.ResourceReference, pm.Namespace) {
+			continue
+		}
+		// Expired IAMPolicyMembers no longer grant their binding.
+		if expiry.HasExpired(pm.Spec.ExpiresAt, time.Now()) {
+			continue
+		}
+		source := PolicySource{
+			Kind:      v1beta1.IAMPolicyMemberGVK.Kind,
+			Namespace: pm.Namespace,
+			Name:      pm.Name,
+		}
+		member, err := resolver.Resolve(pm.Spec.Member, pm.Spec.MemberFrom, pm.Namespace)
+		if err != nil {
+			source.Err = fmt.Errorf("error resolving the member: %w", err)
+			sources = append(sources, source)
+			continue
+		}
+		source.Bindings = []v1beta1.IAMPolicyBinding{
+			{
+				Role:      pm.Spec.Role,
+				Condition: expiry.Condition(pm.Spec.Condition, pm.Spec.ExpiresAt),
+				Members:   []v1beta1.Member{v1beta1.Member(member)},
+			},
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// ComputeBindingSources merges the bindings granted by the given sources and compares them with
+// the given live bindings. It returns, for each member of the live bindings, the sources granting
+// it, and for each member granted by the sources but missing from the live bindings, the sources
+// granting it. Both lists are sorted by role, condition and member.
+func ComputeBindingSources(liveBindings []v1beta1.IAMPolicyBinding, sources []PolicySource) (live, missing []v1beta1.IAMBindingSource) {
+	sourcesPerMember := make(map[iamMemberKey][]string)
+	desiredBindings := make([]v1beta1.IAMPolicyBinding, 0)
+	for _, s := range sources {
+		for _, b := range s.Bindings {
+			k := getIamBindingKey(b)
+			for _, m := range b.Members {
+				mk := iamMemberKey{iamBindingKey: k, Member: m}
+				sourcesPerMember[mk] = appendIfMissing(sourcesPerMember[mk], s.ID())
+			}
+		}
+		desiredBindings = mergeBindingSlices(desiredBindings, s.Bindings)
+	}
+	liveMembers := make(map[iamMemberKey]bool)
+	live = make([]v1beta1.IAMBindingSource, 0)
+	for _, b := range mergeBindingsWithSameRoleAndCondition(liveBindings) {
+		k := getIamBindingKey(b)
+		for _, m := range b.Members {
+			mk := iamMemberKey{iamBindingKey: k, Member: m}
+			liveMembers[mk] = true
+			live = append(live, newIAMBindingSource(b, m, sourcesPerMember[mk]))
+		}
+	}
+	missing = make([]v1beta1.IAMBindingSource, 0)
+	for _, b := range desiredBindings {
+		k := getIamBindingKey(b)
+		for _, m := range b.Members {
+			mk := iamMemberKey{iamBindingKey: k, Member: m}
+			if !liveMembers[mk] {
+				missing = append(missing, newIAMBindingSource(b, m, sourcesPerMember[mk]))
+			}
+		}
+	}
+	sortBindingSourceSlice(live)
+	sortBindingSourceSlice(missing)
+	return live, missing
+}
+
+func newIAMBindingSource(b v1beta1.IAMPolicyBinding, member v1beta1.Member, sources []string) v1beta1.IAMBindingSource {
+	res := v1beta1.IAMBindingSource{
+		Role:      b.Role,
+		Condition: b.Condition.DeepCopy(),
+		Member:    member,
+	}
+	if len(sources) > 0 {
+		res.Sources = append([]string{}, sources...)
+		sort.Strings(res.Sources)
+	}
+	return res
+}
+
+func appendIfMissing(ids []string, id string) []string {
+	for _, i := range ids {
+		if i == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
+
+func sortBindingSourceSlice(bindingSources []v1beta1.IAMBindingSource) {
+	sort.SliceStable(bindingSources, func(i, j int) bool {
+		b1 := v1beta1.IAMPolicyBinding{Role: bindingSources[i].Role, Condition: bindingSources[i].Condition}
+		b2 := v1beta1.IAMPolicyBinding{Role: bindingSources[j].Role, Condition: bindingSources[j].Condition}
+		if k1, k2 := getIamBindingKey(b1), getIamBindingKey(b2); k1 != k2 {
+			return bindingLess(k1, k2)
+		}
+		return bindingSources[i].Member < bindingSources[j].Member
+	})
+}
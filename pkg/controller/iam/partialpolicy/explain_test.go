@@ -0,0 +1,213 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partialpolicy_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	iamv1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/partialpolicy"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/test/mocks"
+)
+
+func TestListPolicySources(t *testing.T) {
+	if err := iamv1beta1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatalf("error adding IAM types to the scheme: %v", err)
+	}
+	ctx := context.Background()
+	kubeClient := mocks.Manager{}.GetClient()
+	existing := []client.Object{
+		&iamv1beta1.IAMPolicyMember{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo-ns", Name: "by-name"},
+			Spec: iamv1beta1.IAMPolicyMemberSpec{
+				ResourceReference: iamv1beta1.ResourceReference{Kind: "Project", Name: "foo-project"},
+				Member:            "user:foo@example.com",
+				Role:              "roles/editor",
+			},
+		},
+		&iamv1beta1.IAMPolicyMember{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo-ns", Name: "by-external"},
+			Spec: iamv1beta1.IAMPolicyMemberSpec{
+				ResourceReference: iamv1beta1.ResourceReference{Kind: "Project", External: "foo-project-id"},
+				Member:            "user:bar@example.com",
+				Role:              "roles/viewer",
+			},
+		},
+		&iamv1beta1.IAMPolicyMember{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo-ns", Name: "other-project"},
+			Spec: iamv1beta1.IAMPolicyMemberSpec{
+				ResourceReference: iamv1beta1.ResourceReference{Kind: "Project", External: "bar-project-id"},
+				Member:            "user:baz@example.com",
+				Role:              "roles/viewer",
+			},
+		},
+		&iamv1beta1.IAMPolicyMember{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo-ns", Name: "unresolved-member"},
+			Spec: iamv1beta1.IAMPolicyMemberSpec{
+				ResourceReference: iamv1beta1.ResourceReference{Kind: "Project", Name: "foo-project"},
+				MemberFrom: &iamv1beta1.MemberSource{
+					ServiceAccountRef: &iamv1beta1.MemberReference{Name: "missing-sa"},
+				},
+				Role: "roles/viewer",
+			},
+		},
+	}
+	for _, obj := range existing {
+		if err := kubeClient.Create(ctx, obj); err != nil {
+			t.Fatalf("error creating %v: %v", obj.GetName(), err)
+		}
+	}
+	idResolver := testResourceIDResolver{
+		"foo-ns/foo-project":      "projects/foo-project-id",
+		"foo-project-id":          "projects/foo-project-id",
+		"projects/foo-project-id": "projects/foo-project-id",
+		"bar-project-id":          "projects/bar-project-id",
+	}
+	resourceRef := iamv1beta1.ResourceReference{Kind: "Project", External: "projects/foo-project-id"}
+	sources, err := partialpolicy.ListPolicySources(ctx, kubeClient, idResolver, resourceRef, "foo-ns", "foo-ns", &missingMemberIdentityResolver{})
+	if err != nil {
+		t.Fatalf("error listing policy sources: %v", err)
+	}
+	ids := make([]string, 0)
+	unresolved := make([]string, 0)
+	for _, s := range sources {
+		ids = append(ids, s.ID())
+		if s.Err != nil {
+			if len(s.Bindings) != 0 {
+				t.Errorf("got bindings %v for unresolved source %v, want none", s.Bindings, s.ID())
+			}
+			unresolved = append(unresolved, s.ID())
+		}
+	}
+	expected := []string{"IAMPolicyMember/foo-ns/by-external", "IAMPolicyMember/foo-ns/by-name", "IAMPolicyMember/foo-ns/unresolved-member"}
+	if diff := cmp.Diff(expected, ids); diff != "" {
+		t.Errorf("unexpected sources diff (-want +got): \n%v", diff)
+	}
+	expectedUnresolved := []string{"IAMPolicyMember/foo-ns/unresolved-member"}
+	if diff := cmp.Diff(expectedUnresolved, unresolved); diff != "" {
+		t.Errorf("unexpected unresolved sources diff (-want +got): \n%v", diff)
+	}
+}
+
+// missingMemberIdentityResolver resolves the members of the objects and fails to resolve their
+// memberFrom, as if the referenced resources did not exist.
+type missingMemberIdentityResolver struct{}
+
+func (r *missingMemberIdentityResolver) Resolve(member iamv1beta1.Member, memberFrom *iamv1beta1.MemberSource, _ string) (string, error) {
+	if member != "" {
+		return string(member), nil
+	}
+	return "", fmt.Errorf("referenced resource not found")
+}
+
+// testResourceIDResolver resolves the references, keyed by their external value or by
+// '<namespace>/<name>', to the IDs of the referenced resources.
+type testResourceIDResolver map[string]string
+
+func (r testResourceIDResolver) ResolveResourceID(_ context.Context, ref iamv1beta1.ResourceReference, namespace string) (string, error) {
+	key := ref.External
+	if key == "" {
+		if ref.Namespace != "" {
+			namespace = ref.Namespace
+		}
+		key = namespace + "/" + ref.Name
+	}
+	id, ok := r[key]
+	if !ok {
+		return "", fmt.Errorf("reference '%v' not found", key)
+	}
+	return id, nil
+}
+
+func TestComputeBindingSources(t *testing.T) {
+	condition := newIAMCondition("test-iam-condition1")
+	sources := []partialpolicy.PolicySource{
+		{
+			Kind:      "IAMPartialPolicy",
+			Namespace: "foo-ns",
+			Name:      "partial",
+			Bindings: []iamv1beta1.IAMPolicyBinding{
+				{
+					Role:    "roles/editor",
+					Members: []iamv1beta1.Member{"user:foo@example.com", "user:bar@example.com"},
+				},
+				{
+					Role:      "roles/editor",
+					Condition: condition,
+					Members:   []iamv1beta1.Member{"user:foo@example.com"},
+				},
+			},
+		},
+		{
+			Kind:      "IAMPolicyMember",
+			Namespace: "foo-ns",
+			Name:      "member",
+			Bindings: []iamv1beta1.IAMPolicyBinding{
+				{
+					Role:    "roles/editor",
+					Members: []iamv1beta1.Member{"user:foo@example.com"},
+				},
+			},
+		},
+	}
+	liveBindings := []iamv1beta1.IAMPolicyBinding{
+		{
+			Role:    "roles/owner",
+			Members: []iamv1beta1.Member{"user:admin@example.com"},
+		},
+		{
+			Role:    "roles/editor",
+			Members: []iamv1beta1.Member{"user:foo@example.com"},
+		},
+	}
+	expectedLive := []iamv1beta1.IAMBindingSource{
+		{
+			Role:    "roles/editor",
+			Member:  "user:foo@example.com",
+			Sources: []string{"IAMPartialPolicy/foo-ns/partial", "IAMPolicyMember/foo-ns/member"},
+		},
+		{
+			Role:   "roles/owner",
+			Member: "user:admin@example.com",
+		},
+	}
+	expectedMissing := []iamv1beta1.IAMBindingSource{
+		{
+			Role:    "roles/editor",
+			Member:  "user:bar@example.com",
+			Sources: []string{"IAMPartialPolicy/foo-ns/partial"},
+		},
+		{
+			Role:      "roles/editor",
+			Condition: condition,
+			Member:    "user:foo@example.com",
+			Sources:   []string{"IAMPartialPolicy/foo-ns/partial"},
+		},
+	}
+	live, missing := partialpolicy.ComputeBindingSources(liveBindings, sources)
+	if diff := cmp.Diff(expectedLive, live); diff != "" {
+		t.Errorf("unexpected live binding sources diff (-want +got): \n%v", diff)
+	}
+	if diff := cmp.Diff(expectedMissing, missing); diff != "" {
+		t.Errorf("unexpected missing binding sources diff (-want +got): \n%v", diff)
+	}
+}
@@ -23,8 +23,10 @@ import (
 
 	iamv1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
 	condition "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/k8s/v1alpha1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/expiry"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/iamclient"
 	kcciamclient "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/iamclient"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/managementconflict"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/jitter"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/lifecyclehandler"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/metrics"
@@ -147,7 +149,9 @@ func (r *ReconcileIAMPartialPolicy) Reconcile(ctx context.Context, request recon
 	if requeue {
 		return reconcile.Result{Requeue: true}, nil
 	}
-	jitteredPeriod := jitter.GenerateJitteredReenqueuePeriod()
+	// Reconcile the IAMPartialPolicy again no later than the expiry time of its next binding to
+	// expire so that the binding is removed on time.
+	jitteredPeriod := expiry.RequeueAfter(jitter.GenerateJitteredReenqueuePeriod(), time.Now(), bindingExpiryTimes(policy)...)
 	logger.Info("successfully finished reconcile", "resource", request.NamespacedName, "time to next reconciliation", jitteredPeriod)
 	return reconcile.Result{RequeueAfter: jitteredPeriod}, nil
 }
@@ -157,6 +161,13 @@ func (r *reconcileContext) doReconcile(pp *iamv1beta1.IAMPartialPolicy) (requeue
 	if !pp.DeletionTimestamp.IsZero() {
 		return r.finalizeDeletion(pp)
 	}
+	conflicts, err := managementconflict.FindConflicts(r.Ctx, r.Reconciler.Client, r.Reconciler.iamClient, pp)
+	if err != nil {
+		return false, r.handleUpdateFailed(pp, err)
+	}
+	if len(conflicts) > 0 {
+		return false, r.handleManagementConflict(pp, managementconflict.NewConflictError(iamv1beta1.IAMPartialPolicyGVK.Kind, conflicts))
+	}
 	iamPolicy := ToIAMPolicySkeleton(pp)
 	if iamPolicy, err = r.Reconciler.iamClient.GetPolicy(r.Ctx, iamPolicy); err != nil {
 		if unwrappedErr, ok := lifecyclehandler.CausedByUnresolvableDeps(err); ok {
@@ -165,6 +176,9 @@ func (r *reconcileContext) doReconcile(pp *iamv1beta1.IAMPartialPolicy) (requeue
 		}
 		return false, r.handleUpdateFailed(pp, err)
 	}
+	if err := r.Reconciler.iamClient.ValidateResourceReferenceAllowedInNamespace(r.Ctx, pp.Namespace, pp.Spec.ResourceReference); err != nil {
+		return false, r.handleUpdateFailed(pp, err)
+	}
 	k8s.EnsureFinalizers(pp, k8s.ControllerFinalizerName, k8s.DeletionDefenderFinalizerName)
 
 	resolver := IAMMemberIdentityResolver{Iamclient: r.Reconciler.iamClient, Ctx: r.Ctx}
@@ -184,6 +198,7 @@ func (r *reconcileContext) doReconcile(pp *iamv1beta1.IAMPartialPolicy) (requeue
 		}
 		return false, r.handleUpdateFailed(pp, fmt.Errorf("error setting policy: %w", err))
 	}
+	r.computeBindingSources(desiredPartialPolicy, &resolver)
 	if isAPIServerUpdateRequired(desiredPartialPolicy, pp) {
 		return false, r.handleUpToDate(desiredPartialPolicy)
 	}
@@ -266,6 +281,14 @@ func (r *reconcileContext) handleDeleteFailed(policy *iamv1beta1.IAMPartialPolic
 	return r.Reconciler.HandleDeleteFailed(r.Ctx, resource, origErr)
 }
 
+func (r *reconcileContext) handleManagementConflict(policy *iamv1beta1.IAMPartialPolicy, origErr error) error {
+	resource, err := toK8sResource(policy)
+	if err != nil {
+		return fmt.Errorf("error converting IAMPartialPolicy to k8s resource while handling %v event: %w", k8s.ManagementConflict, err)
+	}
+	return r.Reconciler.HandleManagementConflict(r.Ctx, resource, origErr)
+}
+
 func (r *reconcileContext) handleUnresolvableDeps(policy *iamv1beta1.IAMPartialPolicy, origErr error) error {
 	resource, err := toK8sResource(policy)
 	if err != nil {
@@ -274,6 +297,22 @@ func (r *reconcileContext) handleUnresolvableDeps(policy *iamv1beta1.IAMPartialP
 	return r.Reconciler.HandleUnresolvableDeps(r.Ctx, resource, origErr)
 }
 
+// computeBindingSources records in the status of the given IAMPartialPolicy the objects in its namespace that
+// grant each of its bindings. The sources are informational only, so failing to compute them is logged and
+// leaves the previously recorded ones unchanged.
+func (r *reconcileContext) computeBindingSources(pp *iamv1beta1.IAMPartialPolicy, resolver MemberIdentityResolver) {
+	sources, err := ListPolicySources(r.Ctx, r.Reconciler.Client, r.Reconciler.iamClient, pp.Spec.ResourceReference, pp.Namespace, pp.Namespace, resolver)
+	if err != nil {
+		logger.Info("unable to compute the sources of the IAM bindings", "resource", k8s.GetNamespacedName(pp), "error", err.Error())
+		return
+	}
+	bindingSources, _ := ComputeBindingSources(pp.Status.AllBindings, sources)
+	if len(bindingSources) == 0 {
+		bindingSources = nil
+	}
+	pp.Status.BindingSources = bindingSources
+}
+
 // IAMMemberIdentityResolver helps to resolve referenced member identity
 type IAMMemberIdentityResolver struct {
 	Iamclient *kcciamclient.IAMClient
@@ -302,6 +341,9 @@ func isAPIServerUpdateRequired(desired, original *iamv1beta1.IAMPartialPolicy) b
 	if !reflect.DeepEqual(desired.Status.AllBindings, original.Status.AllBindings) {
 		return true
 	}
+	if !reflect.DeepEqual(desired.Status.BindingSources, original.Status.BindingSources) {
+		return true
+	}
 	return false
 }
 
@@ -339,3 +381,13 @@ func toDesiredPolicy(desiredPartialPolicy *iamv1beta1.IAMPartialPolicy, livePoli
 	desiredPolicy.Spec.AuditConfigs = livePolicy.Spec.AuditConfigs
 	return desiredPolicy
 }
+
+func bindingExpiryTimes(policy *iamv1beta1.IAMPartialPolicy) []*metav1.Time {
+	res := make([]*metav1.Time, 0)
+	for _, b := range policy.Spec.Bindings {
+		if b.ExpiresAt != nil {
+			res = append(res, b.ExpiresAt)
+		}
+	}
+	return res
+}
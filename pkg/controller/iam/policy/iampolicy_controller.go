@@ -24,6 +24,7 @@ import (
 	condition "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/k8s/v1alpha1"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/iamclient"
 	kcciamclient "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/iamclient"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/managementconflict"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/jitter"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/lifecyclehandler"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/metrics"
@@ -192,6 +193,13 @@ func (r *reconcileContext) doReconcile(policy *iamv1beta1.IAMPolicy) (requeue bo
 		}
 		return false, r.handleDeleted(policy)
 	}
+	conflicts, err := managementconflict.FindConflicts(r.Ctx, r.Reconciler.Client, r.Reconciler.iamClient, policy)
+	if err != nil {
+		return false, r.handleUpdateFailed(policy, err)
+	}
+	if len(conflicts) > 0 {
+		return false, r.handleManagementConflict(policy, managementconflict.NewConflictError(iamv1beta1.IAMPolicyGVK.Kind, conflicts))
+	}
 	if _, err := r.Reconciler.iamClient.GetPolicy(r.Ctx, policy); err != nil {
 		if unwrappedErr, ok := lifecyclehandler.CausedByUnresolvableDeps(err); ok {
 			logger.Info(unwrappedErr.Error(), "resource", k8s.GetNamespacedName(policy))
@@ -199,6 +207,9 @@ func (r *reconcileContext) doReconcile(policy *iamv1beta1.IAMPolicy) (requeue bo
 		}
 		return false, r.handleUpdateFailed(policy, err)
 	}
+	if err := r.Reconciler.iamClient.ValidateResourceReferenceAllowedInNamespace(r.Ctx, policy.Namespace, policy.Spec.ResourceReference); err != nil {
+		return false, r.handleUpdateFailed(policy, err)
+	}
 	k8s.EnsureFinalizers(policy, k8s.ControllerFinalizerName, k8s.DeletionDefenderFinalizerName)
 	// set the etag to an empty string, since IAMPolicy is the authoritative intent, KCC wants to overwrite the underlying policy regardless
 	policy.Spec.Etag = ""
@@ -233,6 +244,14 @@ func (r *reconcileContext) handleUpdateFailed(policy *iamv1beta1.IAMPolicy, orig
 	return r.Reconciler.HandleUpdateFailed(r.Ctx, resource, origErr)
 }
 
+func (r *reconcileContext) handleManagementConflict(policy *iamv1beta1.IAMPolicy, origErr error) error {
+	resource, err := toK8sResource(policy)
+	if err != nil {
+		return fmt.Errorf("error converting IAMPolicy to k8s resource while handling %v event: %w", k8s.ManagementConflict, err)
+	}
+	return r.Reconciler.HandleManagementConflict(r.Ctx, resource, origErr)
+}
+
 func (r *reconcileContext) handleDeleted(policy *iamv1beta1.IAMPolicy) error {
 	resource, err := toK8sResource(policy)
 	if err != nil {
@@ -22,7 +22,9 @@ import (
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
 	condition "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/k8s/v1alpha1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/expiry"
 	kcciamclient "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/iamclient"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/managementconflict"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/jitter"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/lifecyclehandler"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/metrics"
@@ -66,13 +68,17 @@ func Add(mgr manager.Manager, provider *tfschema.Provider, smLoader *servicemapp
 // NewReconciler returns a new reconcile.Reconciler.
 func NewReconciler(mgr manager.Manager, provider *tfschema.Provider, smLoader *servicemappingloader.ServiceMappingLoader,
 	converter *conversion.Converter, dclConfig *mmdcl.Config) (*Reconciler, error) {
+	iamClient := kcciamclient.New(provider, smLoader, mgr.GetClient(), converter, dclConfig)
+	// Coalesce the writes of IAMPolicyMembers targeting the same resource to avoid etag conflicts
+	// between their reconciliations.
+	iamClient.EnablePolicyMemberBatching(kcciamclient.DefaultPolicyMemberBatchWindow)
 	r := Reconciler{
 		LifecycleHandler: lifecyclehandler.NewLifecycleHandler(
 			mgr.GetClient(),
 			mgr.GetEventRecorderFor(controllerName),
 		),
 		Client:    mgr.GetClient(),
-		iamClient: kcciamclient.New(provider, smLoader, mgr.GetClient(), converter, dclConfig),
+		iamClient: iamClient,
 		scheme:    mgr.GetScheme(),
 	}
 	return &r, nil
@@ -138,7 +144,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	if requeue {
 		return reconcile.Result{Requeue: true}, nil
 	}
-	jitteredPeriod := jitter.GenerateJitteredReenqueuePeriod()
+	// Reconcile the IAMPolicyMember again no later than its expiry time so that its binding is
+	// removed on time.
+	jitteredPeriod := expiry.RequeueAfter(jitter.GenerateJitteredReenqueuePeriod(), time.Now(), memberPolicy.Spec.ExpiresAt)
 	logger.Info("successfully finished reconcile", "resource", request.NamespacedName, "time to next reconciliation", jitteredPeriod)
 	return reconcile.Result{RequeueAfter: jitteredPeriod}, nil
 }
@@ -168,6 +176,16 @@ func (r *reconcileContext) doReconcile(policyMember *v1beta1.IAMPolicyMember) (r
 		}
 		return false, r.handleDeleted(policyMember)
 	}
+	conflicts, err := managementconflict.FindConflicts(r.Ctx, r.Reconciler.Client, r.Reconciler.iamClient, policyMember)
+	if err != nil {
+		return false, r.handleUpdateFailed(policyMember, err)
+	}
+	if len(conflicts) > 0 {
+		return false, r.handleManagementConflict(policyMember, managementconflict.NewConflictError(v1beta1.IAMPolicyMemberGVK.Kind, conflicts))
+	}
+	if expiry.HasExpired(policyMember.Spec.ExpiresAt, time.Now()) {
+		return r.removeExpiredPolicyMember(policyMember)
+	}
 	if _, err := r.Reconciler.iamClient.GetPolicyMember(r.Ctx, policyMember); err != nil {
 		if unwrappedErr, ok := lifecyclehandler.CausedByUnresolvableDeps(err); ok {
 			logger.Info(unwrappedErr.Error(), "resource", k8s.GetNamespacedName(policyMember))
@@ -177,6 +195,9 @@ func (r *reconcileContext) doReconcile(policyMember *v1beta1.IAMPolicyMember) (r
 			return false, r.handleUpdateFailed(policyMember, err)
 		}
 	}
+	if err := r.Reconciler.iamClient.ValidateResourceReferenceAllowedInNamespace(r.Ctx, policyMember.Namespace, policyMember.Spec.ResourceReference); err != nil {
+		return false, r.handleUpdateFailed(policyMember, err)
+	}
 	if !k8s.EnsureFinalizers(policyMember, k8s.ControllerFinalizerName, k8s.DeletionDefenderFinalizerName) {
 		if err := r.update(policyMember); err != nil {
 			return false, r.handleUpdateFailed(policyMember, err)
@@ -195,6 +216,21 @@ func (r *reconcileContext) doReconcile(policyMember *v1beta1.IAMPolicyMember) (r
 	return false, nil
 }
 
+// removeExpiredPolicyMember removes the binding of the given expired IAMPolicyMember from the IAM
+// policy, if it is still there, and marks the IAMPolicyMember as expired.
+func (r *reconcileContext) removeExpiredPolicyMember(policyMember *v1beta1.IAMPolicyMember) (requeue bool, err error) {
+	if err := r.Reconciler.iamClient.DeletePolicyMember(r.Ctx, policyMember); err != nil {
+		if !errors.Is(err, kcciamclient.NotFoundError) && !k8s.IsReferenceNotFoundError(err) {
+			if unwrappedErr, ok := lifecyclehandler.CausedByUnresolvableDeps(err); ok {
+				logger.Info(unwrappedErr.Error(), "resource", k8s.GetNamespacedName(policyMember))
+				return true, r.handleUnresolvableDeps(policyMember, unwrappedErr)
+			}
+			return false, r.handleUpdateFailed(policyMember, fmt.Errorf("error removing expired policy member: %w", err))
+		}
+	}
+	return false, r.handleExpired(policyMember)
+}
+
 func (r *reconcileContext) update(policyMember *v1beta1.IAMPolicyMember) error {
 	if err := r.Reconciler.Client.Update(r.Ctx, policyMember); err != nil {
 		return fmt.Errorf("error updating '%v' in API server: %w", r.NamespacedName, err)
@@ -210,6 +246,14 @@ func (r *reconcileContext) handleUpToDate(policyMember *v1beta1.IAMPolicyMember)
 	return r.Reconciler.HandleUpToDate(r.Ctx, resource)
 }
 
+func (r *reconcileContext) handleExpired(policyMember *v1beta1.IAMPolicyMember) error {
+	resource, err := toK8sResource(policyMember)
+	if err != nil {
+		return fmt.Errorf("error converting IAMPolicyMember to k8s resource while handling %v event: %w", k8s.Expired, err)
+	}
+	return r.Reconciler.HandleExpired(r.Ctx, resource, policyMember.Spec.ExpiresAt.Time)
+}
+
 func (r *reconcileContext) handleUpdateFailed(policyMember *v1beta1.IAMPolicyMember, origErr error) error {
 	resource, err := toK8sResource(policyMember)
 	if err != nil {
@@ -220,6 +264,14 @@ func (r *reconcileContext) handleUpdateFailed(policyMember *v1beta1.IAMPolicyMem
 	return r.Reconciler.HandleUpdateFailed(r.Ctx, resource, origErr)
 }
 
+func (r *reconcileContext) handleManagementConflict(policyMember *v1beta1.IAMPolicyMember, origErr error) error {
+	resource, err := toK8sResource(policyMember)
+	if err != nil {
+		return fmt.Errorf("error converting IAMPolicyMember to k8s resource while handling %v event: %w", k8s.ManagementConflict, err)
+	}
+	return r.Reconciler.HandleManagementConflict(r.Ctx, resource, origErr)
+}
+
 func (r *reconcileContext) handleDeleted(policyMember *v1beta1.IAMPolicyMember) error {
 	resource, err := toK8sResource(policyMember)
 	if err != nil {
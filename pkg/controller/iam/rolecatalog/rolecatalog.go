@@ -0,0 +1,201 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rolecatalog provides a catalog of the predefined IAM roles. The catalog is bundled with
+// Config Connector so that roles can be validated without access to the IAM API.
+package rolecatalog
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const predefinedRolePrefix = "roles/"
+
+// basicRolesService is the name given in the list of complete services to the service of the basic
+// roles, e.g. 'roles/owner', which have no service in their name.
+const basicRolesService = "basic"
+
+// maxSuggestionDistance is the maximum edit distance between an unknown role and a role of the
+// catalog for the latter to be suggested in place of the former.
+const maxSuggestionDistance = 3
+
+//go:embed roles.txt
+var bundledRoles string
+
+//go:embed complete_services.txt
+var bundledCompleteServices string
+
+var defaultCatalog = mustParse(bundledRoles, bundledCompleteServices)
+
+// Catalog is a set of predefined IAM roles.
+type Catalog struct {
+	roles map[string]bool
+	// completeServices are the services, e.g. 'storage' for 'roles/storage.admin', all of whose
+	// roles are in the catalog. Basic roles, e.g. 'roles/owner', belong to the '' service.
+	completeServices map[string]bool
+	// complete is true if all the services of the roles in the catalog are complete, as is the
+	// case for a catalog generated from all the roles returned by the IAM API.
+	complete bool
+}
+
+// Default returns the catalog bundled with Config Connector.
+func Default() *Catalog {
+	return defaultCatalog
+}
+
+// Parse parses a catalog from a list of predefined roles and a list of the services all of whose
+// roles are in the former, e.g. 'storage', or 'basic' for the basic roles. Both lists have one item
+// per line. Empty lines and lines starting with '#' are ignored.
+func Parse(roles, completeServices string) (*Catalog, error) {
+	c := &Catalog{
+		roles:            make(map[string]bool),
+		completeServices: make(map[string]bool),
+	}
+	for i, line := range nonCommentLines(roles) {
+		if line == "" {
+			continue
+		}
+		if !IsPredefinedRole(line) {
+			return nil, fmt.Errorf("line %v: '%v' is not a predefined role", i+1, line)
+		}
+		c.roles[line] = true
+	}
+	for _, line := range nonCommentLines(completeServices) {
+		switch line {
+		case "":
+			continue
+		case basicRolesService:
+			c.completeServices[""] = true
+		default:
+			c.completeServices[line] = true
+		}
+	}
+	c.complete = len(c.roles) > 0
+	for role := range c.roles {
+		if !c.completeServices[serviceOf(role)] {
+			c.complete = false
+			break
+		}
+	}
+	return c, nil
+}
+
+// nonCommentLines returns the trimmed lines of the given string, with lines starting with '#'
+// replaced by empty lines so that line numbers are preserved.
+func nonCommentLines(s string) []string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			line = ""
+		}
+		lines[i] = line
+	}
+	return lines
+}
+
+func mustParse(roles, completeServices string) *Catalog {
+	c, err := Parse(roles, completeServices)
+	if err != nil {
+		panic(fmt.Errorf("error parsing the bundled IAM role catalog: %w", err))
+	}
+	return c
+}
+
+// IsPredefinedRole returns true if the given role is a predefined role, e.g. 'roles/storage.admin',
+// as opposed to a custom role, e.g. 'projects/my-project/roles/myRole'.
+func IsPredefinedRole(role string) bool {
+	return strings.HasPrefix(role, predefinedRolePrefix)
+}
+
+// HasRole returns true if the given role is in the catalog.
+func (c *Catalog) HasRole(role string) bool {
+	return c.roles[role]
+}
+
+// CoversService returns true if the catalog has all the roles of the service of the given
+// predefined role. Roles of services that the catalog doesn't fully cover can't be validated
+// against it.
+func (c *Catalog) CoversService(role string) bool {
+	return c.completeServices[serviceOf(role)]
+}
+
+// IsComplete returns true if every service of the catalog's roles is complete. A complete catalog
+// lists all the services with predefined roles, so a role of any other service, e.g.
+// 'roles/storgae.admin', doesn't exist.
+func (c *Catalog) IsComplete() bool {
+	return c.complete
+}
+
+// Suggest returns the role of the catalog closest to the given unknown role, or an empty string if
+// none is close enough to likely be what was meant.
+func (c *Catalog) Suggest(role string) string {
+	suggestion := ""
+	bestDistance := maxSuggestionDistance + 1
+	candidates := make([]string, 0, len(c.roles))
+	for r := range c.roles {
+		candidates = append(candidates, r)
+	}
+	// Iterate in a stable order so that ties are broken deterministically.
+	sort.Strings(candidates)
+	for _, candidate := range candidates {
+		if d := editDistance(role, candidate); d < bestDistance {
+			suggestion = candidate
+			bestDistance = d
+		}
+	}
+	return suggestion
+}
+
+func serviceOf(role string) string {
+	name := strings.TrimPrefix(role, predefinedRolePrefix)
+	if i := strings.Index(name, "."); i >= 0 {
+		return name[:i]
+	}
+	return ""
+}
+
+// editDistance returns the Levenshtein distance between the given strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min(values ...int) int {
+	res := values[0]
+	for _, v := range values[1:] {
+		if v < res {
+			res = v
+		}
+	}
+	return res
+}
@@ -0,0 +1,62 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rolecatalog_test
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/rolecatalog"
+)
+
+func TestDefaultCatalog(t *testing.T) {
+	catalog := rolecatalog.Default()
+	for _, role := range []string{"roles/owner", "roles/iam.serviceAccountUser", "roles/storage.objectViewer"} {
+		if !catalog.HasRole(role) {
+			t.Errorf("expected the bundled catalog to have role '%v'", role)
+		}
+	}
+	if !catalog.CoversService("roles/owner") {
+		t.Errorf("expected the bundled catalog to cover the basic roles")
+	}
+}
+
+func TestParse(t *testing.T) {
+	catalog, err := rolecatalog.Parse("# Comment\n\nroles/viewer\nroles/pubsub.viewer\nroles/storage.admin\n", "# Comment\nbasic\npubsub\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !catalog.HasRole("roles/pubsub.viewer") || !catalog.CoversService("roles/pubsub.editor") {
+		t.Errorf("expected the catalog to have role 'roles/pubsub.viewer' and to cover the pubsub service")
+	}
+	if !catalog.CoversService("roles/ownr") {
+		t.Errorf("expected the catalog to cover the basic roles")
+	}
+	if !catalog.HasRole("roles/storage.admin") || catalog.CoversService("roles/storage.admin") {
+		t.Errorf("expected the catalog to have role 'roles/storage.admin' but not to cover the storage service")
+	}
+	if catalog.IsComplete() {
+		t.Errorf("expected the catalog not to be complete, as it doesn't cover the storage service")
+	}
+	catalog, err = rolecatalog.Parse("roles/viewer\nroles/storage.admin\n", "basic\nstorage\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !catalog.IsComplete() {
+		t.Errorf("expected the catalog to be complete, as it covers all the services of its roles")
+	}
+	if _, err := rolecatalog.Parse("projects/my-project/roles/myRole\n", ""); err == nil {
+		t.Errorf("expected an error when parsing a custom role, got none")
+	}
+}
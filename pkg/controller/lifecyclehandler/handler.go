@@ -17,6 +17,7 @@ package lifecyclehandler
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corekccv1alpha1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/core/v1alpha1"
 	k8sv1alpha1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/k8s/v1alpha1"
@@ -214,6 +215,7 @@ func (r *LifecycleHandler) EnsureFinalizers(ctx context.Context, original, resou
 
 func (r *LifecycleHandler) HandleUpToDate(ctx context.Context, resource *k8s.Resource) error {
 	setCondition(resource, corev1.ConditionTrue, k8s.UpToDate, k8s.UpToDateMessage)
+	recordReconcileHistory(ctx, resource, k8s.UpToDate)
 	if err := r.updateAPIServer(ctx, resource); err != nil {
 		return err
 	}
@@ -231,6 +233,7 @@ func (r *LifecycleHandler) HandleUnresolvableDeps(ctx context.Context, resource
 	if !k8s.ReadyConditionMatches(resource, corev1.ConditionFalse, reason, msg) {
 		setCondition(resource, corev1.ConditionFalse, reason, msg)
 		setObservedGeneration(resource, resource.GetGeneration())
+		recordReconcileHistory(ctx, resource, reason)
 		if err := r.updateStatus(ctx, resource); err != nil {
 			return err
 		}
@@ -240,11 +243,16 @@ func (r *LifecycleHandler) HandleUnresolvableDeps(ctx context.Context, resource
 }
 
 func (r *LifecycleHandler) HandleObtainLeaseFailed(ctx context.Context, resource *k8s.Resource, err error) error {
+	return r.HandleManagementConflict(ctx, resource, err)
+}
+
+func (r *LifecycleHandler) HandleManagementConflict(ctx context.Context, resource *k8s.Resource, err error) error {
 	msg := err.Error()
 	// Only update the API server if there's new information
 	if !k8s.ReadyConditionMatches(resource, corev1.ConditionFalse, k8s.ManagementConflict, msg) {
 		setCondition(resource, corev1.ConditionFalse, k8s.ManagementConflict, msg)
 		setObservedGeneration(resource, resource.GetGeneration())
+		recordReconcileHistory(ctx, resource, k8s.ManagementConflict)
 		if err := r.updateStatus(ctx, resource); err != nil {
 			return err
 		}
@@ -253,12 +261,30 @@ func (r *LifecycleHandler) HandleObtainLeaseFailed(ctx context.Context, resource
 	return err
 }
 
+// HandleExpired marks the given time-bound resource as expired. Controllers call it once they have
+// removed the underlying GCP resource of a resource that has reached its expiry time.
+func (r *LifecycleHandler) HandleExpired(ctx context.Context, resource *k8s.Resource, expiresAt time.Time) error {
+	msg := fmt.Sprintf(k8s.ExpiredMessageTmpl, expiresAt.UTC().Format(time.RFC3339))
+	// Only update the API server if there's new information
+	if !k8s.ReadyConditionMatches(resource, corev1.ConditionFalse, k8s.Expired, msg) {
+		setCondition(resource, corev1.ConditionFalse, k8s.Expired, msg)
+		setObservedGeneration(resource, resource.GetGeneration())
+		recordReconcileHistory(ctx, resource, k8s.Expired)
+		if err := r.updateStatus(ctx, resource); err != nil {
+			return err
+		}
+		r.recordEvent(resource, corev1.EventTypeNormal, k8s.Expired, msg)
+	}
+	return nil
+}
+
 func (r *LifecycleHandler) HandlePreActuationTransformFailed(ctx context.Context, resource *k8s.Resource, err error) error {
 	msg := err.Error()
 	// Only update the API server if there's new information
 	if !k8s.ReadyConditionMatches(resource, corev1.ConditionFalse, k8s.PreActuationTransformFailed, msg) {
 		setCondition(resource, corev1.ConditionFalse, k8s.PreActuationTransformFailed, msg)
 		setObservedGeneration(resource, resource.GetGeneration())
+		recordReconcileHistory(ctx, resource, k8s.PreActuationTransformFailed)
 		if err := r.updateStatus(ctx, resource); err != nil {
 			return err
 		}
@@ -273,6 +299,7 @@ func (r *LifecycleHandler) HandlePostActuationTransformFailed(ctx context.Contex
 	if !k8s.ReadyConditionMatches(resource, corev1.ConditionFalse, k8s.PostActuationTransformFailed, msg) {
 		setCondition(resource, corev1.ConditionFalse, k8s.PostActuationTransformFailed, msg)
 		setObservedGeneration(resource, resource.GetGeneration())
+		recordReconcileHistory(ctx, resource, k8s.PostActuationTransformFailed)
 		if err := r.updateStatus(ctx, resource); err != nil {
 			return err
 		}
@@ -295,6 +322,7 @@ func (r *LifecycleHandler) HandleUpdateFailed(ctx context.Context, resource *k8s
 	msg := fmt.Sprintf(k8s.UpdateFailedMessageTmpl, err)
 	setCondition(resource, corev1.ConditionFalse, k8s.UpdateFailed, msg)
 	setObservedGeneration(resource, resource.GetGeneration())
+	recordReconcileHistory(ctx, resource, k8s.UpdateFailed)
 	if err := r.updateStatus(ctx, resource); err != nil {
 		return err
 	}
@@ -315,6 +343,7 @@ func (r *LifecycleHandler) HandleDeleting(ctx context.Context, resource *k8s.Res
 func (r *LifecycleHandler) HandleDeleted(ctx context.Context, resource *k8s.Resource) error {
 	setCondition(resource, corev1.ConditionFalse, k8s.Deleted, k8s.DeletedMessage)
 	setObservedGeneration(resource, resource.GetGeneration())
+	recordReconcileHistory(ctx, resource, k8s.Deleted)
 	// Do an explicit status update first to prevent a race between the status update and the API
 	// server pruning the resource if there are no more finalizers present.
 	if err := r.updateStatus(ctx, resource); err != nil {
@@ -330,6 +359,7 @@ func (r *LifecycleHandler) HandleDeleteFailed(ctx context.Context, resource *k8s
 	msg := fmt.Sprintf(k8s.DeleteFailedMessageTmpl, err)
 	setCondition(resource, corev1.ConditionFalse, k8s.DeleteFailed, msg)
 	setObservedGeneration(resource, resource.GetGeneration())
+	recordReconcileHistory(ctx, resource, k8s.DeleteFailed)
 	if err := r.updateStatus(ctx, resource); err != nil {
 		return err
 	}
@@ -341,6 +371,7 @@ func (r *LifecycleHandler) HandleUnmanaged(ctx context.Context, resource *k8s.Re
 	msg := fmt.Sprintf(k8s.UnmanagedMessageTmpl, resource.GetNamespace())
 	setCondition(resource, corev1.ConditionFalse, k8s.Unmanaged, msg)
 	setObservedGeneration(resource, resource.GetGeneration())
+	recordReconcileHistory(ctx, resource, k8s.Unmanaged)
 	if err := r.updateStatus(ctx, resource); err != nil {
 		return err
 	}
@@ -0,0 +1,84 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecyclehandler
+
+import (
+	"context"
+	"time"
+
+	k8sv1alpha1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/k8s/v1alpha1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type reconcileTrackerKey struct{}
+
+// reconcileTracker keeps track of a single reconcile so that its outcome can
+// be recorded in the reconciled resource's status.reconcileHistory.
+type reconcileTracker struct {
+	startTime  time.Time
+	gcpMutated bool
+}
+
+// WithReconcileTracker returns a copy of the given context that tracks the
+// start time of a reconcile and whether the reconcile mutated the underlying
+// GCP resource. Controllers are expected to call it at the start of each
+// reconcile and pass the returned context to the LifecycleHandler.
+func WithReconcileTracker(ctx context.Context) context.Context {
+	return context.WithValue(ctx, reconcileTrackerKey{}, &reconcileTracker{startTime: time.Now()})
+}
+
+func getReconcileTracker(ctx context.Context) (*reconcileTracker, bool) {
+	tracker, ok := ctx.Value(reconcileTrackerKey{}).(*reconcileTracker)
+	return tracker, ok
+}
+
+// MarkGCPMutated records that the current reconcile has successfully created,
+// updated or deleted the underlying GCP resource. Controllers are expected to
+// call it only once the GCP call has succeeded, so that failed calls are not
+// recorded as mutations.
+func MarkGCPMutated(ctx context.Context) {
+	if tracker, ok := getReconcileTracker(ctx); ok {
+		tracker.gcpMutated = true
+	}
+}
+
+// recordReconcileHistory appends the outcome of the current reconcile to the
+// resource's status.reconcileHistory if the resource has opted into it via the
+// 'reconcile-history-size' annotation. Reconciles that leave the resource's
+// status untouched (e.g. no-op reconciles of up-to-date resources) are not
+// recorded, as the LifecycleHandler does not write to the API server for them.
+func recordReconcileHistory(ctx context.Context, resource *k8s.Resource, reason string) {
+	// Invalid annotation values are rejected by the webhook, so they are
+	// treated the same as the annotation being absent.
+	size, err := k8s.GetReconcileHistorySize(resource)
+	if err != nil || size == 0 {
+		k8s.RemoveReconcileHistory(resource)
+		return
+	}
+	now := metav1.Now()
+	entry := k8sv1alpha1.ReconcileHistoryEntry{
+		Timestamp: now.Format(time.RFC3339),
+		Reason:    reason,
+	}
+	if tracker, ok := getReconcileTracker(ctx); ok {
+		entry.Duration = now.Sub(tracker.startTime).Round(time.Millisecond).String()
+		entry.GCPMutated = tracker.gcpMutated
+	}
+	if err := k8s.AppendReconcileHistoryEntry(resource, entry, size); err != nil {
+		k8s.RemoveReconcileHistory(resource)
+	}
+}
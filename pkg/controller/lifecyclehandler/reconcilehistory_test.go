@@ -0,0 +1,96 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecyclehandler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/test/mocks"
+)
+
+func TestReconcileHistoryGCPMutated(t *testing.T) {
+	tests := []struct {
+		name               string
+		reconcile          func(ctx context.Context, h *LifecycleHandler, resource *k8s.Resource) error
+		expectedReason     string
+		expectedGCPMutated bool
+	}{
+		{
+			name: "successful update",
+			reconcile: func(ctx context.Context, h *LifecycleHandler, resource *k8s.Resource) error {
+				if err := h.HandleUpdating(ctx, resource); err != nil {
+					return err
+				}
+				MarkGCPMutated(ctx)
+				return h.HandleUpToDate(ctx, resource)
+			},
+			expectedReason:     k8s.UpToDate,
+			expectedGCPMutated: true,
+		},
+		{
+			name: "failed update",
+			reconcile: func(ctx context.Context, h *LifecycleHandler, resource *k8s.Resource) error {
+				if err := h.HandleUpdating(ctx, resource); err != nil {
+					return err
+				}
+				if err := h.HandleUpdateFailed(ctx, resource, fmt.Errorf("error applying desired state")); err == nil {
+					return fmt.Errorf("got no error from HandleUpdateFailed, want one")
+				}
+				return nil
+			},
+			expectedReason:     k8s.UpdateFailed,
+			expectedGCPMutated: false,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewLifecycleHandler(mocks.NewClient(runtime.NewScheme()), record.NewFakeRecorder(10))
+			resource := &k8s.Resource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-resource",
+					Namespace:   "test-namespace",
+					Annotations: map[string]string{k8s.ReconcileHistorySizeAnnotation: "5"},
+				},
+			}
+			resource.SetGroupVersionKind(schema.GroupVersionKind{Group: "test1.cnrm.cloud.google.com", Version: "v1alpha1", Kind: "Test1Foo"})
+			ctx := WithReconcileTracker(context.Background())
+			if err := tc.reconcile(ctx, &h, resource); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			history, err := k8s.GetReconcileHistory(resource)
+			if err != nil {
+				t.Fatalf("error getting the reconcile history: %v", err)
+			}
+			if len(history) != 1 {
+				t.Fatalf("got reconcile history %v, want a single entry", history)
+			}
+			if history[0].Reason != tc.expectedReason {
+				t.Errorf("got reason '%v', want '%v'", history[0].Reason, tc.expectedReason)
+			}
+			if history[0].GCPMutated != tc.expectedGCPMutated {
+				t.Errorf("got gcpMutated %v, want %v", history[0].GCPMutated, tc.expectedGCPMutated)
+			}
+		})
+	}
+}
@@ -137,6 +137,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (res
 	r.RecordReconcileWorkers(ctx, r.gvk)
 	defer r.AfterReconcile()
 	defer r.RecordReconcileMetrics(ctx, r.gvk, req.Namespace, req.Name, startTime, &err)
+	ctx = lifecyclehandler.WithReconcileTracker(ctx)
 
 	u := &unstructured.Unstructured{}
 	u.SetGroupVersionKind(r.gvk)
@@ -231,6 +232,7 @@ func (r *Reconciler) sync(ctx context.Context, krmResource *krmtotf.Resource) (r
 		if _, err := krmResource.TFResource.Apply(ctx, liveState, &terraform.InstanceDiff{Destroy: true}, r.provider.Meta()); err != nil {
 			return false, r.HandleDeleteFailed(ctx, &krmResource.Resource, fmt.Errorf("error deleting resource: %v", err))
 		}
+		lifecyclehandler.MarkGCPMutated(ctx)
 		return false, r.handleDeleted(ctx, krmResource)
 	}
 	liveState, err := krmtotf.FetchLiveState(ctx, krmResource, r.provider, r, r.smLoader)
@@ -266,6 +268,9 @@ func (r *Reconciler) sync(ctx context.Context, krmResource *krmtotf.Resource) (r
 		}
 		return false, r.HandleUpdateFailed(ctx, &krmResource.Resource, fmt.Errorf("error expanding resource configuration for kind %s: %v", krmResource.Kind, err))
 	}
+	if err := r.validateResourceAllowedInNamespace(ctx, krmResource, config); err != nil {
+		return false, r.HandleUpdateFailed(ctx, &krmResource.Resource, err)
+	}
 	diff, err := krmResource.TFResource.Diff(ctx, liveState, config, r.provider.Meta())
 	if err != nil {
 		return false, r.HandleUpdateFailed(ctx, &krmResource.Resource, fmt.Errorf("error calculating diff: %v", err))
@@ -298,6 +303,7 @@ func (r *Reconciler) sync(ctx context.Context, krmResource *krmtotf.Resource) (r
 		r.logger.Error(err, "error applying desired state", "resource", krmResource.GetNamespacedName())
 		return false, r.HandleUpdateFailed(ctx, &krmResource.Resource, fmt.Errorf("error applying desired state: %v", err))
 	}
+	lifecyclehandler.MarkGCPMutated(ctx)
 	return false, r.handleUpToDate(ctx, krmResource, newState, secretVersions)
 }
 
@@ -395,6 +401,37 @@ func (r *Reconciler) applyChangesForBackwardsCompatibility(ctx context.Context,
 	return nil
 }
 
+// validateResourceAllowedInNamespace checks that the resource doesn't target a
+// project, folder or location that its namespace doesn't allow. The webhooks
+// can't resolve references to Project and Folder resources, so the check is
+// redone here against the resolved Terraform configuration.
+func (r *Reconciler) validateResourceAllowedInNamespace(ctx context.Context, resource *krmtotf.Resource, config *terraform.ResourceConfig) error {
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: resource.GetNamespace()}, ns); err != nil {
+		return fmt.Errorf("error getting namespace %v: %v", resource.GetNamespace(), err)
+	}
+	rc := &resource.ResourceConfig
+	for _, h := range rc.HierarchicalReferences {
+		refConfig, err := krmtotf.GetReferenceConfigForHierarchicalReference(h, rc)
+		if err != nil {
+			return err
+		}
+		if val, ok := config.Raw[refConfig.TFField].(string); ok && val != "" {
+			if err := k8s.ValidateHierarchicalReferenceAllowedInNamespace(ns, h, val); err != nil {
+				return err
+			}
+		}
+	}
+	for _, c := range rc.Containers {
+		if val, ok := config.Raw[c.TFField].(string); ok && val != "" {
+			if err := k8s.ValidateContainerAllowedInNamespace(ns, c.Type, val); err != nil {
+				return err
+			}
+		}
+	}
+	return k8s.ValidateLocationAllowedInNamespace(ns, k8s.LocationOf(config.Raw))
+}
+
 func (r *Reconciler) obtainResourceLeaseIfNecessary(ctx context.Context, krmResource *krmtotf.Resource, liveState *terraform.InstanceState) error {
 	conflictPolicy, err := k8s.GetManagementConflictPreventionAnnotationValue(krmResource)
 	if err != nil {
@@ -98,6 +98,35 @@ func GetOpenAPIV3SchemaSkeleton() *apiextensions.JSONSchemaProps {
 							"If this is equal to metadata.generation, then that means that the current reported status reflects the most recent desired state of the resource.",
 						Type: "integer",
 					},
+					"reconcileHistory": {
+						Description: "ReconcileHistory lists the outcomes of the most recent reconciles of the resource, oldest first. " +
+							"It is only populated if the resource has the 'cnrm.cloud.google.com/reconcile-history-size' annotation set.",
+						Items: &apiextensions.JSONSchemaPropsOrArray{
+							Schema: &apiextensions.JSONSchemaProps{
+								Type: "object",
+								Properties: map[string]apiextensions.JSONSchemaProps{
+									"duration": {
+										Description: "Duration of the reconcile (e.g. \"1.5s\").",
+										Type:        "string",
+									},
+									"gcpMutated": {
+										Description: "True if the reconcile successfully created, updated or deleted the " +
+											"underlying GCP resource.",
+										Type: "boolean",
+									},
+									"reason": {
+										Description: "Unique, one-word, CamelCase reason for the outcome of the reconcile.",
+										Type:        "string",
+									},
+									"timestamp": {
+										Description: "Time at which the reconcile finished.",
+										Type:        "string",
+									},
+								},
+							},
+						},
+						Type: "array",
+					},
 					"conditions": {
 						Description: "Conditions represent the latest available observation of the resource's " +
 							"current state.",
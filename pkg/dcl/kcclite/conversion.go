@@ -522,6 +522,14 @@ func resolveMixedSpecAndLegacyStatus(state *unstructured.Unstructured, resource
 	if found {
 		status["observedGeneration"] = g
 	}
+	// preserve the reconcile history
+	h, found, err := unstructured.NestedFieldCopy(resource.Status, "reconcileHistory")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error resolving reconcileHistory from resource status: %w", err)
+	}
+	if found {
+		status["reconcileHistory"] = h
+	}
 
 	stateSpec, found, err := unstructured.NestedMap(state.Object, "spec")
 	if err != nil {
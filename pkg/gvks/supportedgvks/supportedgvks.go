@@ -80,6 +80,7 @@ func basedOnServiceMappings(smLoader *servicemappingloader.ServiceMappingLoader,
 func BasedOnHandwrittenIAMTypes() []schema.GroupVersionKind {
 	return []schema.GroupVersionKind{
 		iamapi.IAMAuditConfigGVK,
+		iamapi.IAMDenyPolicyGVK,
 		iamapi.IAMPolicyGVK,
 		iamapi.IAMPolicyMemberGVK,
 		iamapi.IAMPartialPolicyGVK,
@@ -0,0 +1,201 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corekccv1alpha1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/core/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// locationFields are the fields of the spec that hold the location of a
+// resource, in order of precedence.
+var locationFields = []string{"location", "region", "zone"}
+
+// zoneRegex matches zones, e.g. 'us-central1-a', and captures their region.
+var zoneRegex = regexp.MustCompile(`^([a-z]+-[a-z]+[0-9]+)-[a-z]$`)
+
+// LocationOf returns the location held by the given spec, or an empty string
+// if it has none.
+func LocationOf(spec map[string]interface{}) string {
+	for _, field := range locationFields {
+		if val, _, _ := unstructured.NestedString(spec, field); val != "" {
+			return val
+		}
+	}
+	return ""
+}
+
+// ValidateResourceAllowedInNamespace returns an error if the given resource
+// targets a project, folder or location that its namespace doesn't allow. The
+// container of the resource is read from its hierarchical reference if it
+// supports hierarchical references, and from its container annotations
+// otherwise. Hierarchical references to Project or Folder resources by name
+// are not validated as they can only be resolved by the controllers.
+func ValidateResourceAllowedInNamespace(obj metav1.Object, spec map[string]interface{}, ns *corev1.Namespace,
+	hierarchicalRefs []corekccv1alpha1.HierarchicalReference, containers []corekccv1alpha1.Container) error {
+	if !HasAllowedTargetsAnnotation(ns) {
+		return nil
+	}
+	if len(hierarchicalRefs) > 0 {
+		ref, h, err := GetHierarchicalReferenceFromSpec(spec, hierarchicalRefs)
+		if err != nil {
+			return fmt.Errorf("error getting hierarchical reference: %v", err)
+		}
+		if ref != nil && ref.External != "" {
+			if err := ValidateHierarchicalReferenceAllowedInNamespace(ns, h, ref.External); err != nil {
+				return err
+			}
+		}
+	} else {
+		for _, c := range containers {
+			if val, ok := GetAnnotation(GetAnnotationForContainerType(c.Type), obj); ok {
+				if err := ValidateContainerAllowedInNamespace(ns, c.Type, val); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return ValidateLocationAllowedInNamespace(ns, LocationOf(spec))
+}
+
+// ValidateResourceIDAllowedInNamespace returns an error if the resource of
+// the given kind and ID belongs to a project, folder or location that the
+// given namespace doesn't allow, e.g. the resource referenced by an IAM
+// resource. Projects and Folders are identified by their ID, with or without
+// the collection prefix. For other kinds, the project, folder and location
+// are read from the 'projects/', 'folders/', 'locations/', 'regions/' and
+// 'zones/' segments of the ID, if any.
+func ValidateResourceIDAllowedInNamespace(ns *corev1.Namespace, kind, id string) error {
+	if !HasAllowedTargetsAnnotation(ns) {
+		return nil
+	}
+	switch kind {
+	case "Project":
+		return ValidateContainerAllowedInNamespace(ns, corekccv1alpha1.ContainerTypeProject, id)
+	case "Folder":
+		return ValidateContainerAllowedInNamespace(ns, corekccv1alpha1.ContainerTypeFolder, id)
+	}
+	segments := strings.Split(id, "/")
+	for i := 0; i+1 < len(segments); i++ {
+		var err error
+		switch segments[i] {
+		case "projects":
+			err = ValidateContainerAllowedInNamespace(ns, corekccv1alpha1.ContainerTypeProject, segments[i+1])
+		case "folders":
+			err = ValidateContainerAllowedInNamespace(ns, corekccv1alpha1.ContainerTypeFolder, segments[i+1])
+		case "locations", "regions", "zones":
+			err = ValidateLocationAllowedInNamespace(ns, segments[i+1])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateHierarchicalReferenceAllowedInNamespace returns an error if the
+// given namespace doesn't allow its resources to target the given value of
+// the given hierarchical reference.
+func ValidateHierarchicalReferenceAllowedInNamespace(ns *corev1.Namespace, hierarchicalRef corekccv1alpha1.HierarchicalReference, val string) error {
+	if hierarchicalRef.Type == corekccv1alpha1.HierarchicalReferenceTypeBillingAccount {
+		return nil
+	}
+	return ValidateContainerAllowedInNamespace(ns, containerTypeFor(hierarchicalRef), val)
+}
+
+// ValidateContainerAllowedInNamespace returns an error if the given namespace
+// restricts the containers of the given type that its resources can target
+// and the given container isn't one of them. Only projects and folders can be
+// restricted. The container can be given either as an ID, e.g. 'my-project',
+// or as a name, e.g. 'projects/my-project'.
+func ValidateContainerAllowedInNamespace(ns *corev1.Namespace, containerType corekccv1alpha1.ContainerType, val string) error {
+	var annotation, id string
+	switch containerType {
+	case corekccv1alpha1.ContainerTypeProject:
+		annotation, id = AllowedProjectsAnnotation, strings.TrimPrefix(val, "projects/")
+	case corekccv1alpha1.ContainerTypeFolder:
+		annotation, id = AllowedFoldersAnnotation, strings.TrimPrefix(val, "folders/")
+	default:
+		return nil
+	}
+	allowed, ok := allowedValues(ns, annotation)
+	if !ok {
+		return nil
+	}
+	for _, a := range allowed {
+		if a == id {
+			return nil
+		}
+	}
+	return fmt.Errorf("%v '%v' is not allowed in namespace %v, annotation %v only allows [%v]",
+		containerType, id, ns.GetName(), annotation, strings.Join(allowed, ", "))
+}
+
+// ValidateLocationAllowedInNamespace returns an error if the given namespace
+// restricts the locations that its resources can target and the given
+// location isn't one of them. A zone is allowed if its region is. Locations
+// are compared case-insensitively, and an empty location is always allowed.
+func ValidateLocationAllowedInNamespace(ns *corev1.Namespace, location string) error {
+	allowed, ok := allowedValues(ns, AllowedLocationsAnnotation)
+	if !ok || location == "" {
+		return nil
+	}
+	region := location
+	if m := zoneRegex.FindStringSubmatch(strings.ToLower(location)); m != nil {
+		region = m[1]
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(a, location) || strings.EqualFold(a, region) {
+			return nil
+		}
+	}
+	return fmt.Errorf("location '%v' is not allowed in namespace %v, annotation %v only allows [%v]",
+		location, ns.GetName(), AllowedLocationsAnnotation, strings.Join(allowed, ", "))
+}
+
+// HasAllowedTargetsAnnotation returns true if the given namespace restricts
+// the projects, folders or locations that its resources can target.
+func HasAllowedTargetsAnnotation(ns *corev1.Namespace) bool {
+	for _, annotation := range []string{AllowedProjectsAnnotation, AllowedFoldersAnnotation, AllowedLocationsAnnotation} {
+		if _, ok := GetAnnotation(annotation, ns); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedValues returns the values listed by the given annotation of the
+// given namespace, and whether the namespace has the annotation at all. A
+// namespace with an empty annotation allows no value.
+func allowedValues(ns *corev1.Namespace, annotation string) ([]string, bool) {
+	val, ok := GetAnnotation(annotation, ns)
+	if !ok {
+		return nil, false
+	}
+	res := make([]string, 0)
+	for _, v := range strings.Split(val, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res, true
+}
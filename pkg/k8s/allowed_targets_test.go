@@ -0,0 +1,252 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s_test
+
+import (
+	"testing"
+
+	corekccv1alpha1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/core/v1alpha1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestValidateResourceAllowedInNamespace(t *testing.T) {
+	restrictedNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "restricted-namespace",
+			Annotations: map[string]string{
+				k8s.AllowedProjectsAnnotation:  "project-1, project-2",
+				k8s.AllowedFoldersAnnotation:   "123",
+				k8s.AllowedLocationsAnnotation: "us-central1,US",
+			},
+		},
+	}
+	unrestrictedNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "unrestricted-namespace"},
+	}
+	denyAllNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "deny-all-namespace",
+			Annotations: map[string]string{
+				k8s.AllowedProjectsAnnotation: "",
+			},
+		},
+	}
+	hierarchicalRefs := []corekccv1alpha1.HierarchicalReference{
+		{Type: corekccv1alpha1.HierarchicalReferenceTypeProject, Key: "projectRef"},
+		{Type: corekccv1alpha1.HierarchicalReferenceTypeFolder, Key: "folderRef"},
+	}
+	containers := []corekccv1alpha1.Container{
+		{Type: corekccv1alpha1.ContainerTypeProject},
+	}
+	tests := []struct {
+		name             string
+		annotations      map[string]string
+		spec             map[string]interface{}
+		ns               *corev1.Namespace
+		hierarchicalRefs []corekccv1alpha1.HierarchicalReference
+		containers       []corekccv1alpha1.Container
+		shouldErr        bool
+	}{
+		{
+			name: "unrestricted namespace",
+			spec: map[string]interface{}{
+				"projectRef": map[string]interface{}{"external": "project-3"},
+				"location":   "europe-west1",
+			},
+			ns:               unrestrictedNamespace,
+			hierarchicalRefs: hierarchicalRefs,
+		},
+		{
+			name: "allowed project reference",
+			spec: map[string]interface{}{
+				"projectRef": map[string]interface{}{"external": "projects/project-2"},
+			},
+			ns:               restrictedNamespace,
+			hierarchicalRefs: hierarchicalRefs,
+		},
+		{
+			name: "project reference not allowed",
+			spec: map[string]interface{}{
+				"projectRef": map[string]interface{}{"external": "project-3"},
+			},
+			ns:               restrictedNamespace,
+			hierarchicalRefs: hierarchicalRefs,
+			shouldErr:        true,
+		},
+		{
+			name: "project reference by name is left to the controllers",
+			spec: map[string]interface{}{
+				"projectRef": map[string]interface{}{"name": "project-3"},
+			},
+			ns:               restrictedNamespace,
+			hierarchicalRefs: hierarchicalRefs,
+		},
+		{
+			name: "allowed folder reference",
+			spec: map[string]interface{}{
+				"folderRef": map[string]interface{}{"external": "folders/123"},
+			},
+			ns:               restrictedNamespace,
+			hierarchicalRefs: hierarchicalRefs,
+		},
+		{
+			name: "folder reference not allowed",
+			spec: map[string]interface{}{
+				"folderRef": map[string]interface{}{"external": "456"},
+			},
+			ns:               restrictedNamespace,
+			hierarchicalRefs: hierarchicalRefs,
+			shouldErr:        true,
+		},
+		{
+			name:        "allowed container annotation",
+			annotations: map[string]string{k8s.ProjectIDAnnotation: "project-1"},
+			ns:          restrictedNamespace,
+			containers:  containers,
+		},
+		{
+			name:        "container annotation not allowed",
+			annotations: map[string]string{k8s.ProjectIDAnnotation: "project-3"},
+			ns:          restrictedNamespace,
+			containers:  containers,
+			shouldErr:   true,
+		},
+		{
+			name:        "namespace allowing no project",
+			annotations: map[string]string{k8s.ProjectIDAnnotation: "project-1"},
+			ns:          denyAllNamespace,
+			containers:  containers,
+			shouldErr:   true,
+		},
+		{
+			name: "zone of an allowed region",
+			spec: map[string]interface{}{"zone": "us-central1-a"},
+			ns:   restrictedNamespace,
+		},
+		{
+			name: "allowed multi-region",
+			spec: map[string]interface{}{"location": "us"},
+			ns:   restrictedNamespace,
+		},
+		{
+			name:      "location not allowed",
+			spec:      map[string]interface{}{"region": "europe-west1"},
+			ns:        restrictedNamespace,
+			shouldErr: true,
+		},
+		{
+			name:      "zone of a region not allowed",
+			spec:      map[string]interface{}{"location": "europe-west1-b"},
+			ns:        restrictedNamespace,
+			shouldErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			obj := &unstructured.Unstructured{}
+			obj.SetAnnotations(tc.annotations)
+			err := k8s.ValidateResourceAllowedInNamespace(obj, tc.spec, tc.ns, tc.hierarchicalRefs, tc.containers)
+			if tc.shouldErr && err == nil {
+				t.Fatalf("expected an error, got none")
+			}
+			if !tc.shouldErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateResourceIDAllowedInNamespace(t *testing.T) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "restricted-namespace",
+			Annotations: map[string]string{
+				k8s.AllowedProjectsAnnotation:  "project-1",
+				k8s.AllowedFoldersAnnotation:   "123",
+				k8s.AllowedLocationsAnnotation: "us-central1",
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		kind      string
+		id        string
+		shouldErr bool
+	}{
+		{
+			name: "allowed project",
+			kind: "Project",
+			id:   "projects/project-1",
+		},
+		{
+			name:      "project not allowed",
+			kind:      "Project",
+			id:        "project-2",
+			shouldErr: true,
+		},
+		{
+			name:      "folder not allowed",
+			kind:      "Folder",
+			id:        "folders/456",
+			shouldErr: true,
+		},
+		{
+			name: "organization",
+			kind: "Organization",
+			id:   "organizations/789",
+		},
+		{
+			name: "resource in an allowed project and location",
+			kind: "PubSubTopic",
+			id:   "projects/project-1/locations/us-central1-a/topics/topic",
+		},
+		{
+			name:      "resource in a project not allowed",
+			kind:      "StorageBucket",
+			id:        "projects/project-2/buckets/bucket",
+			shouldErr: true,
+		},
+		{
+			name:      "resource in a location not allowed",
+			kind:      "ComputeSubnetwork",
+			id:        "projects/project-1/regions/europe-west1/subnetworks/subnetwork",
+			shouldErr: true,
+		},
+		{
+			name: "resource ID without container",
+			kind: "StorageBucket",
+			id:   "bucket",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := k8s.ValidateResourceIDAllowedInNamespace(ns, tc.kind, tc.id)
+			if tc.shouldErr && err == nil {
+				t.Fatalf("expected an error, got none")
+			}
+			if !tc.shouldErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
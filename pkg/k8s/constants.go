@@ -60,6 +60,8 @@ const (
 	DependencyNotFound                   = "DependencyNotFound"
 	DependencyInvalid                    = "DependencyInvalid"
 	ManagementConflict                   = "ManagementConflict"
+	Expired                              = "Expired"
+	ExpiredMessageTmpl                   = "The resource expired at %v and is no longer applied"
 	PreActuationTransformFailed          = "PreActuationTransformFailed"
 	PostActuationTransformFailed         = "PostActuationTransformFailed"
 	DeletionPolicyDelete                 = "delete"
@@ -68,6 +70,7 @@ const (
 	NamespaceEnvVar                      = "NAMESPACE"
 	ImmediateReconcileRequestsBufferSize = 10000
 	MaxNumResourceWatcherRoutines        = 10000
+	MaxReconcileHistorySize              = 50
 
 	ReadinessServerPort = 23232
 	ReadinessServerPath = "/ready"
@@ -80,6 +83,10 @@ const (
 	ManagementConflictPreventionPolicyNone     = "none"
 	ManagementConflictPreventionPolicyResource = "resource"
 
+	// IAM role and member validation annotation values
+	IAMRoleAndMemberValidationNone   = "none"
+	IAMRoleAndMemberValidationStrict = "strict"
+
 	// State into spec annotation values
 	StateMergeIntoSpec = "merge"
 	StateAbsentInSpec  = "absent"
@@ -110,6 +117,13 @@ var (
 		OrgIDAnnotation,
 	}
 
+	// Annotations set on namespaces to restrict the projects, folders and
+	// locations that the resources in the namespace can target. Their values
+	// are comma-separated lists.
+	AllowedProjectsAnnotation  = FormatAnnotation("allowed-projects")
+	AllowedFoldersAnnotation   = FormatAnnotation("allowed-folders")
+	AllowedLocationsAnnotation = FormatAnnotation("allowed-locations")
+
 	ManagementConflictPreventionPolicyAnnotation               = "management-conflict-prevention-policy"
 	ManagementConflictPreventionPolicyFullyQualifiedAnnotation = FormatAnnotation(ManagementConflictPreventionPolicyAnnotation)
 	ManagementConflictPreventionPolicyValues                   = []string{
@@ -117,6 +131,14 @@ var (
 		ManagementConflictPreventionPolicyResource,
 	}
 
+	// IAMRoleAndMemberValidationAnnotation is set on namespaces to have the roles and members of
+	// the IAM resources in the namespace validated on admission.
+	IAMRoleAndMemberValidationAnnotation       = FormatAnnotation("iam-role-and-member-validation")
+	IAMRoleAndMemberValidationAnnotationValues = []string{
+		IAMRoleAndMemberValidationNone,
+		IAMRoleAndMemberValidationStrict,
+	}
+
 	KCCComponentLabel    = FormatAnnotation("component")
 	KCCSystemLabel       = FormatAnnotation("system")
 	KCCVersionLabel      = FormatAnnotation("version")
@@ -131,6 +153,8 @@ var (
 
 	BlueprintAttributionAnnotation = FormatAnnotation("blueprint")
 
+	ReconcileHistorySizeAnnotation = FormatAnnotation("reconcile-history-size")
+
 	StateIntoSpecAnnotation       = FormatAnnotation("state-into-spec")
 	StateIntoSpecAnnotationValues = []string{
 		StateMergeIntoSpec,
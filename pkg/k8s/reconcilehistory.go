@@ -0,0 +1,97 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/k8s/v1alpha1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/util"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const reconcileHistoryStatusField = "reconcileHistory"
+
+// ValidateReconcileHistorySizeAnnotation returns an error if the object has
+// the 'reconcile-history-size' annotation set to a value that is not an
+// integer between 0 and MaxReconcileHistorySize.
+func ValidateReconcileHistorySizeAnnotation(obj metav1.Object) error {
+	_, err := GetReconcileHistorySize(obj)
+	return err
+}
+
+// GetReconcileHistorySize returns the number of reconcile outcomes that should
+// be retained in the status of the given object. A size of 0 means that the
+// reconcile history is disabled, which is the default.
+func GetReconcileHistorySize(obj metav1.Object) (int, error) {
+	val, found := GetAnnotation(ReconcileHistorySizeAnnotation, obj)
+	if !found {
+		return 0, nil
+	}
+	size, err := strconv.Atoi(val)
+	if err != nil || size < 0 || size > MaxReconcileHistorySize {
+		return 0, fmt.Errorf("invalid value '%v' for '%v' annotation, must be an integer between 0 and %v",
+			val, ReconcileHistorySizeAnnotation, MaxReconcileHistorySize)
+	}
+	return size, nil
+}
+
+// GetReconcileHistory returns the reconcile history recorded in the resource's
+// status, oldest entry first.
+func GetReconcileHistory(r *Resource) ([]v1alpha1.ReconcileHistoryEntry, error) {
+	raw, ok := r.Status[reconcileHistoryStatusField]
+	if !ok {
+		return nil, nil
+	}
+	history := make([]v1alpha1.ReconcileHistoryEntry, 0)
+	if err := util.Marshal(raw, &history); err != nil {
+		return nil, fmt.Errorf("error parsing 'status.%v': %w", reconcileHistoryStatusField, err)
+	}
+	return history, nil
+}
+
+// AppendReconcileHistoryEntry adds the given entry to the reconcile history in
+// the resource's status, dropping the oldest entries so that at most 'size'
+// entries are retained. An existing history that cannot be parsed is replaced.
+func AppendReconcileHistoryEntry(r *Resource, entry v1alpha1.ReconcileHistoryEntry, size int) error {
+	if size <= 0 {
+		return fmt.Errorf("invalid reconcile history size %v, must be positive", size)
+	}
+	history, err := GetReconcileHistory(r)
+	if err != nil {
+		history = nil
+	}
+	history = append(history, entry)
+	if len(history) > size {
+		history = history[len(history)-size:]
+	}
+	var raw []interface{}
+	if err := util.Marshal(history, &raw); err != nil {
+		return fmt.Errorf("error marshalling 'status.%v': %w", reconcileHistoryStatusField, err)
+	}
+	if r.Status == nil {
+		r.Status = make(map[string]interface{})
+	}
+	r.Status[reconcileHistoryStatusField] = raw
+	return nil
+}
+
+// RemoveReconcileHistory removes the reconcile history from the resource's
+// status, e.g. after the resource has opted out of it.
+func RemoveReconcileHistory(r *Resource) {
+	delete(r.Status, reconcileHistoryStatusField)
+}
@@ -0,0 +1,153 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/k8s/v1alpha1"
+)
+
+func TestGetReconcileHistorySize(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		expectedSize int
+		hasError     bool
+	}{
+		{
+			name:         "annotation is absent",
+			expectedSize: 0,
+		},
+		{
+			name:         "valid size",
+			annotations:  map[string]string{ReconcileHistorySizeAnnotation: "10"},
+			expectedSize: 10,
+		},
+		{
+			name:         "zero disables the history",
+			annotations:  map[string]string{ReconcileHistorySizeAnnotation: "0"},
+			expectedSize: 0,
+		},
+		{
+			name:        "negative size",
+			annotations: map[string]string{ReconcileHistorySizeAnnotation: "-1"},
+			hasError:    true,
+		},
+		{
+			name:        "size above the maximum",
+			annotations: map[string]string{ReconcileHistorySizeAnnotation: "51"},
+			hasError:    true,
+		},
+		{
+			name:        "not an integer",
+			annotations: map[string]string{ReconcileHistorySizeAnnotation: "ten"},
+			hasError:    true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			r := &Resource{}
+			r.SetAnnotations(tc.annotations)
+			size, err := GetReconcileHistorySize(r)
+			if tc.hasError {
+				if err == nil {
+					t.Fatalf("got nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if size != tc.expectedSize {
+				t.Fatalf("got size %v, want %v", size, tc.expectedSize)
+			}
+		})
+	}
+}
+
+func TestAppendReconcileHistoryEntry(t *testing.T) {
+	entry := func(reason string) v1alpha1.ReconcileHistoryEntry {
+		return v1alpha1.ReconcileHistoryEntry{
+			Timestamp: "2022-11-08T00:00:00Z",
+			Reason:    reason,
+			Duration:  "1.5s",
+		}
+	}
+	tests := []struct {
+		name            string
+		existing        []v1alpha1.ReconcileHistoryEntry
+		newEntry        v1alpha1.ReconcileHistoryEntry
+		size            int
+		expectedHistory []v1alpha1.ReconcileHistoryEntry
+	}{
+		{
+			name:            "first entry",
+			newEntry:        entry(UpToDate),
+			size:            3,
+			expectedHistory: []v1alpha1.ReconcileHistoryEntry{entry(UpToDate)},
+		},
+		{
+			name:            "entry is appended after existing entries",
+			existing:        []v1alpha1.ReconcileHistoryEntry{entry(UpdateFailed)},
+			newEntry:        entry(UpToDate),
+			size:            3,
+			expectedHistory: []v1alpha1.ReconcileHistoryEntry{entry(UpdateFailed), entry(UpToDate)},
+		},
+		{
+			name:            "oldest entries are dropped once the history is full",
+			existing:        []v1alpha1.ReconcileHistoryEntry{entry(DependencyNotReady), entry(UpdateFailed), entry(UpToDate)},
+			newEntry:        entry(DeleteFailed),
+			size:            2,
+			expectedHistory: []v1alpha1.ReconcileHistoryEntry{entry(UpToDate), entry(DeleteFailed)},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			r := &Resource{}
+			for _, e := range tc.existing {
+				if err := AppendReconcileHistoryEntry(r, e, len(tc.existing)); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if err := AppendReconcileHistoryEntry(r, tc.newEntry, tc.size); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			history, err := GetReconcileHistory(r)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(history, tc.expectedHistory) {
+				t.Fatalf("got history %v, want %v", history, tc.expectedHistory)
+			}
+		})
+	}
+}
+
+func TestRemoveReconcileHistory(t *testing.T) {
+	r := &Resource{}
+	if err := AppendReconcileHistoryEntry(r, v1alpha1.ReconcileHistoryEntry{Reason: UpToDate}, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	RemoveReconcileHistory(r)
+	if _, ok := r.Status["reconcileHistory"]; ok {
+		t.Fatalf("got status.reconcileHistory, want it to be removed")
+	}
+}
@@ -93,6 +93,9 @@ func GetSpecAndStatusFromState(resource *Resource, state *terraform.InstanceStat
 	if observedGeneration, ok := resource.Status["observedGeneration"]; ok {
 		status["observedGeneration"] = deepcopy.DeepCopy(observedGeneration)
 	}
+	if reconcileHistory, ok := resource.Status["reconcileHistory"]; ok {
+		status["reconcileHistory"] = deepcopy.DeepCopy(reconcileHistory)
+	}
 	if len(spec) == 0 {
 		spec = nil
 	}
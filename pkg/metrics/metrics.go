@@ -34,6 +34,7 @@ var (
 	MInternalErrors           = stats.Int64("InternalErrorsTotal", "The number of internal errors", stats.UnitDimensionless)
 	MReconcileDuration        = stats.Float64("ReconcileDuration", "The duration of reconcile requests", "seconds")
 	MProcessStartTime         = stats.Float64("ProcessStartTimeSeconds", "Start time of the process since unix epoch in seconds", "seconds")
+	MWebhookBypassedDenials   = stats.Int64("WebhookBypassedDenials", "The number of admission requests allowed by a webhook in warn or audit mode that it would otherwise have denied", stats.UnitDimensionless)
 )
 
 // metrics defined in the format of prometheus/client_golang
@@ -33,6 +33,11 @@ func RegisterControllerOpenCensusViewsWithResourceNameLabel() error {
 	return view.Register(GetControllerViewsWithResourceNameLabel()...)
 }
 
+func RegisterWebhookOpenCensusViews() error {
+	// Register the views
+	return view.Register(GetWebhookViews()...)
+}
+
 func RegisterPrometheusExporter(addr string) error {
 	pe, err := prometheus.NewExporter(prometheus.Options{
 		Namespace: "configconnector",
@@ -21,4 +21,6 @@ var (
 	StatusTag, _       = tag.NewKey("status")
 	NamespaceTag, _    = tag.NewKey("namespace")
 	ResourceNameTag, _ = tag.NewKey("name")
+	WebhookTag, _      = tag.NewKey("webhook")
+	ModeTag, _         = tag.NewKey("mode")
 )
@@ -68,6 +68,16 @@ var (
 			Aggregation: view.Distribution(0, 5, 10, 25, 60, 5*60, 10*60, 15*60, 30*60, 45*60, 60*60),
 		},
 	}
+	webhookViews = []*view.View{
+		{
+			Name:        "webhook_bypassed_denials_total",
+			Measure:     MWebhookBypassedDenials,
+			Description: MWebhookBypassedDenials.Description(),
+			TagKeys:     []tag.Key{WebhookTag, ModeTag, KindTag, NamespaceTag},
+			Aggregation: view.Count(),
+		},
+		processStartTime,
+	}
 	controllerViews = []*view.View{
 		{
 			Name:        "reconcile_requests_total",
@@ -101,3 +111,7 @@ func GetControllerViewsWithResourceNameLabel() []*view.View {
 	views = append(views, controllerViewsWithResourceNameLabel...)
 	return views
 }
+
+func GetWebhookViews() []*view.View {
+	return webhookViews
+}
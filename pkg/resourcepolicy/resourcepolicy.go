@@ -0,0 +1,283 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resourcepolicy evaluates the CEL rules of ResourcePolicies against
+// the Config Connector resources that they match.
+package resourcepolicy
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/policy/v1alpha1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/util/slice"
+
+	"github.com/google/cel-go/cel"
+	celtypes "github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/ext"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	objectVariable    = "object"
+	oldObjectVariable = "oldObject"
+	namespaceVariable = "namespaceObject"
+	resourceVariable  = "resource"
+
+	// costLimit bounds the cost of evaluating a rule so that a single rule
+	// can't hold up the admission of resources. It matches the per-expression
+	// limit of the CEL validation rules of CustomResourceDefinitions.
+	costLimit = 1000000
+)
+
+var (
+	envOnce sync.Once
+	env     *cel.Env
+	envErr  error
+)
+
+// Input is what the rules of a ResourcePolicy are evaluated against.
+type Input struct {
+	// Object is the resource being admitted.
+	Object map[string]interface{}
+	// OldObject is the existing resource on updates, and nil on creations.
+	OldObject map[string]interface{}
+	// Namespace is the namespace of the resource.
+	Namespace *corev1.Namespace
+	// Project is the ID of the project of the underlying GCP resource, or an
+	// empty string if it can't be determined.
+	Project string
+	// Location is the location of the underlying GCP resource, or an empty
+	// string if it can't be determined.
+	Location string
+}
+
+// Violation is a rule of a ResourcePolicy that a resource doesn't satisfy.
+type Violation struct {
+	Policy  string
+	Rule    string
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("ResourcePolicy '%v', rule '%v': %v", v.Policy, v.Rule, v.Message)
+}
+
+// Policy is a ResourcePolicy whose rules have been compiled.
+type Policy struct {
+	name  string
+	rules []rule
+}
+
+type rule struct {
+	name    string
+	message string
+	program cel.Program
+}
+
+// Compile compiles the rules of the given ResourcePolicy. The returned error
+// lists all the rules that failed to compile.
+func Compile(policy *v1alpha1.ResourcePolicy) (*Policy, error) {
+	e, err := getEnv()
+	if err != nil {
+		return nil, err
+	}
+	if selector := policy.Spec.Match.NamespaceSelector; selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+			return nil, fmt.Errorf("invalid namespace selector in ResourcePolicy '%v': %w", policy.Name, err)
+		}
+	}
+	res := &Policy{name: policy.Name}
+	var errs []string
+	for _, r := range policy.Spec.Rules {
+		program, err := compileRule(e, r.Expression)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("rule '%v': %v", r.Name, err))
+			continue
+		}
+		message := r.Message
+		if message == "" {
+			message = fmt.Sprintf("failed expression '%v'", r.Expression)
+		}
+		res.rules = append(res.rules, rule{name: r.Name, message: message, program: program})
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("error compiling the rules of ResourcePolicy '%v': %v", policy.Name, strings.Join(errs, "; "))
+	}
+	return res, nil
+}
+
+func compileRule(e *cel.Env, expression string) (cel.Program, error) {
+	ast, issues := e.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if outputType := ast.OutputType(); outputType != cel.BoolType && outputType != cel.DynType {
+		return nil, fmt.Errorf("the expression must evaluate to a bool, got %v", outputType)
+	}
+	return e.Program(ast, cel.CostLimit(costLimit))
+}
+
+func getEnv() (*cel.Env, error) {
+	envOnce.Do(func() {
+		env, envErr = cel.NewEnv(
+			cel.Variable(objectVariable, cel.DynType),
+			cel.Variable(oldObjectVariable, cel.DynType),
+			cel.Variable(namespaceVariable, cel.MapType(cel.StringType, cel.DynType)),
+			cel.Variable(resourceVariable, cel.MapType(cel.StringType, cel.StringType)),
+			ext.Strings(),
+		)
+		if envErr != nil {
+			envErr = fmt.Errorf("error creating CEL environment: %w", envErr)
+		}
+	})
+	return env, envErr
+}
+
+// Evaluate evaluates the rules of the policy against the given input and
+// returns the rules that it violates. A rule that can't be evaluated, e.g.
+// because it accesses a field that the resource doesn't set, is violated.
+func (p *Policy) Evaluate(in *Input) []Violation {
+	// A nil map is not null in CEL, so the old object is bound to null on
+	// creations for rules to be able to test for 'oldObject == null'.
+	var oldObject interface{} = celtypes.NullValue
+	if in.OldObject != nil {
+		oldObject = in.OldObject
+	}
+	vars := map[string]interface{}{
+		objectVariable:    in.Object,
+		oldObjectVariable: oldObject,
+		namespaceVariable: namespaceValue(in.Namespace),
+		resourceVariable: map[string]string{
+			"project":  in.Project,
+			"location": in.Location,
+		},
+	}
+	var violations []Violation
+	for _, r := range p.rules {
+		out, _, err := r.program.Eval(vars)
+		if err != nil {
+			violations = append(violations, Violation{Policy: p.name, Rule: r.name,
+				Message: fmt.Sprintf("error evaluating rule: %v", err)})
+			continue
+		}
+		if satisfied, ok := out.Value().(bool); !ok || !satisfied {
+			violations = append(violations, Violation{Policy: p.name, Rule: r.name, Message: r.message})
+		}
+	}
+	return violations
+}
+
+func namespaceValue(ns *corev1.Namespace) map[string]interface{} {
+	nsLabels := ns.GetLabels()
+	if nsLabels == nil {
+		nsLabels = make(map[string]string)
+	}
+	nsAnnotations := ns.GetAnnotations()
+	if nsAnnotations == nil {
+		nsAnnotations = make(map[string]string)
+	}
+	return map[string]interface{}{
+		"name":        ns.GetName(),
+		"labels":      nsLabels,
+		"annotations": nsAnnotations,
+	}
+}
+
+// Matches returns true if the given ResourcePolicy applies to resources of the
+// given GroupVersionKind in the given namespace.
+func Matches(policy *v1alpha1.ResourcePolicy, gvk schema.GroupVersionKind, ns *corev1.Namespace) (bool, error) {
+	match := policy.Spec.Match
+	if !matchesKind(match.Kinds, gvk) {
+		return false, nil
+	}
+	if len(match.Namespaces) > 0 && !slice.StringSliceContains(match.Namespaces, ns.GetName()) {
+		return false, nil
+	}
+	if match.NamespaceSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(match.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace selector in ResourcePolicy '%v': %w", policy.Name, err)
+	}
+	return selector.Matches(labels.Set(ns.GetLabels())), nil
+}
+
+func matchesKind(kinds []v1alpha1.ResourcePolicyKind, gvk schema.GroupVersionKind) bool {
+	for _, k := range kinds {
+		if k.Group == gvk.Group && (k.Kind == "" || k.Kind == gvk.Kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// Cache caches the compiled ResourcePolicies so that their rules are only
+// compiled again when they change.
+type Cache struct {
+	mu sync.Mutex
+	// policies are the cached policies by the name of their ResourcePolicy.
+	policies map[string]cachedPolicy
+}
+
+type cachedPolicy struct {
+	uid             types.UID
+	resourceVersion string
+	policy          *Policy
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		policies: make(map[string]cachedPolicy),
+	}
+}
+
+// Get returns the compiled version of the given ResourcePolicy, compiling it
+// if it isn't in the cache or has changed since it was cached.
+func (c *Cache) Get(policy *v1alpha1.ResourcePolicy) (*Policy, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.policies[policy.Name]
+	if ok && cached.uid == policy.UID && cached.resourceVersion == policy.ResourceVersion {
+		return cached.policy, nil
+	}
+	compiled, err := Compile(policy)
+	if err != nil {
+		return nil, err
+	}
+	c.policies[policy.Name] = cachedPolicy{uid: policy.UID, resourceVersion: policy.ResourceVersion, policy: compiled}
+	return compiled, nil
+}
+
+// Prune removes the ResourcePolicies that aren't in the given list from the
+// cache.
+func (c *Cache) Prune(policies []v1alpha1.ResourcePolicy) {
+	live := make(map[string]bool, len(policies))
+	for _, p := range policies {
+		live[p.Name] = true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name := range c.policies {
+		if !live[name] {
+			delete(c.policies, name)
+		}
+	}
+}
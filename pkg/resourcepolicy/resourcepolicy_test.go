@@ -0,0 +1,408 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcepolicy_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/policy/v1alpha1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/resourcepolicy"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	storageBucketGVK = schema.GroupVersionKind{
+		Group:   "storage.cnrm.cloud.google.com",
+		Version: "v1beta1",
+		Kind:    "StorageBucket",
+	}
+	computeFirewallGVK = schema.GroupVersionKind{
+		Group:   "compute.cnrm.cloud.google.com",
+		Version: "v1beta1",
+		Kind:    "ComputeFirewall",
+	}
+)
+
+func newTestPolicy(rules ...v1alpha1.ResourcePolicyRule) *v1alpha1.ResourcePolicy {
+	return &v1alpha1.ResourcePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-policy"},
+		Spec: v1alpha1.ResourcePolicySpec{
+			Match: v1alpha1.ResourcePolicyMatch{
+				Kinds: []v1alpha1.ResourcePolicyKind{
+					{Group: storageBucketGVK.Group, Kind: storageBucketGVK.Kind},
+				},
+			},
+			Rules: rules,
+		},
+	}
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name              string
+		expression        string
+		namespaceSelector *metav1.LabelSelector
+		expectedError     string
+	}{
+		{
+			name:       "valid expression",
+			expression: "object.spec.uniformBucketLevelAccess == true",
+		},
+		{
+			name:       "all variables",
+			expression: `resource.project == namespaceObject.annotations["cnrm.cloud.google.com/project-id"] && oldObject == null`,
+		},
+		{
+			name:          "syntax error",
+			expression:    "object.spec.uniformBucketLevelAccess ==",
+			expectedError: "rule 'test-rule'",
+		},
+		{
+			name:          "undeclared variable",
+			expression:    "bucket.spec.uniformBucketLevelAccess",
+			expectedError: "undeclared reference to 'bucket'",
+		},
+		{
+			name:          "non-bool expression",
+			expression:    "resource.location",
+			expectedError: "the expression must evaluate to a bool, got string",
+		},
+		{
+			name:       "invalid namespace selector",
+			expression: "true",
+			namespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Equals"}},
+			},
+			expectedError: "invalid namespace selector",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			policy := newTestPolicy(v1alpha1.ResourcePolicyRule{Name: "test-rule", Expression: tc.expression})
+			policy.Spec.Match.NamespaceSelector = tc.namespaceSelector
+			_, err := resourcepolicy.Compile(policy)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("got error '%v', want an error containing '%v'", err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	bucketPolicy := newTestPolicy(
+		v1alpha1.ResourcePolicyRule{
+			Name:       "uniform-bucket-level-access",
+			Expression: "has(object.spec.uniformBucketLevelAccess) && object.spec.uniformBucketLevelAccess",
+			Message:    "StorageBuckets must set uniformBucketLevelAccess",
+		},
+		v1alpha1.ResourcePolicyRule{
+			Name:       "allowed-locations",
+			Expression: `resource.location in namespaceObject.annotations["example.com/allowed-locations"].split(",")`,
+		},
+	)
+	firewallPolicy := newTestPolicy(
+		v1alpha1.ResourcePolicyRule{
+			Name: "no-public-ssh",
+			Expression: `!(has(object.spec.sourceRanges) && "0.0.0.0/0" in object.spec.sourceRanges &&
+				has(object.spec.allow) && object.spec.allow.exists(a, has(a.ports) && "22" in a.ports))`,
+		},
+	)
+	immutableLocationPolicy := newTestPolicy(
+		v1alpha1.ResourcePolicyRule{
+			Name:       "immutable-location",
+			Expression: "oldObject == null || object.spec.location == oldObject.spec.location",
+			Message:    "the location can't be changed",
+		},
+	)
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-namespace",
+			Annotations: map[string]string{
+				"example.com/allowed-locations": "EU,europe-west1",
+			},
+		},
+	}
+	tests := []struct {
+		name               string
+		policy             *v1alpha1.ResourcePolicy
+		input              *resourcepolicy.Input
+		expectedViolations []string
+	}{
+		{
+			name:   "compliant StorageBucket",
+			policy: bucketPolicy,
+			input: &resourcepolicy.Input{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"uniformBucketLevelAccess": true,
+					},
+				},
+				Namespace: ns,
+				Location:  "EU",
+			},
+		},
+		{
+			name:   "non-compliant StorageBucket",
+			policy: bucketPolicy,
+			input: &resourcepolicy.Input{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{},
+				},
+				Namespace: ns,
+				Location:  "US",
+			},
+			expectedViolations: []string{
+				"ResourcePolicy 'test-policy', rule 'uniform-bucket-level-access': StorageBuckets must set uniformBucketLevelAccess",
+				`ResourcePolicy 'test-policy', rule 'allowed-locations': failed expression 'resource.location in namespaceObject.annotations["example.com/allowed-locations"].split(",")'`,
+			},
+		},
+		{
+			name:   "rule that can't be evaluated",
+			policy: bucketPolicy,
+			input: &resourcepolicy.Input{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"uniformBucketLevelAccess": true,
+					},
+				},
+				Namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other-namespace"}},
+				Location:  "EU",
+			},
+			expectedViolations: []string{
+				"ResourcePolicy 'test-policy', rule 'allowed-locations': error evaluating rule: no such key: example.com/allowed-locations",
+			},
+		},
+		{
+			name:   "ComputeFirewall allowing SSH from anywhere",
+			policy: firewallPolicy,
+			input: &resourcepolicy.Input{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"sourceRanges": []interface{}{"0.0.0.0/0"},
+						"allow": []interface{}{
+							map[string]interface{}{"protocol": "icmp"},
+							map[string]interface{}{"protocol": "tcp", "ports": []interface{}{"22", "80"}},
+						},
+					},
+				},
+				Namespace: ns,
+			},
+			expectedViolations: []string{
+				`ResourcePolicy 'test-policy', rule 'no-public-ssh': failed expression '!(has(object.spec.sourceRanges) && "0.0.0.0/0" in object.spec.sourceRanges &&
+				has(object.spec.allow) && object.spec.allow.exists(a, has(a.ports) && "22" in a.ports))'`,
+			},
+		},
+		{
+			name:   "ComputeFirewall allowing SSH from an internal range",
+			policy: firewallPolicy,
+			input: &resourcepolicy.Input{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"sourceRanges": []interface{}{"10.0.0.0/8"},
+						"allow": []interface{}{
+							map[string]interface{}{"protocol": "tcp", "ports": []interface{}{"22"}},
+						},
+					},
+				},
+				Namespace: ns,
+			},
+		},
+		{
+			name:   "creation of a resource",
+			policy: immutableLocationPolicy,
+			input: &resourcepolicy.Input{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"location": "EU",
+					},
+				},
+				Namespace: ns,
+			},
+		},
+		{
+			name:   "update keeping the location",
+			policy: immutableLocationPolicy,
+			input: &resourcepolicy.Input{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"location": "EU",
+					},
+				},
+				OldObject: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"location": "EU",
+					},
+				},
+				Namespace: ns,
+			},
+		},
+		{
+			name:   "update changing the location",
+			policy: immutableLocationPolicy,
+			input: &resourcepolicy.Input{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"location": "US",
+					},
+				},
+				OldObject: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"location": "EU",
+					},
+				},
+				Namespace: ns,
+			},
+			expectedViolations: []string{
+				"ResourcePolicy 'test-policy', rule 'immutable-location': the location can't be changed",
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			policy, err := resourcepolicy.Compile(tc.policy)
+			if err != nil {
+				t.Fatalf("error compiling policy: %v", err)
+			}
+			violations := policy.Evaluate(tc.input)
+			if len(violations) != len(tc.expectedViolations) {
+				t.Fatalf("got violations %v, want %v", violations, tc.expectedViolations)
+			}
+			for i, v := range violations {
+				if v.String() != tc.expectedViolations[i] {
+					t.Errorf("got violation '%v', want '%v'", v, tc.expectedViolations[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-namespace",
+			Labels: map[string]string{"env": "prod"},
+		},
+	}
+	tests := []struct {
+		name     string
+		match    v1alpha1.ResourcePolicyMatch
+		gvk      schema.GroupVersionKind
+		expected bool
+	}{
+		{
+			name: "matching kind",
+			match: v1alpha1.ResourcePolicyMatch{
+				Kinds: []v1alpha1.ResourcePolicyKind{{Group: storageBucketGVK.Group, Kind: storageBucketGVK.Kind}},
+			},
+			gvk:      storageBucketGVK,
+			expected: true,
+		},
+		{
+			name: "matching group",
+			match: v1alpha1.ResourcePolicyMatch{
+				Kinds: []v1alpha1.ResourcePolicyKind{{Group: computeFirewallGVK.Group}},
+			},
+			gvk:      computeFirewallGVK,
+			expected: true,
+		},
+		{
+			name: "other kind",
+			match: v1alpha1.ResourcePolicyMatch{
+				Kinds: []v1alpha1.ResourcePolicyKind{{Group: storageBucketGVK.Group, Kind: storageBucketGVK.Kind}},
+			},
+			gvk:      computeFirewallGVK,
+			expected: false,
+		},
+		{
+			name: "other namespace",
+			match: v1alpha1.ResourcePolicyMatch{
+				Kinds:      []v1alpha1.ResourcePolicyKind{{Group: storageBucketGVK.Group}},
+				Namespaces: []string{"other-namespace"},
+			},
+			gvk:      storageBucketGVK,
+			expected: false,
+		},
+		{
+			name: "matching namespace selector",
+			match: v1alpha1.ResourcePolicyMatch{
+				Kinds:             []v1alpha1.ResourcePolicyKind{{Group: storageBucketGVK.Group}},
+				Namespaces:        []string{"test-namespace"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			},
+			gvk:      storageBucketGVK,
+			expected: true,
+		},
+		{
+			name: "non-matching namespace selector",
+			match: v1alpha1.ResourcePolicyMatch{
+				Kinds:             []v1alpha1.ResourcePolicyKind{{Group: storageBucketGVK.Group}},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+			},
+			gvk:      storageBucketGVK,
+			expected: false,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			policy := newTestPolicy()
+			policy.Spec.Match = tc.match
+			res, err := resourcepolicy.Matches(policy, tc.gvk, ns)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res != tc.expected {
+				t.Fatalf("got %v, want %v", res, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCache(t *testing.T) {
+	cache := resourcepolicy.NewCache()
+	policy := newTestPolicy(v1alpha1.ResourcePolicyRule{Name: "test-rule", Expression: "true"})
+	policy.UID = "test-uid"
+	policy.ResourceVersion = "1"
+	first, err := cache.Get(policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second, _ := cache.Get(policy); second != first {
+		t.Errorf("expected the cached policy to be returned for an unchanged ResourcePolicy")
+	}
+	policy.ResourceVersion = "2"
+	if third, _ := cache.Get(policy); third == first {
+		t.Errorf("expected the policy to be compiled again for a changed ResourcePolicy")
+	}
+	cache.Prune(nil)
+	if fourth, _ := cache.Get(policy); fourth == first {
+		t.Errorf("expected the policy to be compiled again after being pruned")
+	}
+}
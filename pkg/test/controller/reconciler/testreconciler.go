@@ -23,6 +23,7 @@ import (
 
 	dclcontroller "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/dcl"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/auditconfig"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/denypolicy"
 	partialpolicy "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/partialpolicy"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/policy"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/policymember"
@@ -186,6 +187,8 @@ func (r *TestReconciler) NewReconcilerForKind(kind string) reconcile.Reconciler
 		reconciler, err = policymember.NewReconciler(r.mgr, r.provider, r.smLoader, r.dclConverter, r.dclConfig)
 	case "IAMAuditConfig":
 		reconciler, err = auditconfig.NewReconciler(r.mgr, r.provider, r.smLoader, r.dclConverter, r.dclConfig, immediateReconcileRequests, resourceWatcherRoutines)
+	case "IAMDenyPolicy":
+		reconciler, err = denypolicy.NewReconciler(r.mgr, r.provider, r.smLoader, r.dclConverter, r.dclConfig, immediateReconcileRequests, resourceWatcherRoutines)
 	default:
 		crd := testcontroller.GetCRDForKind(r.t, r.mgr.GetClient(), kind)
 		reconciler, err = r.newReconcilerForCRD(crd)
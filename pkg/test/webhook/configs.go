@@ -21,7 +21,7 @@ import (
 )
 
 func GetTestCommonWebhookConfigs() ([]webhook.WebhookConfig, error) {
-	whCfgs, err := webhook.GetCommonWebhookConfigs()
+	whCfgs, err := webhook.GetCommonWebhookConfigs(nil)
 	if err != nil {
 		return nil, fmt.Errorf("error getting common wehbook configs: %v", err)
 	}
@@ -0,0 +1,129 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	iamv1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	dclmetadata "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/schema/dclschemaloader"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/servicemapping/servicemappingloader"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// allowedTargetsValidatorHandler denies the creation and the update of
+// resources targeting a project, folder or location that their namespace
+// doesn't allow through its allowed-projects, allowed-folders and
+// allowed-locations annotations. It is a security guardrail and is therefore
+// kept separate from the validating webhooks whose mode can be relaxed.
+type allowedTargetsValidatorHandler struct {
+	client                client.Client
+	smLoader              *servicemappingloader.ServiceMappingLoader
+	dclSchemaLoader       dclschemaloader.DCLSchemaLoader
+	serviceMetadataLoader dclmetadata.ServiceMetadataLoader
+}
+
+func NewAllowedTargetsValidatorHandler(smLoader *servicemappingloader.ServiceMappingLoader, dclSchemaLoader dclschemaloader.DCLSchemaLoader, serviceMetadataLoader dclmetadata.ServiceMetadataLoader) *allowedTargetsValidatorHandler {
+	return &allowedTargetsValidatorHandler{
+		smLoader:              smLoader,
+		dclSchemaLoader:       dclSchemaLoader,
+		serviceMetadataLoader: serviceMetadataLoader,
+	}
+}
+
+// allowedTargetsValidatorHandler implements inject.Client.
+var _ inject.Client = &allowedTargetsValidatorHandler{}
+
+// InjectClient injects the client into the allowedTargetsValidatorHandler
+func (a *allowedTargetsValidatorHandler) InjectClient(c client.Client) error {
+	a.client = c
+	return nil
+}
+
+func (a *allowedTargetsValidatorHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	// The controllers validate the resources again once their references are
+	// resolved, so their own updates, e.g. of finalizers, don't need to be
+	// validated.
+	if regexp.MustCompile(ControllerManagerServiceAccountRegex).MatchString(req.AdmissionRequest.UserInfo.Username) {
+		return admission.ValidationResponse(true, "ignore non-user requests")
+	}
+	deserializer := codecs.UniversalDeserializer()
+	obj := &unstructured.Unstructured{}
+	if _, _, err := deserializer.Decode(req.AdmissionRequest.Object.Raw, nil, obj); err != nil {
+		glog.Error(err)
+		return admission.Errored(http.StatusBadRequest,
+			fmt.Errorf("error decoding object: %v", err))
+	}
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return allowedResponse
+	}
+	ns := &corev1.Namespace{}
+	if err := a.client.Get(ctx, apimachinerytypes.NamespacedName{Name: obj.GetNamespace()}, ns); err != nil {
+		return admission.Errored(http.StatusInternalServerError,
+			fmt.Errorf("error getting Namespace %v: %v", obj.GetNamespace(), err))
+	}
+	return validateResourceAllowedInNamespace(obj, ns, a.smLoader, a.dclSchemaLoader, a.serviceMetadataLoader)
+}
+
+func validateResourceAllowedInNamespace(obj *unstructured.Unstructured, ns *corev1.Namespace, smLoader *servicemappingloader.ServiceMappingLoader,
+	dclSchemaLoader dclschemaloader.DCLSchemaLoader, serviceMetadataLoader dclmetadata.ServiceMetadataLoader) admission.Response {
+	if !k8s.HasAllowedTargetsAnnotation(ns) {
+		return allowedResponse
+	}
+	spec, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest,
+			fmt.Errorf("the type of spec field is not map[string]interface{}"))
+	}
+	if iamv1beta1.IsHandwrittenIAM(obj.GroupVersionKind()) {
+		return validateIAMResourceReferenceAllowedInNamespace(spec, ns)
+	}
+	containers, hierarchicalRefs, err := containersAndHierarchicalRefsOf(obj, smLoader, dclSchemaLoader, serviceMetadataLoader)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if err := k8s.ValidateResourceAllowedInNamespace(obj, spec, ns, hierarchicalRefs, containers); err != nil {
+		return admission.Errored(http.StatusForbidden, err)
+	}
+	return allowedResponse
+}
+
+// validateIAMResourceReferenceAllowedInNamespace denies IAM resources whose
+// external resource reference targets a project, folder or location that their
+// namespace doesn't allow. References by name can only be resolved by the
+// controllers, which validate them before applying the IAM resources.
+func validateIAMResourceReferenceAllowedInNamespace(spec map[string]interface{}, ns *corev1.Namespace) admission.Response {
+	kind, _, _ := unstructured.NestedString(spec, "resourceRef", "kind")
+	external, _, _ := unstructured.NestedString(spec, "resourceRef", "external")
+	if external == "" {
+		return allowedResponse
+	}
+	if err := k8s.ValidateResourceIDAllowedInNamespace(ns, kind, external); err != nil {
+		return admission.Errored(http.StatusForbidden, err)
+	}
+	return allowedResponse
+}
@@ -0,0 +1,224 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+
+	dclmetadata "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/schema/dclschemaloader"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	testservicemappingloader "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/test/servicemappingloader"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestValidateResourceAllowedInNamespace(t *testing.T) {
+	restrictedNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				k8s.AllowedProjectsAnnotation:  "allowed-project, other-project",
+				k8s.AllowedLocationsAnnotation: "us-central1",
+			},
+			Name: "namespace-name",
+		},
+	}
+	tests := []struct {
+		name   string
+		obj    *unstructured.Unstructured
+		ns     *corev1.Namespace
+		denied bool
+	}{
+		{
+			name: "allow resource in a namespace without restrictions",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1",
+					"kind":       "PubSubSchema",
+					"spec": map[string]interface{}{
+						"projectRef": map[string]interface{}{
+							"external": "forbidden-project",
+						},
+					},
+				},
+			},
+			ns: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "namespace-name"},
+			},
+		},
+		{
+			name: "allow resource if its reference targets a project allowed by the namespace",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1",
+					"kind":       "PubSubSchema",
+					"spec": map[string]interface{}{
+						"projectRef": map[string]interface{}{
+							"external": "projects/allowed-project",
+						},
+					},
+				},
+			},
+			ns: restrictedNamespace,
+		},
+		{
+			name: "deny resource if its reference targets a project not allowed by the namespace",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1",
+					"kind":       "PubSubSchema",
+					"spec": map[string]interface{}{
+						"projectRef": map[string]interface{}{
+							"external": "forbidden-project",
+						},
+					},
+				},
+			},
+			ns:     restrictedNamespace,
+			denied: true,
+		},
+		{
+			name: "deny resource if its container annotation targets a project not allowed by the namespace",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "storage.cnrm.cloud.google.com/v1beta1",
+					"kind":       "StorageBucket",
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							k8s.ProjectIDAnnotation: "forbidden-project",
+						},
+					},
+					"spec": map[string]interface{}{
+						"location": "us-central1",
+					},
+				},
+			},
+			ns:     restrictedNamespace,
+			denied: true,
+		},
+		{
+			name: "deny resource if its location is not allowed by the namespace",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "storage.cnrm.cloud.google.com/v1beta1",
+					"kind":       "StorageBucket",
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							k8s.ProjectIDAnnotation: "allowed-project",
+						},
+					},
+					"spec": map[string]interface{}{
+						"location": "europe-west1",
+					},
+				},
+			},
+			ns:     restrictedNamespace,
+			denied: true,
+		},
+		{
+			name: "allow IAM resource if its reference targets a project allowed by the namespace",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "iam.cnrm.cloud.google.com/v1beta1",
+					"kind":       "IAMPolicyMember",
+					"spec": map[string]interface{}{
+						"member": "user:someone@example.com",
+						"role":   "roles/viewer",
+						"resourceRef": map[string]interface{}{
+							"kind":     "Project",
+							"external": "projects/allowed-project",
+						},
+					},
+				},
+			},
+			ns: restrictedNamespace,
+		},
+		{
+			name: "deny IAM resource if its reference targets a project not allowed by the namespace",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "iam.cnrm.cloud.google.com/v1beta1",
+					"kind":       "IAMPolicyMember",
+					"spec": map[string]interface{}{
+						"member": "user:someone@example.com",
+						"role":   "roles/owner",
+						"resourceRef": map[string]interface{}{
+							"kind":     "Project",
+							"external": "projects/forbidden-project",
+						},
+					},
+				},
+			},
+			ns:     restrictedNamespace,
+			denied: true,
+		},
+		{
+			name: "deny IAM resource if its reference targets a resource in a project not allowed by the namespace",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "iam.cnrm.cloud.google.com/v1beta1",
+					"kind":       "IAMPolicy",
+					"spec": map[string]interface{}{
+						"resourceRef": map[string]interface{}{
+							"kind":     "PubSubTopic",
+							"external": "projects/forbidden-project/topics/topic",
+						},
+					},
+				},
+			},
+			ns:     restrictedNamespace,
+			denied: true,
+		},
+		{
+			name: "allow IAM resource referencing a resource by name",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "iam.cnrm.cloud.google.com/v1beta1",
+					"kind":       "IAMPolicyMember",
+					"spec": map[string]interface{}{
+						"member": "user:someone@example.com",
+						"role":   "roles/owner",
+						"resourceRef": map[string]interface{}{
+							"kind": "Project",
+							"name": "project",
+						},
+					},
+				},
+			},
+			ns: restrictedNamespace,
+		},
+	}
+	smLoader := testservicemappingloader.New(t)
+	dclSchemaLoader, err := dclschemaloader.New()
+	if err != nil {
+		t.Fatalf("error creating a DCL schema loader: %v", err)
+	}
+	serviceMetadataLoader := dclmetadata.New()
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			response := validateResourceAllowedInNamespace(tc.obj, tc.ns, smLoader, dclSchemaLoader, serviceMetadataLoader)
+			if tc.denied && response.Allowed {
+				t.Fatalf("expected request to be denied, but was allowed. Response:\n%v", response)
+			}
+			if !tc.denied && !response.Allowed {
+				t.Fatalf("request was unexpectedly denied. Response:\n%v", response)
+			}
+		})
+	}
+}
@@ -52,5 +52,8 @@ func (a *genericDefaulter) Handle(ctx context.Context, req admission.Request) ad
 	if err := k8s.ValidateOrDefaultStateIntoSpecAnnotation(newObj); err != nil {
 		return admission.Errored(http.StatusBadRequest, fmt.Errorf("error validating or defaulting '%v' annotation: %v", k8s.StateIntoSpecAnnotation, err))
 	}
+	if err := k8s.ValidateReconcileHistorySizeAnnotation(newObj); err != nil {
+		return admission.Errored(http.StatusBadRequest, fmt.Errorf("error validating '%v' annotation: %v", k8s.ReconcileHistorySizeAnnotation, err))
+	}
 	return constructPatchResponse(obj, newObj)
 }
@@ -0,0 +1,316 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/rolecatalog"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/util/slice"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var (
+	iamCustomRoleGVK = schema.GroupVersionKind{
+		Group:   v1beta1.SchemeGroupVersion.Group,
+		Version: v1beta1.SchemeGroupVersion.Version,
+		Kind:    "IAMCustomRole",
+	}
+
+	emailRegex                    = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	workloadIdentityMemberRegex   = regexp.MustCompile(`^([a-z0-9.-]+:)?[a-z0-9-]+\.svc\.id\.goog\[[^/\[\]]+/[^/\[\]]+\]$`)
+	domainRegex                   = regexp.MustCompile(`^(?i)([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+	projectIDRegex                = regexp.MustCompile(`^([a-z0-9.-]+:)?[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+	deletedMemberUIDSuffixRegex   = regexp.MustCompile(`\?uid=[0-9]+$`)
+	principalIdentifierRegex      = regexp.MustCompile(`^principal(Set)?://\S+$`)
+	supportedPrincipalTypesString = "allUsers, allAuthenticatedUsers, user:, serviceAccount:, group:, domain:, " +
+		"projectOwner:, projectEditor:, projectViewer:, deleted:, principal:// and principalSet://"
+)
+
+// validateRolesAndMembers validates the roles and the members granted by the given IAM object if
+// its namespace has opted in to IAM role and member validation. Predefined roles are validated
+// against the bundled role catalog, custom roles against the IAMCustomRoles known to the cluster,
+// and members against the syntax of their principal type. On updates, only the roles and the
+// members which were not already granted by the previous version of the object are validated, so
+// that objects admitted before the namespace opted in can still be updated.
+func (a *iamValidatorHandler) validateRolesAndMembers(ctx context.Context, req admission.Request, obj client.Object) admission.Response {
+	if regexp.MustCompile(ControllerManagerServiceAccountRegex).MatchString(req.AdmissionRequest.UserInfo.Username) {
+		return allowedResponse
+	}
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return allowedResponse
+	}
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		namespace = req.Namespace
+	}
+	enabled, err := a.isRoleAndMemberValidationEnabled(ctx, namespace)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if !enabled {
+		return allowedResponse
+	}
+	roles, members := rolesAndMembersOf(obj)
+	if req.AdmissionRequest.Operation == admissionv1.Update {
+		oldRoles, oldMembers, err := oldRolesAndMembersOf(req, obj)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		roles = removeRoles(roles, oldRoles)
+		members = removeMembers(members, oldMembers)
+	}
+	for _, member := range members {
+		if err := validateMemberSyntax(string(member)); err != nil {
+			return admission.Errored(http.StatusForbidden, err)
+		}
+	}
+	var customRoles map[string]bool
+	for _, role := range roles {
+		if rolecatalog.IsPredefinedRole(role) {
+			if err := validatePredefinedRole(role, rolecatalog.Default()); err != nil {
+				return admission.Errored(http.StatusForbidden, err)
+			}
+			continue
+		}
+		if customRoles == nil {
+			if customRoles, err = a.listCustomRoles(ctx); err != nil {
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+		}
+		if !customRoles[role] {
+			return admission.Errored(http.StatusForbidden,
+				fmt.Errorf("custom role '%v' is not managed by any IAMCustomRole in the cluster", role))
+		}
+	}
+	return allowedResponse
+}
+
+func (a *iamValidatorHandler) isRoleAndMemberValidationEnabled(ctx context.Context, namespace string) (bool, error) {
+	ns := &corev1.Namespace{}
+	if err := a.client.Get(ctx, apimachinerytypes.NamespacedName{Name: namespace}, ns); err != nil {
+		return false, fmt.Errorf("error getting Namespace %v: %v", namespace, err)
+	}
+	val, ok := k8s.GetAnnotation(k8s.IAMRoleAndMemberValidationAnnotation, ns)
+	if !ok {
+		return false, nil
+	}
+	if !slice.StringSliceContains(k8s.IAMRoleAndMemberValidationAnnotationValues, val) {
+		return false, fmt.Errorf("invalid value '%v' for annotation '%v' on Namespace %v, must be one of {%v}",
+			val, k8s.IAMRoleAndMemberValidationAnnotation, namespace, strings.Join(k8s.IAMRoleAndMemberValidationAnnotationValues, ", "))
+	}
+	return val == k8s.IAMRoleAndMemberValidationStrict, nil
+}
+
+// listCustomRoles returns the names, e.g. 'projects/my-project/roles/myRole', of the custom roles
+// managed by the IAMCustomRoles in all namespaces.
+func (a *iamValidatorHandler) listCustomRoles(ctx context.Context) (map[string]bool, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(iamCustomRoleGVK.GroupVersion().WithKind(iamCustomRoleGVK.Kind + "List"))
+	if err := a.client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("error listing IAMCustomRoles: %w", err)
+	}
+	res := make(map[string]bool)
+	for _, customRole := range list.Items {
+		if name := customRoleName(&customRole); name != "" {
+			res[name] = true
+		}
+	}
+	return res, nil
+}
+
+// customRoleName returns the name of the custom role managed by the given IAMCustomRole, or an
+// empty string if it can't be determined yet.
+func customRoleName(customRole *unstructured.Unstructured) string {
+	if name, _, _ := unstructured.NestedString(customRole.Object, "status", "name"); name != "" {
+		return name
+	}
+	roleID, _, _ := unstructured.NestedString(customRole.Object, "spec", "resourceID")
+	if roleID == "" {
+		roleID = customRole.GetName()
+	}
+	if projectID, ok := k8s.GetAnnotation(k8s.ProjectIDAnnotation, customRole); ok {
+		return fmt.Sprintf("projects/%v/roles/%v", projectID, roleID)
+	}
+	if orgID, ok := k8s.GetAnnotation(k8s.OrgIDAnnotation, customRole); ok {
+		return fmt.Sprintf("organizations/%v/roles/%v", orgID, roleID)
+	}
+	return ""
+}
+
+func validatePredefinedRole(role string, catalog *rolecatalog.Catalog) error {
+	if catalog.HasRole(role) {
+		return nil
+	}
+	// Roles of a service the catalog doesn't cover can only be rejected if the catalog lists all the
+	// services, in which case the service doesn't exist.
+	if !catalog.CoversService(role) && !catalog.IsComplete() {
+		return nil
+	}
+	if suggestion := catalog.Suggest(role); suggestion != "" {
+		return fmt.Errorf("unknown predefined role '%v', did you mean '%v'?", role, suggestion)
+	}
+	return fmt.Errorf("unknown predefined role '%v'", role)
+}
+
+// validateMemberSyntax validates the syntax of the given member according to its principal type,
+// e.g. that the member 'user:foo@example.com' is an email address.
+func validateMemberSyntax(member string) error {
+	if member == "allUsers" || member == "allAuthenticatedUsers" {
+		return nil
+	}
+	if principalIdentifierRegex.MatchString(member) {
+		return nil
+	}
+	principalType, id, ok := strings.Cut(member, ":")
+	if !ok || id == "" {
+		return fmt.Errorf("invalid member '%v': members must be prefixed by their principal type, one of %v",
+			member, supportedPrincipalTypesString)
+	}
+	if principalType == "deleted" {
+		if !deletedMemberUIDSuffixRegex.MatchString(id) {
+			return fmt.Errorf("invalid member '%v': deleted members must be suffixed by '?uid=<numeric ID>'", member)
+		}
+		deletedType, deletedID, _ := strings.Cut(deletedMemberUIDSuffixRegex.ReplaceAllString(id, ""), ":")
+		if deletedType != "user" && deletedType != "serviceAccount" && deletedType != "group" {
+			return fmt.Errorf("invalid member '%v': only deleted users, service accounts and groups can be members", member)
+		}
+		return validateMemberSyntax(fmt.Sprintf("%v:%v", deletedType, deletedID))
+	}
+	switch principalType {
+	case "user", "group":
+		if !emailRegex.MatchString(id) {
+			return fmt.Errorf("invalid member '%v': '%v' is not an email address", member, id)
+		}
+	case "serviceAccount":
+		if !emailRegex.MatchString(id) && !workloadIdentityMemberRegex.MatchString(id) {
+			return fmt.Errorf("invalid member '%v': '%v' is neither an email address nor a Workload Identity "+
+				"of the form 'PROJECT_ID.svc.id.goog[NAMESPACE/NAME]'", member, id)
+		}
+	case "domain":
+		if !domainRegex.MatchString(id) {
+			return fmt.Errorf("invalid member '%v': '%v' is not a domain name", member, id)
+		}
+	case "projectOwner", "projectEditor", "projectViewer":
+		if !projectIDRegex.MatchString(id) {
+			return fmt.Errorf("invalid member '%v': '%v' is not a project ID", member, id)
+		}
+	default:
+		return fmt.Errorf("invalid member '%v': unknown principal type '%v', must be one of %v",
+			member, principalType, supportedPrincipalTypesString)
+	}
+	return nil
+}
+
+// rolesAndMembersOf returns the roles and the members granted by the given IAM object. Members
+// given through memberFrom are resolved by the controllers and are therefore not returned.
+func rolesAndMembersOf(obj client.Object) (roles []string, members []v1beta1.Member) {
+	switch o := obj.(type) {
+	case *v1beta1.IAMPolicy:
+		for _, b := range o.Spec.Bindings {
+			roles = append(roles, b.Role)
+			members = append(members, b.Members...)
+		}
+		for _, ac := range o.Spec.AuditConfigs {
+			for _, lc := range ac.AuditLogConfigs {
+				members = append(members, lc.ExemptedMembers...)
+			}
+		}
+	case *v1beta1.IAMPartialPolicy:
+		for _, b := range o.Spec.Bindings {
+			roles = append(roles, b.Role)
+			for _, m := range b.Members {
+				if m.Member != "" {
+					members = append(members, m.Member)
+				}
+			}
+		}
+	case *v1beta1.IAMPolicyMember:
+		roles = append(roles, o.Spec.Role)
+		if o.Spec.Member != "" {
+			members = append(members, o.Spec.Member)
+		}
+	case *v1beta1.IAMAuditConfig:
+		for _, lc := range o.Spec.AuditLogConfigs {
+			members = append(members, lc.ExemptedMembers...)
+		}
+	}
+	return roles, members
+}
+
+// oldRolesAndMembersOf returns the roles and the members granted by the previous version of the
+// given IAM object in an update request.
+func oldRolesAndMembersOf(req admission.Request, obj client.Object) ([]string, []v1beta1.Member, error) {
+	u := &unstructured.Unstructured{}
+	if _, _, err := codecs.UniversalDeserializer().Decode(req.AdmissionRequest.OldObject.Raw, nil, u); err != nil {
+		return nil, nil, fmt.Errorf("error decoding old object: %v", err)
+	}
+	var oldObj client.Object
+	var err error
+	switch obj.(type) {
+	case *v1beta1.IAMPolicy:
+		oldObj, err = toIAMPolicy(u)
+	case *v1beta1.IAMPartialPolicy:
+		oldObj, err = toIAMPartialPolicy(u)
+	case *v1beta1.IAMPolicyMember:
+		oldObj, err = toIAMPolicyMember(u)
+	case *v1beta1.IAMAuditConfig:
+		oldObj, err = toIAMAuditConfig(u)
+	default:
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	oldRoles, oldMembers := rolesAndMembersOf(oldObj)
+	return oldRoles, oldMembers, nil
+}
+
+func removeRoles(roles, rolesToRemove []string) []string {
+	res := make([]string, 0, len(roles))
+	for _, r := range roles {
+		if !slice.StringSliceContains(rolesToRemove, r) {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+func removeMembers(members, membersToRemove []v1beta1.Member) []v1beta1.Member {
+	toRemove := make(map[v1beta1.Member]bool, len(membersToRemove))
+	for _, m := range membersToRemove {
+		toRemove[m] = true
+	}
+	res := make([]v1beta1.Member, 0, len(members))
+	for _, m := range members {
+		if !toRemove[m] {
+			res = append(res, m)
+		}
+	}
+	return res
+}
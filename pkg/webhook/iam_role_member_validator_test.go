@@ -0,0 +1,383 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/rolecatalog"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/test/mocks"
+)
+
+func TestValidateMemberSyntax(t *testing.T) {
+	tests := []struct {
+		member   string
+		hasError bool
+	}{
+		{member: "allUsers"},
+		{member: "allAuthenticatedUsers"},
+		{member: "user:foo@example.com"},
+		{member: "group:foo-group@example.com"},
+		{member: "serviceAccount:foo@my-project.iam.gserviceaccount.com"},
+		{member: "serviceAccount:my-project.svc.id.goog[my-namespace/my-ksa]"},
+		{member: "domain:example.com"},
+		{member: "projectOwner:my-project"},
+		{member: "deleted:user:foo@example.com?uid=123456789012345678901"},
+		{member: "principal://iam.googleapis.com/locations/global/workforcePools/my-pool/subject/foo"},
+		{member: "principalSet://iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/my-pool/*"},
+		{member: "foo@example.com", hasError: true},
+		{member: "user:", hasError: true},
+		{member: "user:foo", hasError: true},
+		{member: "usr:foo@example.com", hasError: true},
+		{member: "serviceAccount:my-project.svc.id.goog[my-ksa]", hasError: true},
+		{member: "domain:example", hasError: true},
+		{member: "projectViewer:My_Project", hasError: true},
+		{member: "deleted:user:foo@example.com", hasError: true},
+		{member: "deleted:domain:example.com?uid=123", hasError: true},
+	}
+	for _, tc := range tests {
+		err := validateMemberSyntax(tc.member)
+		if tc.hasError && err == nil {
+			t.Errorf("member '%v': expected an error, got none", tc.member)
+		}
+		if !tc.hasError && err != nil {
+			t.Errorf("member '%v': unexpected error: %v", tc.member, err)
+		}
+	}
+}
+
+func TestValidatePredefinedRole(t *testing.T) {
+	catalog, err := rolecatalog.Parse("roles/owner\nroles/storage.admin\nroles/storage.objectViewer\nroles/pubsub.admin\n", "basic\nstorage\n")
+	if err != nil {
+		t.Fatalf("error parsing role catalog: %v", err)
+	}
+	tests := []struct {
+		role          string
+		expectedError string
+	}{
+		{role: "roles/owner"},
+		{role: "roles/storage.objectViewer"},
+		{
+			role:          "roles/storage.objectViewr",
+			expectedError: "unknown predefined role 'roles/storage.objectViewr', did you mean 'roles/storage.objectViewer'?",
+		},
+		{
+			role:          "roles/storage.bucketCreator",
+			expectedError: "unknown predefined role 'roles/storage.bucketCreator'",
+		},
+		{
+			role:          "roles/ownr",
+			expectedError: "unknown predefined role 'roles/ownr', did you mean 'roles/owner'?",
+		},
+		// The catalog doesn't fully cover the pubsub service.
+		{role: "roles/pubsub.publisher"},
+	}
+	for _, tc := range tests {
+		err := validatePredefinedRole(tc.role, catalog)
+		if tc.expectedError == "" {
+			if err != nil {
+				t.Errorf("role '%v': unexpected error: %v", tc.role, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.expectedError {
+			t.Errorf("role '%v': got error '%v', want '%v'", tc.role, err, tc.expectedError)
+		}
+	}
+}
+
+func TestValidatePredefinedRoleWithCompleteCatalog(t *testing.T) {
+	catalog, err := rolecatalog.Parse("roles/owner\nroles/storage.admin\nroles/storage.objectViewer\nroles/pubsub.admin\n", "basic\nstorage\npubsub\n")
+	if err != nil {
+		t.Fatalf("error parsing role catalog: %v", err)
+	}
+	tests := []struct {
+		role          string
+		expectedError string
+	}{
+		{role: "roles/storage.admin"},
+		{
+			role:          "roles/storgae.admin",
+			expectedError: "unknown predefined role 'roles/storgae.admin', did you mean 'roles/storage.admin'?",
+		},
+		{
+			role:          "roles/pubsub.publisher",
+			expectedError: "unknown predefined role 'roles/pubsub.publisher'",
+		},
+		{
+			role:          "roles/spanner.databaseUser",
+			expectedError: "unknown predefined role 'roles/spanner.databaseUser'",
+		},
+	}
+	for _, tc := range tests {
+		err := validatePredefinedRole(tc.role, catalog)
+		if tc.expectedError == "" {
+			if err != nil {
+				t.Errorf("role '%v': unexpected error: %v", tc.role, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.expectedError {
+			t.Errorf("role '%v': got error '%v', want '%v'", tc.role, err, tc.expectedError)
+		}
+	}
+}
+
+func TestValidateRolesAndMembers(t *testing.T) {
+	if err := v1beta1.SchemeBuilder.AddToScheme(clientgoscheme.Scheme); err != nil {
+		t.Fatalf("error adding IAM types to the scheme: %v", err)
+	}
+	ctx := context.Background()
+	kubeClient := mocks.Manager{}.GetClient()
+	namespaces := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "strict-namespace",
+				Annotations: map[string]string{
+					k8s.IAMRoleAndMemberValidationAnnotation: k8s.IAMRoleAndMemberValidationStrict,
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-namespace"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "invalid-namespace",
+				Annotations: map[string]string{
+					k8s.IAMRoleAndMemberValidationAnnotation: "invalid",
+				},
+			},
+		},
+	}
+	for _, ns := range namespaces {
+		if err := kubeClient.Create(ctx, ns); err != nil {
+			t.Fatalf("error creating Namespace: %v", err)
+		}
+	}
+	customRole := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "iam.cnrm.cloud.google.com/v1beta1",
+			"kind":       "IAMCustomRole",
+			"metadata": map[string]interface{}{
+				"namespace": "other-namespace",
+				"name":      "my-custom-role",
+				"annotations": map[string]interface{}{
+					k8s.ProjectIDAnnotation: "my-project",
+				},
+			},
+			"spec": map[string]interface{}{
+				"title":       "My custom role",
+				"permissions": []interface{}{"storage.buckets.get"},
+			},
+		},
+	}
+	handler := &iamValidatorHandler{
+		client: &customRoleListingClient{
+			Client:      kubeClient,
+			customRoles: []unstructured.Unstructured{customRole},
+		},
+	}
+	newPolicyMember := func(namespace, role string, member v1beta1.Member) *v1beta1.IAMPolicyMember {
+		policyMember := newTestPolicyMember()
+		policyMember.SetNamespace(namespace)
+		policyMember.Spec.Role = role
+		policyMember.Spec.Member = member
+		return policyMember
+	}
+	toRaw := func(obj client.Object) []byte {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatalf("error marshaling object: %v", err)
+		}
+		return raw
+	}
+	partialPolicyTypeMeta := metav1.TypeMeta{
+		Kind:       v1beta1.IAMPartialPolicyGVK.Kind,
+		APIVersion: v1beta1.IAMPartialPolicyGVK.GroupVersion().String(),
+	}
+	deletionTimestamp := metav1.Now()
+	deletedPolicyMember := newPolicyMember("strict-namespace", "roles/storage.objectViewr", "usr:foo@example.com")
+	deletedPolicyMember.SetDeletionTimestamp(&deletionTimestamp)
+	tests := []struct {
+		name                 string
+		obj                  client.Object
+		oldObj               client.Object
+		username             string
+		expectedAllowedValue bool
+	}{
+		{
+			name:                 "namespace without validation",
+			obj:                  newPolicyMember("default-namespace", "roles/storage.objectViewr", "usr:foo@example.com"),
+			expectedAllowedValue: true,
+		},
+		{
+			name:                 "invalid annotation value",
+			obj:                  newPolicyMember("invalid-namespace", "roles/storage.objectViewer", "user:foo@example.com"),
+			expectedAllowedValue: false,
+		},
+		{
+			name:                 "valid predefined role and member",
+			obj:                  newPolicyMember("strict-namespace", "roles/storage.objectViewer", "user:foo@example.com"),
+			expectedAllowedValue: true,
+		},
+		{
+			name:                 "invalid predefined role",
+			obj:                  newPolicyMember("strict-namespace", "roles/ownr", "user:foo@example.com"),
+			expectedAllowedValue: false,
+		},
+		{
+			name:                 "invalid member",
+			obj:                  newPolicyMember("strict-namespace", "roles/storage.objectViewer", "usr:foo@example.com"),
+			expectedAllowedValue: false,
+		},
+		{
+			name:                 "custom role managed by an IAMCustomRole",
+			obj:                  newPolicyMember("strict-namespace", "projects/my-project/roles/my-custom-role", "user:foo@example.com"),
+			expectedAllowedValue: true,
+		},
+		{
+			name:                 "custom role not managed by any IAMCustomRole",
+			obj:                  newPolicyMember("strict-namespace", "projects/my-project/roles/other-custom-role", "user:foo@example.com"),
+			expectedAllowedValue: false,
+		},
+		{
+			name: "IAMPartialPolicy with an invalid member",
+			obj: &v1beta1.IAMPartialPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "strict-namespace"},
+				Spec: v1beta1.IAMPartialPolicySpec{
+					Bindings: []v1beta1.IAMPartialPolicyBinding{
+						{
+							Role: "roles/storage.objectViewer",
+							Members: []v1beta1.IAMPartialPolicyMember{
+								{Member: "user:foo@example.com"},
+								{Member: "group:foo"},
+							},
+						},
+					},
+				},
+			},
+			expectedAllowedValue: false,
+		},
+		{
+			name:                 "object being deleted",
+			obj:                  deletedPolicyMember,
+			expectedAllowedValue: true,
+		},
+		{
+			name:                 "request from the controller manager",
+			obj:                  newPolicyMember("strict-namespace", "roles/storage.objectViewr", "usr:foo@example.com"),
+			username:             "system:serviceaccount:cnrm-system:cnrm-controller-manager",
+			expectedAllowedValue: true,
+		},
+		{
+			name:                 "update not changing an invalid role and member",
+			obj:                  newPolicyMember("strict-namespace", "roles/storage.objectViewr", "usr:foo@example.com"),
+			oldObj:               newPolicyMember("strict-namespace", "roles/storage.objectViewr", "usr:foo@example.com"),
+			expectedAllowedValue: true,
+		},
+		{
+			name:                 "update changing the member to an invalid one",
+			obj:                  newPolicyMember("strict-namespace", "roles/storage.objectViewr", "usr:bar@example.com"),
+			oldObj:               newPolicyMember("strict-namespace", "roles/storage.objectViewr", "usr:foo@example.com"),
+			expectedAllowedValue: false,
+		},
+		{
+			name:                 "update changing the role to an invalid one",
+			obj:                  newPolicyMember("strict-namespace", "roles/viewr", "user:foo@example.com"),
+			oldObj:               newPolicyMember("strict-namespace", "roles/viewer", "user:foo@example.com"),
+			expectedAllowedValue: false,
+		},
+		{
+			name: "update adding a valid member to a binding with an invalid member",
+			obj: &v1beta1.IAMPartialPolicy{
+				TypeMeta:   partialPolicyTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{Namespace: "strict-namespace"},
+				Spec: v1beta1.IAMPartialPolicySpec{
+					Bindings: []v1beta1.IAMPartialPolicyBinding{
+						{
+							Role: "roles/storage.objectViewer",
+							Members: []v1beta1.IAMPartialPolicyMember{
+								{Member: "group:foo"},
+								{Member: "user:foo@example.com"},
+							},
+						},
+					},
+				},
+			},
+			oldObj: &v1beta1.IAMPartialPolicy{
+				TypeMeta:   partialPolicyTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{Namespace: "strict-namespace"},
+				Spec: v1beta1.IAMPartialPolicySpec{
+					Bindings: []v1beta1.IAMPartialPolicyBinding{
+						{
+							Role: "roles/storage.objectViewer",
+							Members: []v1beta1.IAMPartialPolicyMember{
+								{Member: "group:foo"},
+							},
+						},
+					},
+				},
+			},
+			expectedAllowedValue: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					UserInfo:  authenticationv1.UserInfo{Username: tc.username},
+				},
+			}
+			if tc.oldObj != nil {
+				req.AdmissionRequest.Operation = admissionv1.Update
+				req.AdmissionRequest.OldObject.Raw = toRaw(tc.oldObj)
+			}
+			response := handler.validateRolesAndMembers(ctx, req, tc.obj)
+			if response.Allowed != tc.expectedAllowedValue {
+				t.Fatalf("unexpected value for Allowed: got '%v', want '%v' (%v)", response.Allowed, tc.expectedAllowedValue, response.Result)
+			}
+		})
+	}
+}
+
+// customRoleListingClient serves the given IAMCustomRoles, which the mock client can't list as
+// unstructured objects.
+type customRoleListingClient struct {
+	client.Client
+	customRoles []unstructured.Unstructured
+}
+
+func (c *customRoleListingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if u, ok := list.(*unstructured.UnstructuredList); ok && u.GetKind() == "IAMCustomRoleList" {
+		u.Items = c.customRoles
+		return nil
+	}
+	return c.Client.List(ctx, list, opts...)
+}
@@ -18,10 +18,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"regexp"
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/core/v1alpha1"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
 	kcciamclient "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/iamclient"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/iam/managementconflict"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/extension"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/schema/dclschemaloader"
@@ -30,16 +32,31 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/util"
 
 	"github.com/golang/glog"
+	tfschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta"
 	"github.com/nasa9084/go-openapi"
+	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+// denyPolicyPermissionRegex matches the permissions of IAM deny policies, which are in the
+// format '{service-fqdn}/{resource}.{verb}' (e.g. 'iam.googleapis.com/roles.delete'), and in
+// which any segment after the service FQDN may be a wildcard (e.g. 'iam.googleapis.com/*').
+var denyPolicyPermissionRegex = regexp.MustCompile(
+	`^[a-z0-9-]+(\.[a-z0-9-]+)*\.googleapis\.com/(\*|(\*|[a-zA-Z][a-zA-Z0-9]*)(\.(\*|[a-zA-Z][a-zA-Z0-9]*))+)$`)
+
 type iamValidatorHandler struct {
+	client                client.Client
 	smLoader              *servicemappingloader.ServiceMappingLoader
 	serviceMetadataLoader metadata.ServiceMetadataLoader
 	schemaLoader          dclschemaloader.DCLSchemaLoader
+	// tfProvider only holds the schemas of the TF resources, which are needed to resolve the
+	// references to them.
+	tfProvider *tfschema.Provider
 }
 
 func NewIAMValidatorHandler(smLoader *servicemappingloader.ServiceMappingLoader,
@@ -49,9 +66,19 @@ func NewIAMValidatorHandler(smLoader *servicemappingloader.ServiceMappingLoader,
 		smLoader:              smLoader,
 		serviceMetadataLoader: serviceMetadataLoader,
 		schemaLoader:          schemaLoader,
+		tfProvider:            &tfschema.Provider{ResourcesMap: google.ResourceMap()},
 	}
 }
 
+// iamValidatorHandler implements inject.Client.
+var _ inject.Client = &iamValidatorHandler{}
+
+// InjectClient injects the client into the iamValidatorHandler
+func (a *iamValidatorHandler) InjectClient(c client.Client) error {
+	a.client = c
+	return nil
+}
+
 func (a *iamValidatorHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
 	deserializer := codecs.UniversalDeserializer()
 	obj := &unstructured.Unstructured{}
@@ -68,7 +95,13 @@ func (a *iamValidatorHandler) Handle(ctx context.Context, req admission.Request)
 		}
 		refResourceGVK := policy.Spec.ResourceReference.GroupVersionKind()
 		isDCLResource := metadata.IsDCLBasedResourceKind(refResourceGVK, a.serviceMetadataLoader)
-		return a.validateIAMPolicy(policy, isDCLResource)
+		if resp := a.validateIAMPolicy(policy, isDCLResource); !resp.Allowed {
+			return resp
+		}
+		if resp := a.validateRolesAndMembers(ctx, req, policy); !resp.Allowed {
+			return resp
+		}
+		return a.validateNoManagementConflict(ctx, req, policy)
 
 	case isIAMPartialPolicy(obj):
 		partialPolicy, err := toIAMPartialPolicy(obj)
@@ -77,7 +110,13 @@ func (a *iamValidatorHandler) Handle(ctx context.Context, req admission.Request)
 		}
 		refResourceGVK := partialPolicy.Spec.ResourceReference.GroupVersionKind()
 		isDCLResource := metadata.IsDCLBasedResourceKind(refResourceGVK, a.serviceMetadataLoader)
-		return a.validateIAMPartialPolicy(partialPolicy, isDCLResource)
+		if resp := a.validateIAMPartialPolicy(partialPolicy, isDCLResource); !resp.Allowed {
+			return resp
+		}
+		if resp := a.validateRolesAndMembers(ctx, req, partialPolicy); !resp.Allowed {
+			return resp
+		}
+		return a.validateNoManagementConflict(ctx, req, partialPolicy)
 
 	case isIAMPolicyMember(obj):
 		policyMember, err := toIAMPolicyMember(obj)
@@ -86,7 +125,13 @@ func (a *iamValidatorHandler) Handle(ctx context.Context, req admission.Request)
 		}
 		refResourceGVK := policyMember.Spec.ResourceReference.GroupVersionKind()
 		isDCLResource := metadata.IsDCLBasedResourceKind(refResourceGVK, a.serviceMetadataLoader)
-		return a.validateIAMPolicyMember(policyMember, isDCLResource)
+		if resp := a.validateIAMPolicyMember(policyMember, isDCLResource); !resp.Allowed {
+			return resp
+		}
+		if resp := a.validateRolesAndMembers(ctx, req, policyMember); !resp.Allowed {
+			return resp
+		}
+		return a.validateNoManagementConflict(ctx, req, policyMember)
 	case isIAMAuditConfig(obj):
 		auditConfig, err := toIAMAuditConfig(obj)
 		if err != nil {
@@ -102,7 +147,16 @@ func (a *iamValidatorHandler) Handle(ctx context.Context, req admission.Request)
 		if err != nil {
 			return admission.Errored(http.StatusBadRequest, err)
 		}
-		return validateIAMAuditConfig(auditConfig, rcs)
+		if resp := validateIAMAuditConfig(auditConfig, rcs); !resp.Allowed {
+			return resp
+		}
+		return a.validateRolesAndMembers(ctx, req, auditConfig)
+	case isIAMDenyPolicy(obj):
+		denyPolicy, err := toIAMDenyPolicy(obj)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		return validateIAMDenyPolicy(denyPolicy)
 	default:
 		return admission.Errored(http.StatusInternalServerError,
 			fmt.Errorf("object of GroupVersionKind %v is not a supported IAM resource", obj.GroupVersionKind()))
@@ -141,6 +195,14 @@ func toIAMAuditConfig(obj *unstructured.Unstructured) (*v1beta1.IAMAuditConfig,
 	return auditConfig, nil
 }
 
+func toIAMDenyPolicy(obj *unstructured.Unstructured) (*v1beta1.IAMDenyPolicy, error) {
+	denyPolicy := &v1beta1.IAMDenyPolicy{}
+	if err := util.Marshal(obj, denyPolicy); err != nil {
+		return nil, fmt.Errorf("error parsing %v into IAMDenyPolicy object: %v", obj.GetName(), err)
+	}
+	return denyPolicy, nil
+}
+
 func getDCLSchema(gvk schema.GroupVersionKind, serviceMetadataLoader metadata.ServiceMetadataLoader, schemaLoader dclschemaloader.DCLSchemaLoader) (*openapi.Schema, admission.Response) {
 	dclSchema, err := dclschemaloader.GetDCLSchemaForGVK(gvk, serviceMetadataLoader, schemaLoader)
 	if err != nil {
@@ -216,6 +278,28 @@ func (a *iamValidatorHandler) validateIAMPolicyMember(policyMember *v1beta1.IAMP
 	return a.tfValidateIAMPolicyMember(policyMember, rcs)
 }
 
+// validateNoManagementConflict denies the creation of an IAMPolicy, IAMPartialPolicy or IAMPolicyMember
+// whose resource already has its IAM policy managed by a conflicting IAM resource in any namespace.
+// Updates are always allowed so that conflicts predating the object can still be resolved; the
+// controllers surface them through the 'ManagementConflict' condition instead.
+func (a *iamValidatorHandler) validateNoManagementConflict(ctx context.Context, req admission.Request, obj client.Object) admission.Response {
+	if req.Operation != admissionv1.Create {
+		return allowedResponse
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(req.Namespace)
+	}
+	resolver := kcciamclient.NewTFIAMClient(a.tfProvider, a.smLoader, a.client)
+	conflicts, err := managementconflict.FindConflicts(ctx, a.client, resolver, obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(conflicts) > 0 {
+		return admission.Errored(http.StatusForbidden, managementconflict.NewConflictError(obj.GetObjectKind().GroupVersionKind().Kind, conflicts))
+	}
+	return allowedResponse
+}
+
 func validateIAMAuditConfig(auditConfig *v1beta1.IAMAuditConfig, refResourceRCs []*v1alpha1.ResourceConfig) admission.Response {
 	resourceRef := auditConfig.Spec.ResourceReference
 	if !doesTFResourceSupportAuditConfigs(refResourceRCs) {
@@ -225,6 +309,27 @@ func validateIAMAuditConfig(auditConfig *v1beta1.IAMAuditConfig, refResourceRCs
 	return allowedResponse
 }
 
+func validateIAMDenyPolicy(denyPolicy *v1beta1.IAMDenyPolicy) admission.Response {
+	resourceRef := denyPolicy.Spec.ResourceReference
+	if !kcciamclient.IsDenyPolicyAttachmentPointKind(resourceRef.Kind) {
+		return admission.Errored(http.StatusForbidden,
+			fmt.Errorf("GroupVersionKind %v does not support IAM Deny Policies; only Projects, Folders and "+
+				"Organizations are supported", resourceRef.GroupVersionKind()))
+	}
+	for i, rule := range denyPolicy.Spec.Rules {
+		permissions := append(append([]string{}, rule.DenyRule.DeniedPermissions...), rule.DenyRule.ExceptionPermissions...)
+		for _, p := range permissions {
+			if !denyPolicyPermissionRegex.MatchString(p) {
+				return admission.Errored(http.StatusForbidden,
+					fmt.Errorf("invalid permission '%v' in spec.rules[%v]: permissions in IAM Deny Policies must be "+
+						"in the format '{service-fqdn}/{resource}.{verb}', e.g. 'iam.googleapis.com/roles.delete', "+
+						"where the resource and the verb may be '*'", p, i))
+			}
+		}
+	}
+	return allowedResponse
+}
+
 func (a *iamValidatorHandler) dclValidateIAMPolicy(policy *v1beta1.IAMPolicy) admission.Response {
 	resourceRef := policy.Spec.ResourceReference
 	// Check that DCL-based resource supports IAMPolicy
@@ -327,17 +432,21 @@ func doesIAMPolicyHaveConditions(policy *v1beta1.IAMPolicy) bool {
 	return false
 }
 
+// doesIAMPartialPolicyHaveConditions returns true if any binding of the given IAMPartialPolicy has
+// a condition, including the condition enforcing its expiry time.
 func doesIAMPartialPolicyHaveConditions(partialPolicy *v1beta1.IAMPartialPolicy) bool {
 	for _, binding := range partialPolicy.Spec.Bindings {
-		if binding.Condition != nil {
+		if binding.Condition != nil || binding.ExpiresAt != nil {
 			return true
 		}
 	}
 	return false
 }
 
+// doesIAMPolicyMemberHaveCondition returns true if the given IAMPolicyMember has a condition,
+// including the condition enforcing its expiry time.
 func doesIAMPolicyMemberHaveCondition(policyMember *v1beta1.IAMPolicyMember) bool {
-	return policyMember.Spec.Condition != nil
+	return policyMember.Spec.Condition != nil || policyMember.Spec.ExpiresAt != nil
 }
 
 func doesTFResourceSupportConditions(rcs []*v1alpha1.ResourceConfig) bool {
@@ -15,7 +15,9 @@
 package webhook
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/core/v1alpha1"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
@@ -25,8 +27,12 @@ import (
 	testservicemappingloader "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/test/servicemappingloader"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/test/mocks"
 )
 
 func TestValidateIAMPolicy(t *testing.T) {
@@ -305,6 +311,18 @@ func TestValidateIAMPolicyMember(t *testing.T) {
 			refResourceRCs:       newTestResourceRCsThatSupportConditions(),
 			expectedAllowedValue: true,
 		},
+		{
+			name:                 "IAMPolicyMember with an expiry time, with reference that doesn't support conditions",
+			policyMember:         newTestPolicyMemberWithExpiryTime(),
+			refResourceRCs:       newTestResourceRCs(),
+			expectedAllowedValue: false,
+		},
+		{
+			name:                 "IAMPolicyMember with an expiry time, with reference that supports conditions",
+			policyMember:         newTestPolicyMemberWithExpiryTime(),
+			refResourceRCs:       newTestResourceRCsThatSupportConditions(),
+			expectedAllowedValue: true,
+		},
 		// TODO(kcc-eng): Remove the two tests below when we drop support for headless IAM.
 		{
 			name:                 "Headless Project IAMPolicyMember without conditions",
@@ -400,6 +418,140 @@ func TestValidateIAMAuditConfig(t *testing.T) {
 	}
 }
 
+func TestValidateIAMDenyPolicy(t *testing.T) {
+	tests := []struct {
+		name                 string
+		resourceRef          v1beta1.ResourceReference
+		deniedPermissions    []string
+		exceptionPermissions []string
+		expectedAllowedValue bool
+	}{
+		{
+			name:        "IAMDenyPolicy attached to a project",
+			resourceRef: v1beta1.ResourceReference{Kind: "Project", External: "projects/foo-project"},
+			deniedPermissions: []string{
+				"iam.googleapis.com/roles.delete",
+				"iam.googleapis.com/roles.*",
+				"iam.googleapis.com/*.delete",
+				"iam.googleapis.com/*",
+				"cloudresourcemanager.googleapis.com/projects.setIamPolicy",
+			},
+			exceptionPermissions: []string{"iam.googleapis.com/roles.get"},
+			expectedAllowedValue: true,
+		},
+		{
+			name:                 "IAMDenyPolicy attached to an organization",
+			resourceRef:          v1beta1.ResourceReference{Kind: "Organization", External: "organizations/123456789"},
+			deniedPermissions:    []string{"iam.googleapis.com/roles.delete"},
+			expectedAllowedValue: true,
+		},
+		{
+			name:                 "IAMDenyPolicy attached to an unsupported resource",
+			resourceRef:          v1beta1.ResourceReference{Kind: "StorageBucket", Name: "foo-bucket"},
+			deniedPermissions:    []string{"iam.googleapis.com/roles.delete"},
+			expectedAllowedValue: false,
+		},
+		{
+			name:                 "IAMDenyPolicy with a v1 permission name",
+			resourceRef:          v1beta1.ResourceReference{Kind: "Project", Name: "foo-project"},
+			deniedPermissions:    []string{"iam.roles.delete"},
+			expectedAllowedValue: false,
+		},
+		{
+			name:                 "IAMDenyPolicy with a permission missing the verb",
+			resourceRef:          v1beta1.ResourceReference{Kind: "Folder", Name: "foo-folder"},
+			deniedPermissions:    []string{"iam.googleapis.com/roles"},
+			expectedAllowedValue: false,
+		},
+		{
+			name:                 "IAMDenyPolicy with an invalid exception permission",
+			resourceRef:          v1beta1.ResourceReference{Kind: "Project", Name: "foo-project"},
+			deniedPermissions:    []string{"iam.googleapis.com/roles.*"},
+			exceptionPermissions: []string{"roles/iam.roleViewer"},
+			expectedAllowedValue: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			denyPolicy := &v1beta1.IAMDenyPolicy{
+				Spec: v1beta1.IAMDenyPolicySpec{
+					ResourceReference: tc.resourceRef,
+					Rules: []v1beta1.IAMDenyPolicyRule{
+						{
+							DenyRule: v1beta1.IAMDenyRule{
+								DeniedPrincipals:     []string{"principalSet://goog/public:all"},
+								DeniedPermissions:    tc.deniedPermissions,
+								ExceptionPermissions: tc.exceptionPermissions,
+							},
+						},
+					},
+				},
+			}
+			response := validateIAMDenyPolicy(denyPolicy)
+			if response.Allowed != tc.expectedAllowedValue {
+				t.Fatalf("unexpected value for Allowed: got '%v', want '%v'", response.Allowed, tc.expectedAllowedValue)
+			}
+		})
+	}
+}
+
+func TestValidateNoManagementConflict(t *testing.T) {
+	if err := v1beta1.SchemeBuilder.AddToScheme(clientgoscheme.Scheme); err != nil {
+		t.Fatalf("error adding IAM types to the scheme: %v", err)
+	}
+	ctx := context.Background()
+	kubeClient := mocks.Manager{}.GetClient()
+	policy := newTestPolicy()
+	policy.SetNamespace("my-namespace")
+	policy.SetName("my-policy")
+	if err := kubeClient.Create(ctx, policy); err != nil {
+		t.Fatalf("error creating IAMPolicy: %v", err)
+	}
+	handler := &iamValidatorHandler{client: kubeClient}
+	tests := []struct {
+		name                 string
+		operation            admissionv1.Operation
+		obj                  client.Object
+		expectedAllowedValue bool
+	}{
+		{
+			name:                 "creation of IAMPolicyMember referencing a resource with an IAMPolicy",
+			operation:            admissionv1.Create,
+			obj:                  newTestPolicyMember(),
+			expectedAllowedValue: false,
+		},
+		{
+			name:                 "update of IAMPolicyMember referencing a resource with an IAMPolicy",
+			operation:            admissionv1.Update,
+			obj:                  newTestPolicyMember(),
+			expectedAllowedValue: true,
+		},
+		{
+			name:                 "creation of IAMPartialPolicy referencing a resource with an IAMPolicy",
+			operation:            admissionv1.Create,
+			obj:                  newTestPartialPolicy(),
+			expectedAllowedValue: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: tc.operation,
+					Namespace: "my-namespace",
+				},
+			}
+			response := handler.validateNoManagementConflict(ctx, req, tc.obj)
+			if response.Allowed != tc.expectedAllowedValue {
+				t.Fatalf("unexpected value for Allowed: got '%v', want '%v'", response.Allowed, tc.expectedAllowedValue)
+			}
+		})
+	}
+}
+
 func newTestPolicy() *v1beta1.IAMPolicy {
 	return &v1beta1.IAMPolicy{
 		TypeMeta: metav1.TypeMeta{
@@ -569,6 +721,13 @@ func newTestPolicyMemberWithIAMConditions() *v1beta1.IAMPolicyMember {
 	return policyMember
 }
 
+func newTestPolicyMemberWithExpiryTime() *v1beta1.IAMPolicyMember {
+	policyMember := newTestPolicyMember()
+	expiresAt := metav1.NewTime(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC))
+	policyMember.Spec.ExpiresAt = &expiresAt
+	return policyMember
+}
+
 func newTestIAMCondition() *v1beta1.IAMCondition {
 	return &v1beta1.IAMCondition{
 		Title:       "test-iam-condition",
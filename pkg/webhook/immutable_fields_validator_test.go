@@ -2384,6 +2384,60 @@ func assertHandleIAMAuditConfig(t *testing.T, old *unstructured.Unstructured, ne
 	}
 }
 
+func TestUpdateIAMDenyPolicy(t *testing.T) {
+	resourceID := "foo-policy"
+	denyPolicy := v1beta1.IAMDenyPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1beta1.IAMDenyPolicyGVK.Kind,
+			APIVersion: v1beta1.IAMDenyPolicyGVK.GroupVersion().String(),
+		},
+		Spec: v1beta1.IAMDenyPolicySpec{
+			ResourceReference: v1beta1.ResourceReference{
+				Kind:       "Project",
+				Namespace:  "my-namespace",
+				Name:       "my-project",
+				APIVersion: "resourcemanager.cnrm.cloud.google.com/v1beta1",
+			},
+			ResourceID: &resourceID,
+			Rules: []v1beta1.IAMDenyPolicyRule{
+				{
+					DenyRule: v1beta1.IAMDenyRule{
+						DeniedPrincipals:  []string{"principalSet://goog/public:all"},
+						DeniedPermissions: []string{"iam.googleapis.com/roles.delete"},
+					},
+				},
+			},
+		},
+	}
+	oldDenyPolicyUnstructured := newUnstructuredFromObject(t, &denyPolicy)
+	newDenyPolicyUnstructured := newUnstructuredFromObject(t, &denyPolicy)
+	assertHandleIAMDenyPolicy(t, oldDenyPolicyUnstructured, newDenyPolicyUnstructured, true)
+	copyDenyPolicy := denyPolicy
+	copyDenyPolicy.Spec.DisplayName = "new-display-name"
+	copyDenyPolicy.Spec.Rules = append(copyDenyPolicy.Spec.Rules, v1beta1.IAMDenyPolicyRule{Description: "new-rule"})
+	newDenyPolicyUnstructured = newUnstructuredFromObject(t, &copyDenyPolicy)
+	assertHandleIAMDenyPolicy(t, oldDenyPolicyUnstructured, newDenyPolicyUnstructured, true)
+	copyDenyPolicy = denyPolicy
+	copyDenyPolicy.Spec.ResourceReference.Name = "new-resource-reference-name"
+	newDenyPolicyUnstructured = newUnstructuredFromObject(t, &copyDenyPolicy)
+	assertHandleIAMDenyPolicy(t, oldDenyPolicyUnstructured, newDenyPolicyUnstructured, false)
+	copyDenyPolicy = denyPolicy
+	newResourceID := "new-policy"
+	copyDenyPolicy.Spec.ResourceID = &newResourceID
+	newDenyPolicyUnstructured = newUnstructuredFromObject(t, &copyDenyPolicy)
+	assertHandleIAMDenyPolicy(t, oldDenyPolicyUnstructured, newDenyPolicyUnstructured, false)
+}
+
+func assertHandleIAMDenyPolicy(t *testing.T, old *unstructured.Unstructured, new *unstructured.Unstructured, expectedAllowedValue bool) {
+	t.Helper()
+	oldSpec := getSpecFromUnstructed(t, old)
+	newSpec := getSpecFromUnstructed(t, new)
+	response := handleIAMDenyPolicy(oldSpec, newSpec)
+	if response.Allowed != expectedAllowedValue {
+		t.Fatalf("unexpected value for Allowed: got '%v', want '%v'", response.Allowed, expectedAllowedValue)
+	}
+}
+
 func getSpecFromUnstructed(t *testing.T, u *unstructured.Unstructured) map[string]interface{} {
 	spec, ok, err := unstructured.NestedMap(u.Object, "spec")
 	if err != nil {
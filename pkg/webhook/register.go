@@ -21,6 +21,7 @@ import (
 	"path"
 	"strings"
 
+	policyv1alpha1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/policy/v1alpha1"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/schema/dclschemaloader"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/gvks/supportedgvks"
@@ -51,9 +52,9 @@ var (
 	CommonWebhookServiceName           = "cnrm-validating-webhook"
 )
 
-func RegisterCommonWebhooks(mgr manager.Manager, nocacheClient client.Client) error {
+func RegisterCommonWebhooks(mgr manager.Manager, nocacheClient client.Client, validationModes ValidationModes) error {
 	fmt.Println("starting up webhooks")
-	whCfgs, err := GetCommonWebhookConfigs()
+	whCfgs, err := GetCommonWebhookConfigs(validationModes)
 	if err != nil {
 		return fmt.Errorf("error getting common wehbook configs: %v", err)
 	}
@@ -68,7 +69,10 @@ func RegisterCommonWebhooks(mgr manager.Manager, nocacheClient client.Client) er
 	)
 }
 
-func GetCommonWebhookConfigs() ([]WebhookConfig, error) {
+// GetCommonWebhookConfigs returns the configurations of the webhooks served by
+// the webhook manager. The validating webhooks that are not in the given modes
+// are in enforce mode.
+func GetCommonWebhookConfigs(validationModes ValidationModes) ([]WebhookConfig, error) {
 	smLoader, err := servicemappingloader.New()
 	if err != nil {
 		return nil, fmt.Errorf("error getting new service mapping loader: %w", err)
@@ -88,7 +92,7 @@ func GetCommonWebhookConfigs() ([]WebhookConfig, error) {
 			Name:          "deny-immutable-field-updates.cnrm.cloud.google.com",
 			Path:          "/deny-immutable-field-updates",
 			Type:          Validating,
-			Handler:       NewRequestLoggingHandler(NewImmutableFieldsValidatorHandler(smLoader, dclSchemaLoader, serviceMetadataLoader), "immutable fields validation"),
+			Handler:       NewRequestLoggingHandler(NewValidationModeHandler(NewImmutableFieldsValidatorHandler(smLoader, dclSchemaLoader, serviceMetadataLoader), ImmutableFieldsValidation, validationModes.ModeFor(ImmutableFieldsValidation)), "immutable fields validation"),
 			FailurePolicy: admissionregistration.Fail,
 			Rules: getRulesForOperationTypes(
 				allResourcesRules,
@@ -100,7 +104,7 @@ func GetCommonWebhookConfigs() ([]WebhookConfig, error) {
 			Name:          "deny-unknown-fields.cnrm.cloud.google.com",
 			Path:          "/deny-unknown-fields",
 			Type:          Validating,
-			Handler:       NewRequestLoggingHandler(NewNoUnknownFieldsValidatorHandler(smLoader), "unknown fields validation"),
+			Handler:       NewRequestLoggingHandler(NewValidationModeHandler(NewNoUnknownFieldsValidatorHandler(smLoader), UnknownFieldsValidation, validationModes.ModeFor(UnknownFieldsValidation)), "unknown fields validation"),
 			FailurePolicy: admissionregistration.Fail,
 			Rules: getRulesForOperationTypes(
 				allResourcesRules,
@@ -113,7 +117,7 @@ func GetCommonWebhookConfigs() ([]WebhookConfig, error) {
 			Name:          "iam-validation.cnrm.cloud.google.com",
 			Path:          "/iam-validation",
 			Type:          Validating,
-			Handler:       NewRequestLoggingHandler(NewIAMValidatorHandler(smLoader, serviceMetadataLoader, dclSchemaLoader), "iam validation"),
+			Handler:       NewRequestLoggingHandler(NewValidationModeHandler(NewIAMValidatorHandler(smLoader, serviceMetadataLoader, dclSchemaLoader), IAMValidation, validationModes.ModeFor(IAMValidation)), "iam validation"),
 			FailurePolicy: admissionregistration.Fail,
 			Rules: getRulesForOperationTypes(handwrittenIamResourcesRules,
 				admissionregistration.Create,
@@ -168,11 +172,50 @@ func GetCommonWebhookConfigs() ([]WebhookConfig, error) {
 			),
 			SideEffects: admissionregistration.SideEffectClassNone,
 		},
+		{
+			Name:          "allowed-targets-validation.cnrm.cloud.google.com",
+			Path:          "/allowed-targets-validation",
+			Type:          Validating,
+			Handler:       NewRequestLoggingHandler(NewAllowedTargetsValidatorHandler(smLoader, dclSchemaLoader, serviceMetadataLoader), "allowed targets validation"),
+			FailurePolicy: admissionregistration.Fail,
+			Rules: getRulesForOperationTypes(
+				allResourcesRules,
+				admissionregistration.Create,
+				admissionregistration.Update,
+			),
+			SideEffects: admissionregistration.SideEffectClassNone,
+		},
+		{
+			Name:          "resource-policy-validation.cnrm.cloud.google.com",
+			Path:          "/resource-policy-validation",
+			Type:          Validating,
+			Handler:       NewRequestLoggingHandler(NewValidationModeHandler(NewResourcePolicyValidatorHandler(smLoader, dclSchemaLoader, serviceMetadataLoader), ResourcePolicyValidation, validationModes.ModeFor(ResourcePolicyValidation)), "resource policy validation"),
+			FailurePolicy: admissionregistration.Fail,
+			Rules: getRulesForOperationTypes(
+				allResourcesRules,
+				admissionregistration.Create,
+				admissionregistration.Update,
+			),
+			SideEffects: admissionregistration.SideEffectClassNone,
+		},
+		{
+			Name:          "resource-policy-definition-validation.cnrm.cloud.google.com",
+			Path:          "/resource-policy-definition-validation",
+			Type:          Validating,
+			Handler:       NewRequestLoggingHandler(NewResourcePolicyDefinitionValidatorHandler(), "resource policy definition validation"),
+			FailurePolicy: admissionregistration.Fail,
+			Rules: getRulesForOperationTypes(
+				getRulesFromResources([]schema.GroupVersionKind{policyv1alpha1.ResourcePolicyGVK}),
+				admissionregistration.Create,
+				admissionregistration.Update,
+			),
+			SideEffects: admissionregistration.SideEffectClassNone,
+		},
 		{
 			Name:          "resource-validation.cnrm.cloud.google.com",
 			Path:          "/resource-validation",
 			Type:          Validating,
-			Handler:       NewRequestLoggingHandler(NewResourceValidatorHandler(), "resource validation"),
+			Handler:       NewRequestLoggingHandler(NewValidationModeHandler(NewResourceValidatorHandler(), ResourceValidation, validationModes.ModeFor(ResourceValidation)), "resource validation"),
 			FailurePolicy: admissionregistration.Fail,
 			Rules: getRulesForOperationTypes(resourcesWithOverridesRules,
 				admissionregistration.Create,
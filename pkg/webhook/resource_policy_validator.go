@@ -0,0 +1,241 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	corekccv1alpha1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/core/v1alpha1"
+	iamv1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/iam/v1beta1"
+	policyv1alpha1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/policy/v1alpha1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl"
+	dclcontainer "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/extension/container"
+	dclmetadata "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/schema/dclschemaloader"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/resourcepolicy"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/servicemapping/servicemappingloader"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/util"
+
+	"github.com/golang/glog"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+type resourcePolicyValidatorHandler struct {
+	client                client.Client
+	smLoader              *servicemappingloader.ServiceMappingLoader
+	dclSchemaLoader       dclschemaloader.DCLSchemaLoader
+	serviceMetadataLoader dclmetadata.ServiceMetadataLoader
+	policyCache           *resourcepolicy.Cache
+}
+
+// resourcePolicyValidatorHandler implements inject.Client.
+var _ inject.Client = &resourcePolicyValidatorHandler{}
+
+func NewResourcePolicyValidatorHandler(smLoader *servicemappingloader.ServiceMappingLoader, dclSchemaLoader dclschemaloader.DCLSchemaLoader, serviceMetadataLoader dclmetadata.ServiceMetadataLoader) *resourcePolicyValidatorHandler {
+	return &resourcePolicyValidatorHandler{
+		smLoader:              smLoader,
+		dclSchemaLoader:       dclSchemaLoader,
+		serviceMetadataLoader: serviceMetadataLoader,
+		policyCache:           resourcepolicy.NewCache(),
+	}
+}
+
+// InjectClient injects the client into the resourcePolicyValidatorHandler
+func (a *resourcePolicyValidatorHandler) InjectClient(c client.Client) error {
+	a.client = c
+	return nil
+}
+
+// Handle evaluates the rules of the ResourcePolicies matching the resource
+// being admitted, and denies its admission if it violates any of them.
+func (a *resourcePolicyValidatorHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if regexp.MustCompile(ControllerManagerServiceAccountRegex).MatchString(req.AdmissionRequest.UserInfo.Username) {
+		return admission.ValidationResponse(true, "ignore non-user requests")
+	}
+	deserializer := codecs.UniversalDeserializer()
+	obj := &unstructured.Unstructured{}
+	if _, _, err := deserializer.Decode(req.AdmissionRequest.Object.Raw, nil, obj); err != nil {
+		glog.Error(err)
+		return admission.Errored(http.StatusBadRequest,
+			fmt.Errorf("error decoding object: %v", err))
+	}
+	// Don't prevent resources from being deleted because of the rules that
+	// they violate.
+	if obj.GetDeletionTimestamp() != nil {
+		return allowedResponse
+	}
+	var oldObj map[string]interface{}
+	if req.Operation == admissionv1.Update {
+		u := &unstructured.Unstructured{}
+		if _, _, err := deserializer.Decode(req.AdmissionRequest.OldObject.Raw, nil, u); err != nil {
+			glog.Error(err)
+			return admission.Errored(http.StatusBadRequest,
+				fmt.Errorf("error decoding old object: %v", err))
+		}
+		oldObj = u.Object
+	}
+	policies := &policyv1alpha1.ResourcePolicyList{}
+	if err := a.client.List(ctx, policies); err != nil {
+		// The ResourcePolicy CRD is installed separately from the resource CRDs and may be missing.
+		if meta.IsNoMatchError(err) {
+			return allowedResponse
+		}
+		return admission.Errored(http.StatusInternalServerError,
+			fmt.Errorf("error listing ResourcePolicies: %v", err))
+	}
+	a.policyCache.Prune(policies.Items)
+	if len(policies.Items) == 0 {
+		return allowedResponse
+	}
+	ns := &corev1.Namespace{}
+	if err := a.client.Get(ctx, apimachinerytypes.NamespacedName{Name: obj.GetNamespace()}, ns); err != nil {
+		return admission.Errored(http.StatusInternalServerError,
+			fmt.Errorf("error getting Namespace %v: %v", obj.GetNamespace(), err))
+	}
+	var input *resourcepolicy.Input
+	var violations []string
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		matches, err := resourcepolicy.Matches(policy, obj.GroupVersionKind(), ns)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if !matches {
+			continue
+		}
+		compiled, err := a.policyCache.Get(policy)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if input == nil {
+			project, location, err := a.projectAndLocationOf(obj)
+			if err != nil {
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+			input = &resourcepolicy.Input{
+				Object:    obj.Object,
+				OldObject: oldObj,
+				Namespace: ns,
+				Project:   project,
+				Location:  location,
+			}
+		}
+		for _, v := range compiled.Evaluate(input) {
+			violations = append(violations, v.String())
+		}
+	}
+	if len(violations) > 0 {
+		return admission.Errored(http.StatusForbidden,
+			fmt.Errorf("resource violates the following ResourcePolicy rules: %v", strings.Join(violations, "; ")))
+	}
+	return allowedResponse
+}
+
+// projectAndLocationOf returns the project ID and the location of the GCP
+// resource underlying the given resource. The project is resolved from the
+// hierarchical reference or the container annotation that the resource
+// supports according to its service mapping or DCL service metadata, and is
+// empty if the resource isn't project-scoped or references its project
+// through a Project resource. The location is empty if the resource has no
+// location.
+func (a *resourcePolicyValidatorHandler) projectAndLocationOf(obj *unstructured.Unstructured) (project, location string, err error) {
+	spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+	location = k8s.LocationOf(spec)
+	gvk := obj.GroupVersionKind()
+	if iamv1beta1.IsHandwrittenIAM(gvk) {
+		return "", location, nil
+	}
+	containers, hierarchicalRefs, err := containersAndHierarchicalRefsOf(obj, a.smLoader, a.dclSchemaLoader, a.serviceMetadataLoader)
+	if err != nil {
+		return "", "", err
+	}
+	for _, ref := range hierarchicalRefs {
+		if ref.Type != corekccv1alpha1.HierarchicalReferenceTypeProject {
+			continue
+		}
+		if external, _, _ := unstructured.NestedString(obj.Object, "spec", ref.Key, "external"); external != "" {
+			return strings.TrimPrefix(external, "projects/"), location, nil
+		}
+	}
+	if k8s.IsProjectScoped(containers) {
+		if val, ok := k8s.GetAnnotation(k8s.ProjectIDAnnotation, obj); ok {
+			return val, location, nil
+		}
+	}
+	return "", location, nil
+}
+
+// containersAndHierarchicalRefsOf returns the containers and the hierarchical
+// references supported by the kind of the given resource.
+func containersAndHierarchicalRefsOf(obj *unstructured.Unstructured, smLoader *servicemappingloader.ServiceMappingLoader,
+	dclSchemaLoader dclschemaloader.DCLSchemaLoader, serviceMetadataLoader dclmetadata.ServiceMetadataLoader) ([]corekccv1alpha1.Container, []corekccv1alpha1.HierarchicalReference, error) {
+	gvk := obj.GroupVersionKind()
+	if dclmetadata.IsDCLBasedResourceKind(gvk, serviceMetadataLoader) {
+		containers, err := dclcontainer.GetContainersForGVK(gvk, serviceMetadataLoader, dclSchemaLoader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting containers supported by GroupVersionKind %v: %v", gvk, err)
+		}
+		hierarchicalRefs, err := dcl.GetHierarchicalReferencesForGVK(gvk, serviceMetadataLoader, dclSchemaLoader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting hierarchical references supported by GroupVersionKind %v: %v", gvk, err)
+		}
+		return containers, hierarchicalRefs, nil
+	}
+	rc, err := smLoader.GetResourceConfig(obj)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting ResourceConfig for kind %v: %v", obj.GetKind(), err)
+	}
+	return rc.Containers, rc.HierarchicalReferences, nil
+}
+
+type resourcePolicyDefinitionValidatorHandler struct{}
+
+func NewResourcePolicyDefinitionValidatorHandler() *resourcePolicyDefinitionValidatorHandler {
+	return &resourcePolicyDefinitionValidatorHandler{}
+}
+
+// Handle denies the admission of ResourcePolicies whose rules don't compile,
+// as they would otherwise deny the admission of all the resources that they
+// match.
+func (a *resourcePolicyDefinitionValidatorHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	deserializer := codecs.UniversalDeserializer()
+	obj := &unstructured.Unstructured{}
+	if _, _, err := deserializer.Decode(req.AdmissionRequest.Object.Raw, nil, obj); err != nil {
+		glog.Error(err)
+		return admission.Errored(http.StatusBadRequest,
+			fmt.Errorf("error decoding object: %v", err))
+	}
+	policy := &policyv1alpha1.ResourcePolicy{}
+	if err := util.Marshal(obj, policy); err != nil {
+		return admission.Errored(http.StatusInternalServerError,
+			fmt.Errorf("error parsing %v into ResourcePolicy object: %v", obj.GetName(), err))
+	}
+	if _, err := resourcepolicy.Compile(policy); err != nil {
+		return admission.Errored(http.StatusForbidden, err)
+	}
+	return allowedResponse
+}
@@ -0,0 +1,383 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	policyv1alpha1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/policy/v1alpha1"
+	dclmetadata "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/schema/dclschemaloader"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	testservicemappingloader "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/test/servicemappingloader"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/test/mocks"
+)
+
+func TestResourcePolicyValidatorHandler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("error adding client-go types to the scheme: %v", err)
+	}
+	if err := policyv1alpha1.SchemeBuilder.AddToScheme(scheme); err != nil {
+		t.Fatalf("error adding policy types to the scheme: %v", err)
+	}
+	ctx := context.Background()
+	kubeClient := mocks.NewClient(scheme)
+	namespaces := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "prod-namespace",
+				Annotations: map[string]string{
+					k8s.ProjectIDAnnotation: "prod-project",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev-namespace"},
+		},
+	}
+	for _, ns := range namespaces {
+		if err := kubeClient.Create(ctx, ns); err != nil {
+			t.Fatalf("error creating Namespace: %v", err)
+		}
+	}
+	policies := []*policyv1alpha1.ResourcePolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "storage-buckets"},
+			Spec: policyv1alpha1.ResourcePolicySpec{
+				Match: policyv1alpha1.ResourcePolicyMatch{
+					Kinds:      []policyv1alpha1.ResourcePolicyKind{{Group: "storage.cnrm.cloud.google.com", Kind: "StorageBucket"}},
+					Namespaces: []string{"prod-namespace"},
+				},
+				Rules: []policyv1alpha1.ResourcePolicyRule{
+					{
+						Name:       "uniform-bucket-level-access",
+						Expression: "has(object.spec.uniformBucketLevelAccess) && object.spec.uniformBucketLevelAccess",
+						Message:    "StorageBuckets must set uniformBucketLevelAccess",
+					},
+					{
+						Name:       "namespace-project",
+						Expression: `resource.project == namespaceObject.annotations["cnrm.cloud.google.com/project-id"]`,
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute-firewalls"},
+			Spec: policyv1alpha1.ResourcePolicySpec{
+				Match: policyv1alpha1.ResourcePolicyMatch{
+					Kinds: []policyv1alpha1.ResourcePolicyKind{{Group: "compute.cnrm.cloud.google.com", Kind: "ComputeFirewall"}},
+				},
+				Rules: []policyv1alpha1.ResourcePolicyRule{
+					{
+						Name: "no-public-ssh",
+						Expression: `!(has(object.spec.sourceRanges) && "0.0.0.0/0" in object.spec.sourceRanges && ` +
+							`has(object.spec.allow) && object.spec.allow.exists(a, has(a.ports) && "22" in a.ports))`,
+					},
+				},
+			},
+		},
+	}
+	for _, p := range policies {
+		if err := kubeClient.Create(ctx, p); err != nil {
+			t.Fatalf("error creating ResourcePolicy: %v", err)
+		}
+	}
+	dclSchemaLoader, err := dclschemaloader.New()
+	if err != nil {
+		t.Fatalf("error creating a DCL schema loader: %v", err)
+	}
+	handler := NewResourcePolicyValidatorHandler(testservicemappingloader.New(t), dclSchemaLoader, dclmetadata.New())
+	if err := handler.InjectClient(kubeClient); err != nil {
+		t.Fatalf("error injecting client: %v", err)
+	}
+	newStorageBucket := func(namespace, projectID string, uniformBucketLevelAccess bool) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "storage.cnrm.cloud.google.com/v1beta1",
+				"kind":       "StorageBucket",
+				"metadata": map[string]interface{}{
+					"namespace": namespace,
+					"name":      "my-bucket",
+					"annotations": map[string]interface{}{
+						k8s.ProjectIDAnnotation: projectID,
+					},
+				},
+				"spec": map[string]interface{}{
+					"location":                 "EU",
+					"uniformBucketLevelAccess": uniformBucketLevelAccess,
+				},
+			},
+		}
+	}
+	newComputeFirewall := func(sourceRange string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "compute.cnrm.cloud.google.com/v1beta1",
+				"kind":       "ComputeFirewall",
+				"metadata": map[string]interface{}{
+					"namespace": "dev-namespace",
+					"name":      "my-firewall",
+				},
+				"spec": map[string]interface{}{
+					"networkRef":   map[string]interface{}{"name": "my-network"},
+					"sourceRanges": []interface{}{sourceRange},
+					"allow": []interface{}{
+						map[string]interface{}{"protocol": "tcp", "ports": []interface{}{"22"}},
+					},
+				},
+			},
+		}
+	}
+	deletedStorageBucket := newStorageBucket("prod-namespace", "prod-project", false)
+	deletedStorageBucket.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	tests := []struct {
+		name                 string
+		obj                  *unstructured.Unstructured
+		username             string
+		expectedAllowedValue bool
+	}{
+		{
+			name:                 "compliant StorageBucket",
+			obj:                  newStorageBucket("prod-namespace", "prod-project", true),
+			expectedAllowedValue: true,
+		},
+		{
+			name:                 "StorageBucket without uniform bucket-level access",
+			obj:                  newStorageBucket("prod-namespace", "prod-project", false),
+			expectedAllowedValue: false,
+		},
+		{
+			name:                 "StorageBucket in another project than its namespace",
+			obj:                  newStorageBucket("prod-namespace", "other-project", true),
+			expectedAllowedValue: false,
+		},
+		{
+			name:                 "StorageBucket in a namespace not matched by the policy",
+			obj:                  newStorageBucket("dev-namespace", "dev-project", false),
+			expectedAllowedValue: true,
+		},
+		{
+			name:                 "StorageBucket being deleted",
+			obj:                  deletedStorageBucket,
+			expectedAllowedValue: true,
+		},
+		{
+			name:                 "request from the controller manager",
+			obj:                  newStorageBucket("prod-namespace", "prod-project", false),
+			username:             "system:serviceaccount:cnrm-system:cnrm-controller-manager",
+			expectedAllowedValue: true,
+		},
+		{
+			name:                 "ComputeFirewall allowing SSH from anywhere",
+			obj:                  newComputeFirewall("0.0.0.0/0"),
+			expectedAllowedValue: false,
+		},
+		{
+			name:                 "ComputeFirewall allowing SSH from an internal range",
+			obj:                  newComputeFirewall("10.0.0.0/8"),
+			expectedAllowedValue: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, err := json.Marshal(tc.obj)
+			if err != nil {
+				t.Fatalf("error marshaling object: %v", err)
+			}
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+					UserInfo:  authenticationv1.UserInfo{Username: tc.username},
+				},
+			}
+			response := handler.Handle(ctx, req)
+			if response.Allowed != tc.expectedAllowedValue {
+				t.Fatalf("unexpected value for Allowed: got '%v', want '%v' (%v)", response.Allowed, tc.expectedAllowedValue, response.Result)
+			}
+		})
+	}
+}
+
+func TestProjectAndLocationOf(t *testing.T) {
+	dclSchemaLoader, err := dclschemaloader.New()
+	if err != nil {
+		t.Fatalf("error creating a DCL schema loader: %v", err)
+	}
+	handler := NewResourcePolicyValidatorHandler(testservicemappingloader.New(t), dclSchemaLoader, dclmetadata.New())
+	tests := []struct {
+		name             string
+		obj              *unstructured.Unstructured
+		expectedProject  string
+		expectedLocation string
+	}{
+		{
+			name: "container annotation",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "storage.cnrm.cloud.google.com/v1beta1",
+					"kind":       "StorageBucket",
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							k8s.ProjectIDAnnotation: "my-project",
+						},
+					},
+					"spec": map[string]interface{}{
+						"location": "EU",
+					},
+				},
+			},
+			expectedProject:  "my-project",
+			expectedLocation: "EU",
+		},
+		{
+			name: "hierarchical reference",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1",
+					"kind":       "PubSubSchema",
+					"spec": map[string]interface{}{
+						"projectRef": map[string]interface{}{
+							"external": "projects/my-project",
+						},
+					},
+				},
+			},
+			expectedProject: "my-project",
+		},
+		{
+			name: "hierarchical reference to a Project resource",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1",
+					"kind":       "PubSubSchema",
+					"spec": map[string]interface{}{
+						"projectRef": map[string]interface{}{
+							"name": "my-project-resource",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "zonal resource",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "compute.cnrm.cloud.google.com/v1beta1",
+					"kind":       "ComputeInstance",
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							k8s.ProjectIDAnnotation: "my-project",
+						},
+					},
+					"spec": map[string]interface{}{
+						"zone":        "us-central1-a",
+						"machineType": "n1-standard-1",
+					},
+				},
+			},
+			expectedProject:  "my-project",
+			expectedLocation: "us-central1-a",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			project, location, err := handler.projectAndLocationOf(tc.obj)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if project != tc.expectedProject {
+				t.Errorf("got project '%v', want '%v'", project, tc.expectedProject)
+			}
+			if location != tc.expectedLocation {
+				t.Errorf("got location '%v', want '%v'", location, tc.expectedLocation)
+			}
+		})
+	}
+}
+
+func TestResourcePolicyDefinitionValidatorHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		rule                 policyv1alpha1.ResourcePolicyRule
+		expectedAllowedValue bool
+	}{
+		{
+			name: "valid rule",
+			rule: policyv1alpha1.ResourcePolicyRule{
+				Name:       "uniform-bucket-level-access",
+				Expression: "object.spec.uniformBucketLevelAccess == true",
+			},
+			expectedAllowedValue: true,
+		},
+		{
+			name: "rule that doesn't compile",
+			rule: policyv1alpha1.ResourcePolicyRule{
+				Name:       "uniform-bucket-level-access",
+				Expression: "object.spec.uniformBucketLevelAccess ==",
+			},
+			expectedAllowedValue: false,
+		},
+	}
+	handler := NewResourcePolicyDefinitionValidatorHandler()
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			policy := &policyv1alpha1.ResourcePolicy{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: policyv1alpha1.SchemeGroupVersion.String(),
+					Kind:       policyv1alpha1.ResourcePolicyGVK.Kind,
+				},
+				ObjectMeta: metav1.ObjectMeta{Name: "storage-buckets"},
+				Spec: policyv1alpha1.ResourcePolicySpec{
+					Match: policyv1alpha1.ResourcePolicyMatch{
+						Kinds: []policyv1alpha1.ResourcePolicyKind{{Group: "storage.cnrm.cloud.google.com"}},
+					},
+					Rules: []policyv1alpha1.ResourcePolicyRule{tc.rule},
+				},
+			}
+			raw, err := json.Marshal(policy)
+			if err != nil {
+				t.Fatalf("error marshaling ResourcePolicy: %v", err)
+			}
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			}
+			response := handler.Handle(context.Background(), req)
+			if response.Allowed != tc.expectedAllowedValue {
+				t.Fatalf("unexpected value for Allowed: got '%v', want '%v' (%v)", response.Allowed, tc.expectedAllowedValue, response.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/metrics"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/util/slice"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ValidationMode determines what a validating webhook does with the requests
+// that it denies.
+type ValidationMode string
+
+const (
+	// ValidationModeEnforce denies the requests.
+	ValidationModeEnforce ValidationMode = "enforce"
+	// ValidationModeWarn allows the requests and returns the reason they would
+	// have been denied as an admission warning.
+	ValidationModeWarn ValidationMode = "warn"
+	// ValidationModeAudit allows the requests and only logs the reason they
+	// would have been denied.
+	ValidationModeAudit ValidationMode = "audit"
+)
+
+// Names of the validating webhooks whose mode can be configured.
+const (
+	ImmutableFieldsValidation = "immutable-fields"
+	UnknownFieldsValidation   = "unknown-fields"
+	IAMValidation             = "iam-validation"
+	ResourceValidation        = "resource-validation"
+	ResourcePolicyValidation  = "resource-policy-validation"
+)
+
+var (
+	configurableValidations = []string{
+		ImmutableFieldsValidation,
+		UnknownFieldsValidation,
+		IAMValidation,
+		ResourceValidation,
+		ResourcePolicyValidation,
+	}
+	validationModeValues = []string{
+		string(ValidationModeEnforce),
+		string(ValidationModeWarn),
+		string(ValidationModeAudit),
+	}
+)
+
+// ValidationModes maps the names of the configurable validating webhooks to
+// their mode. Webhooks that aren't in the map are in enforce mode.
+type ValidationModes map[string]ValidationMode
+
+// ParseValidationModes validates the given modes, keyed by webhook name, e.g.
+// as given by the --validation-modes flag of the webhook manager.
+func ParseValidationModes(modes map[string]string) (ValidationModes, error) {
+	res := make(ValidationModes)
+	names := make([]string, 0, len(modes))
+	for name := range modes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !slice.StringSliceContains(configurableValidations, name) {
+			return nil, fmt.Errorf("unknown webhook '%v', the mode can only be configured for {%v}",
+				name, strings.Join(configurableValidations, ", "))
+		}
+		mode := modes[name]
+		if !slice.StringSliceContains(validationModeValues, mode) {
+			return nil, fmt.Errorf("invalid mode '%v' for webhook '%v', must be one of {%v}",
+				mode, name, strings.Join(validationModeValues, ", "))
+		}
+		res[name] = ValidationMode(mode)
+	}
+	return res, nil
+}
+
+// ModeFor returns the mode of the webhook with the given name.
+func (m ValidationModes) ModeFor(name string) ValidationMode {
+	if mode, ok := m[name]; ok {
+		return mode
+	}
+	return ValidationModeEnforce
+}
+
+// ValidationModeHandler allows the requests denied by the wrapped validating
+// webhook handler if it isn't in enforce mode. The requests that would have
+// been denied are logged and counted in the webhook_bypassed_denials_total
+// metric, and in warn mode the reason they would have been denied is returned
+// as an admission warning.
+type ValidationModeHandler struct {
+	handler admission.Handler
+	name    string
+	mode    ValidationMode
+}
+
+var _ inject.Client = &ValidationModeHandler{}
+
+func NewValidationModeHandler(handler admission.Handler, name string, mode ValidationMode) *ValidationModeHandler {
+	return &ValidationModeHandler{
+		handler: handler,
+		name:    name,
+		mode:    mode,
+	}
+}
+
+func (a *ValidationModeHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	response := a.handler.Handle(ctx, req)
+	if response.Allowed || a.mode == ValidationModeEnforce || a.mode == "" {
+		return response
+	}
+	reason := "no reason given"
+	if response.Result != nil && response.Result.Message != "" {
+		reason = response.Result.Message
+	}
+	logger.Info("allowing request that would have been denied",
+		"operation", req.Operation,
+		"webhook", a.name,
+		"mode", a.mode,
+		"kind", req.Kind.Kind,
+		"resource", types.NamespacedName{Name: req.Name, Namespace: req.Namespace},
+		"reason", reason)
+	a.recordBypassedDenial(ctx, req)
+	allowed := admission.ValidationResponse(true, fmt.Sprintf("%v webhook in %v mode", a.name, a.mode))
+	if a.mode == ValidationModeWarn {
+		allowed = allowed.WithWarnings(fmt.Sprintf("the %v webhook would have denied this request: %v", a.name, reason))
+	}
+	return allowed
+}
+
+func (a *ValidationModeHandler) recordBypassedDenial(ctx context.Context, req admission.Request) {
+	gk := schema.GroupKind{Group: req.Kind.Group, Kind: req.Kind.Kind}
+	ctx, err := tag.New(ctx,
+		tag.Insert(metrics.WebhookTag, a.name),
+		tag.Insert(metrics.ModeTag, string(a.mode)),
+		tag.Insert(metrics.KindTag, gk.String()),
+		tag.Insert(metrics.NamespaceTag, req.Namespace))
+	if err != nil {
+		logger.Error(err, "error creating the tags of the bypassed denial metric", "webhook", a.name)
+		return
+	}
+	stats.Record(ctx, metrics.MWebhookBypassedDenials.M(1))
+}
+
+// InjectClient is called by controller-runtime to inject a client into the handler
+func (a *ValidationModeHandler) InjectClient(c client.Client) error {
+	injectClient, ok := a.handler.(inject.Client)
+	if !ok {
+		return nil
+	}
+	return injectClient.InjectClient(c)
+}
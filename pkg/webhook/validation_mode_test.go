@@ -0,0 +1,154 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+type fakeHandler struct {
+	response admission.Response
+}
+
+func (f *fakeHandler) Handle(_ context.Context, _ admission.Request) admission.Response {
+	return f.response
+}
+
+func TestParseValidationModes(t *testing.T) {
+	tests := []struct {
+		name      string
+		modes     map[string]string
+		expected  ValidationModes
+		shouldErr bool
+	}{
+		{
+			name:     "no modes",
+			expected: ValidationModes{},
+		},
+		{
+			name: "valid modes",
+			modes: map[string]string{
+				ImmutableFieldsValidation: "warn",
+				UnknownFieldsValidation:   "audit",
+				IAMValidation:             "enforce",
+				ResourcePolicyValidation:  "warn",
+			},
+			expected: ValidationModes{
+				ImmutableFieldsValidation: ValidationModeWarn,
+				UnknownFieldsValidation:   ValidationModeAudit,
+				IAMValidation:             ValidationModeEnforce,
+				ResourcePolicyValidation:  ValidationModeWarn,
+			},
+		},
+		{
+			name:      "unknown webhook",
+			modes:     map[string]string{"container-annotations": "warn"},
+			shouldErr: true,
+		},
+		{
+			name:      "invalid mode",
+			modes:     map[string]string{ResourceValidation: "dry-run"},
+			shouldErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			modes, err := ParseValidationModes(tc.modes)
+			if tc.shouldErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, modes); diff != "" {
+				t.Fatalf("unexpected diff: %v", diff)
+			}
+		})
+	}
+}
+
+func TestValidationModeHandler(t *testing.T) {
+	denied := admission.Errored(http.StatusForbidden, fmt.Errorf("field 'spec.foo' is immutable"))
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Group: "pubsub.cnrm.cloud.google.com", Version: "v1beta1", Kind: "PubSubTopic"},
+			Name:      "my-topic",
+			Namespace: "my-namespace",
+			Operation: admissionv1.Update,
+		},
+	}
+	tests := []struct {
+		name             string
+		mode             ValidationMode
+		response         admission.Response
+		expectedAllowed  bool
+		expectedWarnings []string
+	}{
+		{
+			name:            "denial in enforce mode",
+			mode:            ValidationModeEnforce,
+			response:        denied,
+			expectedAllowed: false,
+		},
+		{
+			name:            "denial in warn mode",
+			mode:            ValidationModeWarn,
+			response:        denied,
+			expectedAllowed: true,
+			expectedWarnings: []string{
+				"the immutable-fields webhook would have denied this request: field 'spec.foo' is immutable",
+			},
+		},
+		{
+			name:            "denial in audit mode",
+			mode:            ValidationModeAudit,
+			response:        denied,
+			expectedAllowed: true,
+		},
+		{
+			name:            "allowed request in warn mode",
+			mode:            ValidationModeWarn,
+			response:        allowedResponse,
+			expectedAllowed: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			handler := NewValidationModeHandler(&fakeHandler{response: tc.response}, ImmutableFieldsValidation, tc.mode)
+			response := handler.Handle(context.TODO(), req)
+			if response.Allowed != tc.expectedAllowed {
+				t.Fatalf("got allowed %v, want %v", response.Allowed, tc.expectedAllowed)
+			}
+			if diff := cmp.Diff(tc.expectedWarnings, response.Warnings); diff != "" {
+				t.Fatalf("unexpected diff in warnings: %v", diff)
+			}
+		})
+	}
+}
@@ -62,6 +62,7 @@ type resourceDefinition struct {
 	StatusFields        []*fieldProperties
 	SpecNestedStructs   map[string][]*fieldProperties
 	StatusNestedStructs map[string][]*fieldProperties
+	HasReconcileHistory bool
 }
 
 type svkMap struct {
@@ -361,6 +362,10 @@ func organizeStatusFieldDescriptions(descriptions []fielddesc.FieldDescription,
 		if d.ShortName == "conditions" {
 			continue // not defined in types file
 		}
+		if d.ShortName == "reconcileHistory" {
+			r.HasReconcileHistory = true
+			continue // not defined in types file
+		}
 		if d.ShortName == "[]" {
 			continue
 		}
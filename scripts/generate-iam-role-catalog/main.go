@@ -0,0 +1,106 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This program generates the catalog of predefined IAM roles bundled with Config Connector from
+// the roles returned by the IAM API. It uses the application default credentials.
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/gcp"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/util/repo"
+
+	"google.golang.org/api/iam/v1"
+)
+
+const (
+	outputFileMode = 0644
+	rolesHeader    = `# Predefined IAM roles bundled with Config Connector, one per line.
+#
+# Regenerate this file from the roles returned by the IAM API with:
+#
+#   go run ./scripts/generate-iam-role-catalog
+#
+# Only roles of the services listed in complete_services.txt are validated against the catalog.
+`
+	completeServicesHeader = `# Services all of whose predefined IAM roles are listed in roles.txt, one per line. The basic roles,
+# e.g. 'roles/owner', are listed as 'basic'.
+#
+# Regenerate this file along with roles.txt with:
+#
+#   go run ./scripts/generate-iam-role-catalog
+#
+# Roles of the other services are not validated against the catalog, unless every service of
+# roles.txt is listed here, as in a generated catalog, in which case they are rejected.
+`
+)
+
+func main() {
+	ctx := context.Background()
+	iamClient, err := gcp.NewIAMClient(ctx)
+	if err != nil {
+		log.Fatalf("error creating IAM client: %v", err)
+	}
+	roles := make([]string, 0)
+	err = iamClient.Roles.List().PageSize(1000).Pages(ctx, func(resp *iam.ListRolesResponse) error {
+		for _, r := range resp.Roles {
+			if !r.Deleted {
+				roles = append(roles, r.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("error listing predefined IAM roles: %v", err)
+	}
+	sort.Strings(roles)
+	// All the roles are listed, so every service with a role is complete.
+	serviceSet := make(map[string]bool)
+	for _, r := range roles {
+		serviceSet[serviceOf(r)] = true
+	}
+	services := make([]string, 0, len(serviceSet))
+	for s := range serviceSet {
+		services = append(services, s)
+	}
+	sort.Strings(services)
+	catalogDir := path.Join(repo.GetRootOrLogFatal(), "pkg", "controller", "iam", "rolecatalog")
+	writeList(path.Join(catalogDir, "roles.txt"), rolesHeader, roles)
+	writeList(path.Join(catalogDir, "complete_services.txt"), completeServicesHeader, services)
+}
+
+// serviceOf returns the service of the given predefined role, e.g. 'storage' for
+// 'roles/storage.admin', or 'basic' for the basic roles, e.g. 'roles/owner'.
+func serviceOf(role string) string {
+	name := strings.TrimPrefix(role, "roles/")
+	if i := strings.Index(name, "."); i >= 0 {
+		return name[:i]
+	}
+	return "basic"
+}
+
+func writeList(filePath, header string, items []string) {
+	contents := header + strings.Join(items, "\n") + "\n"
+	if err := ioutil.WriteFile(filePath, []byte(contents), outputFileMode); err != nil {
+		log.Fatalf("error writing %v: %v", filePath, err)
+	}
+	log.Printf("wrote %v items to %v", len(items), filePath)
+}